@@ -0,0 +1,163 @@
+// Command clli-compare reports CLLI inputs whose parse outcome changes
+// between parser releases, to assess upgrade impact before rollout.
+//
+// Record the behavior of the release currently in production, then run the
+// candidate release against the recorded table:
+//
+//	clli-compare -record table.json corpus.txt    # with the old release
+//	clli-compare -against table.json corpus.txt   # with the new release
+//
+// Corpus files hold one CLLI per line; standard input is read when no
+// files are given. The comparison exits with status 1 if any input changed.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the tool and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("clli-compare", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	record := fs.String("record", "", "write the behavior table for the corpus to `file`")
+	against := fs.String("against", "", "compare the corpus against the behavior table in `file`")
+	asJSON := fs.Bool("json", false, "report changes as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (*record == "") == (*against == "") {
+		fmt.Fprintln(stderr, "clli-compare: exactly one of -record or -against is required")
+		return 2
+	}
+
+	inputs, err := readCorpus(fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "clli-compare: %v\n", err)
+		return 1
+	}
+	current := clli.RecordBehavior(inputs, nil)
+
+	if *record != "" {
+		if err := writeTable(*record, current); err != nil {
+			fmt.Fprintf(stderr, "clli-compare: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "recorded %d inputs to %s\n", len(current), *record)
+		return 0
+	}
+
+	old, err := readTable(*against)
+	if err != nil {
+		fmt.Fprintf(stderr, "clli-compare: %v\n", err)
+		return 1
+	}
+	changes := clli.CompareBehavior(old, current)
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(changes); err != nil {
+			fmt.Fprintf(stderr, "clli-compare: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, c := range changes {
+			fmt.Fprintf(stdout, "%s: %s -> %s (%s)\n", c.Input, summarize(c.Old), summarize(c.New), strings.Join(c.Fields, ", "))
+		}
+		fmt.Fprintf(stdout, "%d of %d inputs changed\n", len(changes), len(current))
+	}
+
+	if len(changes) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// summarize describes a behavior in a single short phrase.
+func summarize(b clli.Behavior) string {
+	if !b.Valid {
+		return "invalid (" + b.Error + ")"
+	}
+	return b.Type + " " + strings.Join(nonEmpty(b.Place, b.Region, b.NetworkSite, b.EntityCode,
+		b.LocationCode, b.LocationID, b.CustomerCode, b.CustomerID), "/")
+}
+
+// nonEmpty returns the non-empty values.
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// readCorpus reads one input per line from the named files, or from stdin
+// when none are given. Blank lines are skipped.
+func readCorpus(files []string, stdin io.Reader) ([]string, error) {
+	if len(files) == 0 {
+		return readLines(stdin)
+	}
+
+	var inputs []string
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := readLines(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		inputs = append(inputs, lines...)
+	}
+	return inputs, nil
+}
+
+// readLines returns the non-blank lines of r.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// readTable loads a behavior table written by -record.
+func readTable(name string) ([]clli.Behavior, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var table []clli.Behavior
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return table, nil
+}
+
+// writeTable saves a behavior table as JSON.
+func writeTable(name string, table []clli.Behavior) error {
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestRecordAndCompare tests recording a table and comparing against it
+func TestRecordAndCompare(t *testing.T) {
+	dir := t.TempDir()
+	table := filepath.Join(dir, "table.json")
+	corpus := "CHCGIL01DS0\n\nCHCGILA1DS0\r\nCHCGZZ01DS0\n"
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run([]string{"-record", table}, strings.NewReader(corpus), &stdout, &stderr), stderr.String())
+	assert.Equal(t, "recorded 3 inputs to "+table+"\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"-against", table}, strings.NewReader(corpus), &stdout, &stderr))
+	assert.Equal(t, "0 of 3 inputs changed\n", stdout.String())
+
+	// Simulate an older release that accepted mixed network sites
+	var recorded []clli.Behavior
+	data, err := os.ReadFile(table)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &recorded))
+	recorded[1] = clli.Behavior{Input: "CHCGILA1DS0", Valid: true, Type: "Entity", Place: "CHCG", Region: "IL", NetworkSite: "A1", EntityCode: "DS0"}
+	data, err = json.Marshal(recorded)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(table, data, 0o644))
+
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"-against", table}, strings.NewReader(corpus), &stdout, &stderr))
+	assert.Equal(t, "CHCGILA1DS0: Entity CHCG/IL/A1/DS0 -> invalid (bad_site) "+
+		"(valid, error, type, place, region, network_site, entity_code)\n1 of 3 inputs changed\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"-json", "-against", table}, strings.NewReader(corpus), &stdout, &stderr))
+	var changes []clli.BehaviorChange
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &changes))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "CHCGILA1DS0", changes[0].Input)
+}
+
+// TestUsage tests flag validation
+func TestUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(nil, strings.NewReader(""), &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "exactly one of -record or -against")
+
+	stderr.Reset()
+	assert.Equal(t, 1, run([]string{"-against", filepath.Join(t.TempDir(), "missing.json")}, strings.NewReader(""), &stdout, &stderr))
+	assert.NotEmpty(t, stderr.String())
+}
@@ -0,0 +1,88 @@
+// Command clli-server exposes the CLLI parser as an HTTP JSON service, for
+// consumers that are not written in Go.
+//
+// Usage:
+//
+//	clli-server [-addr :8080] [-max-body 1048576] [-max-batch 10000]
+//
+// Endpoints:
+//
+//	GET  /v1/parse?code=CODE    Parse a code and return its components
+//	POST /v1/parse              Same, with a {"code": "..."} body
+//	GET  /v1/validate?code=CODE Report every problem with a code
+//	POST /v1/validate           Same, with a {"code": "..."} body
+//	POST /v1/batch              Parse {"codes": [...]} in one request
+//	GET  /v1/geo/{clli}         Return the location of a code
+//	GET  /metrics               Parse and request counters as JSON
+//
+// Invalid codes are reported with status 422 and a JSON body holding the
+// error and its machine-readable code.
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run starts the server and returns the process exit code once it stops.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("clli-server", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "listen `address`")
+	maxBody := fs.Int64("max-body", defaultMaxBody, "maximum request body size in `bytes`")
+	maxBatch := fs.Int("max-batch", defaultMaxBatch, "maximum number of codes in a batch request")
+	timeout := fs.Duration("timeout", 30*time.Second, "read and write `timeout` per request")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 || *maxBody <= 0 || *maxBatch <= 0 {
+		fs.Usage()
+		return 2
+	}
+
+	srv := newServer(Config{MaxBody: *maxBody, MaxBatch: *maxBatch})
+	expvar.Publish("clli", srv.metrics.Map())
+	expvar.Publish("clli_requests", srv.requests)
+
+	hs := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- hs.ListenAndServe() }()
+	fmt.Fprintf(stderr, "clli-server: listening on %s\n", *addr)
+
+	select {
+	case err := <-errc:
+		fmt.Fprintf(stderr, "clli-server: %v\n", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if err := hs.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "clli-server: %v\n", err)
+		return 1
+	}
+	return 0
+}
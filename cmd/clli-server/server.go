@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Default request limits
+const (
+	defaultMaxBody  = 1 << 20
+	defaultMaxBatch = 10000
+)
+
+// Config holds the server settings.
+type Config struct {
+	MaxBody  int64              // Maximum request body size in bytes; 0 selects defaultMaxBody
+	MaxBatch int                // Maximum codes per batch request; 0 selects defaultMaxBatch
+	Options  *clli.ParseOptions // Parse options; nil selects the Parse defaults
+}
+
+// server handles the HTTP API. It is safe for concurrent use.
+type server struct {
+	mux      *http.ServeMux
+	parser   *clli.Parser
+	opts     clli.ParseOptions
+	maxBody  int64
+	maxBatch int
+	metrics  *clli.ExpvarMetrics
+	requests *expvar.Map // Requests by route and status
+}
+
+// newServer creates a server from cfg. The metrics are not published, so
+// several servers can coexist in tests.
+func newServer(cfg Config) *server {
+	s := &server{
+		mux:      http.NewServeMux(),
+		parser:   clli.MustNewParser(cfg.Options),
+		maxBody:  cfg.MaxBody,
+		maxBatch: cfg.MaxBatch,
+		metrics:  clli.NewExpvarMetrics(),
+		requests: new(expvar.Map),
+	}
+	if s.maxBody <= 0 {
+		s.maxBody = defaultMaxBody
+	}
+	if s.maxBatch <= 0 {
+		s.maxBatch = defaultMaxBatch
+	}
+	s.opts = s.parser.Options()
+	s.parser.SetMetricsHook(s.metrics)
+
+	s.handle("GET /v1/parse", s.handleParse)
+	s.handle("POST /v1/parse", s.handleParse)
+	s.handle("GET /v1/validate", s.handleValidate)
+	s.handle("POST /v1/validate", s.handleValidate)
+	s.handle("POST /v1/batch", s.handleBatch)
+	s.handle("GET /v1/geo/{clli}", s.handleGeo)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle registers a handler that returns its status and response body,
+// counting each request under its route and status.
+func (s *server) handle(pattern string, h func(*http.Request) (int, any)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxBody)
+		status, body := h(r)
+		s.requests.Add(fmt.Sprintf("%s %d", pattern, status), 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Error     string `json:"error"`
+	ErrorCode string `json:"error_code,omitempty"` // clli.ErrorCode name for invalid codes
+}
+
+// codeRequest is the body of single-code POST requests.
+type codeRequest struct {
+	Code string `json:"code"`
+}
+
+// requestCode returns the code given in the query string or request body.
+// Returns a status and error body if the request is malformed.
+func requestCode(r *http.Request) (string, int, any) {
+	if r.Method == http.MethodGet {
+		if !r.URL.Query().Has("code") {
+			return "", http.StatusBadRequest, errorResponse{Error: "missing code parameter"}
+		}
+		return r.URL.Query().Get("code"), 0, nil
+	}
+	var req codeRequest
+	if status, body := decodeBody(r, &req); body != nil {
+		return "", status, body
+	}
+	return req.Code, 0, nil
+}
+
+// decodeBody decodes a JSON request body into v. Returns a status and
+// error body if it cannot be decoded.
+func decodeBody(r *http.Request, v any) (int, any) {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http.StatusRequestEntityTooLarge, errorResponse{Error: fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)}
+		}
+		return http.StatusBadRequest, errorResponse{Error: "invalid request body: " + err.Error()}
+	}
+	return 0, nil
+}
+
+// result is the outcome of parsing one code.
+type result struct {
+	Input        string `json:"input"`
+	Valid        bool   `json:"valid"`
+	Code         string `json:"code,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Place        string `json:"place,omitempty"`
+	Region       string `json:"region,omitempty"`
+	NetworkSite  string `json:"network_site,omitempty"`
+	EntityCode   string `json:"entity_code,omitempty"`
+	LocationCode string `json:"location_code,omitempty"`
+	LocationID   string `json:"location_id,omitempty"`
+	CustomerCode string `json:"customer_code,omitempty"`
+	CustomerID   string `json:"customer_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+}
+
+// newResult records the outcome of parsing input.
+func newResult(input string, c *clli.CLLI, err error) result {
+	if err != nil {
+		return result{Input: input, Error: err.Error(), ErrorCode: clli.ErrorCodeOf(err).String()}
+	}
+	return result{
+		Input:        input,
+		Valid:        true,
+		Code:         c.Format(),
+		Type:         c.Type().String(),
+		Place:        c.Place,
+		Region:       c.Region,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+	}
+}
+
+// handleParse parses one code.
+func (s *server) handleParse(r *http.Request) (int, any) {
+	code, status, body := requestCode(r)
+	if body != nil {
+		return status, body
+	}
+	c, err := s.parser.Parse(code)
+	if err != nil {
+		return http.StatusUnprocessableEntity, newResult(code, nil, err)
+	}
+	return http.StatusOK, newResult(code, c, nil)
+}
+
+// issue is the JSON form of a clli.ValidationIssue.
+type issue struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Position int    `json:"position"`
+	Message  string `json:"message"`
+}
+
+// validateResponse is the body of a validate request.
+type validateResponse struct {
+	Input  string  `json:"input"`
+	Valid  bool    `json:"valid"`
+	Code   string  `json:"code,omitempty"`
+	Issues []issue `json:"issues"`
+}
+
+// handleValidate reports every problem with one code. The status is 200
+// whether or not the code is valid.
+func (s *server) handleValidate(r *http.Request) (int, any) {
+	code, status, body := requestCode(r)
+	if body != nil {
+		return status, body
+	}
+	report := clli.Validate(code, &s.opts)
+	resp := validateResponse{Input: code, Valid: report.Valid(), Issues: []issue{}}
+	if report.CLLI != nil {
+		resp.Code = report.CLLI.Format()
+	}
+	for _, i := range report.Issues {
+		resp.Issues = append(resp.Issues, issue{Severity: string(i.Severity), Field: i.Field, Position: i.Position, Message: i.Err.Error()})
+	}
+	return http.StatusOK, resp
+}
+
+// batchRequest is the body of a batch request.
+type batchRequest struct {
+	Codes []string `json:"codes"`
+}
+
+// batchResponse is the body of a batch response.
+type batchResponse struct {
+	Results []result `json:"results"`
+	Total   int      `json:"total"`
+	Valid   int      `json:"valid"`
+	Invalid int      `json:"invalid"`
+}
+
+// handleBatch parses many codes. The status is 200 even if some codes are
+// invalid; each result reports its own outcome.
+func (s *server) handleBatch(r *http.Request) (int, any) {
+	var req batchRequest
+	if status, body := decodeBody(r, &req); body != nil {
+		return status, body
+	}
+	if len(req.Codes) > s.maxBatch {
+		return http.StatusRequestEntityTooLarge, errorResponse{Error: fmt.Sprintf("batch of %d codes exceeds limit of %d", len(req.Codes), s.maxBatch)}
+	}
+
+	resp := batchResponse{Results: make([]result, len(req.Codes)), Total: len(req.Codes)}
+	for i, code := range req.Codes {
+		c, err := s.parser.Parse(code)
+		resp.Results[i] = newResult(code, c, err)
+		if err != nil {
+			resp.Invalid++
+		} else {
+			resp.Valid++
+		}
+	}
+	return http.StatusOK, resp
+}
+
+// geoResponse is the body of a geo request. Fields the package cannot
+// resolve are omitted.
+type geoResponse struct {
+	Code      string   `json:"code"`
+	Place     string   `json:"place"`
+	Region    string   `json:"region"`
+	City      string   `json:"city,omitempty"`
+	State     string   `json:"state,omitempty"`
+	StateName string   `json:"state_name,omitempty"`
+	Country   string   `json:"country,omitempty"`
+	TimeZone  string   `json:"time_zone,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// handleGeo returns the location of the code in the path.
+func (s *server) handleGeo(r *http.Request) (int, any) {
+	code := r.PathValue("clli")
+	c, err := s.parser.Parse(code)
+	if err != nil {
+		return http.StatusUnprocessableEntity, errorResponse{Error: err.Error(), ErrorCode: clli.ErrorCodeOf(err).String()}
+	}
+
+	resp := geoResponse{
+		Code:      c.Format(),
+		Place:     c.Place,
+		Region:    c.Region,
+		City:      c.CityName(),
+		State:     c.StateCode(),
+		StateName: c.StateName(),
+		Country:   c.CountryCode(),
+	}
+	if loc := c.TimeZone(); loc != nil {
+		resp.TimeZone = loc.String()
+	}
+	if lat, lon, ok := c.Coordinates(); ok {
+		resp.Latitude, resp.Longitude = &lat, &lon
+	}
+	return http.StatusOK, resp
+}
+
+// handleMetrics writes the parse and request counters as JSON.
+func (s *server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, "{\"parses\": %s, \"requests\": %s}\n", s.metrics.Map(), s.requests)
+}
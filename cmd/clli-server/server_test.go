@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// do sends a request to a server and decodes its JSON response into a map.
+func do(t *testing.T, s *server, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	var out map[string]any
+	if rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	}
+	return rec.Code, out
+}
+
+// TestParse tests the parse endpoint with query and body input
+func TestParse(t *testing.T) {
+	s := newServer(Config{})
+
+	status, out := do(t, s, http.MethodGet, "/v1/parse?code=chcgil01ds0", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, true, out["valid"])
+	assert.Equal(t, "CHCGIL01DS0", out["code"])
+	assert.Equal(t, "Entity", out["type"])
+	assert.Equal(t, "DS0", out["entity_code"])
+
+	status, out = do(t, s, http.MethodPost, "/v1/parse", `{"code": "DLLSTXB1234"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "NonBuilding", out["type"])
+
+	status, out = do(t, s, http.MethodGet, "/v1/parse?code=CHCGZZ01DS0", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, false, out["valid"])
+	assert.Equal(t, "bad_region", out["error_code"])
+
+	status, _ = do(t, s, http.MethodGet, "/v1/parse", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = do(t, s, http.MethodPost, "/v1/parse", `{"cod": "CHCGIL01DS0"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = do(t, s, http.MethodDelete, "/v1/parse", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+// TestValidate tests that the validate endpoint reports every issue
+func TestValidate(t *testing.T) {
+	s := newServer(Config{})
+
+	status, out := do(t, s, http.MethodGet, "/v1/validate?code=CHCGIL01DS0", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, true, out["valid"])
+	assert.Empty(t, out["issues"])
+
+	status, out = do(t, s, http.MethodPost, "/v1/validate", `{"code": "CH1GZZ01DS0"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, false, out["valid"])
+	require.Len(t, out["issues"], 2)
+	issues := out["issues"].([]any)
+	assert.Equal(t, "place", issues[0].(map[string]any)["field"])
+	assert.Equal(t, "region", issues[1].(map[string]any)["field"])
+}
+
+// TestBatch tests the batch endpoint and its size limits
+func TestBatch(t *testing.T) {
+	s := newServer(Config{MaxBatch: 3, MaxBody: 100})
+
+	status, out := do(t, s, http.MethodPost, "/v1/batch", `{"codes": ["CHCGIL01DS0", "BAD", "DLLSTXB1234"]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.EqualValues(t, 3, out["total"])
+	assert.EqualValues(t, 2, out["valid"])
+	assert.EqualValues(t, 1, out["invalid"])
+	results := out["results"].([]any)
+	require.Len(t, results, 3)
+	assert.Equal(t, "length", results[1].(map[string]any)["error_code"])
+
+	status, _ = do(t, s, http.MethodPost, "/v1/batch", `{"codes": ["A", "B", "C", "D"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+
+	status, out = do(t, s, http.MethodPost, "/v1/batch", `{"codes": ["`+strings.Repeat("A", 200)+`"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, out["error"], "100 bytes")
+}
+
+// TestGeo tests the geo endpoint
+func TestGeo(t *testing.T) {
+	clli.SetCoordinateResolver(clli.NewResolver(clli.NewDataset("test", []clli.PlaceRecord{
+		{Place: "CHCG", Region: "IL", City: "Chicago", Latitude: 41.8781, Longitude: -87.6298, HasCoordinates: true},
+	})))
+	t.Cleanup(func() { clli.SetCoordinateResolver(nil) })
+	s := newServer(Config{})
+
+	status, out := do(t, s, http.MethodGet, "/v1/geo/CHCGIL01DS0", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "CHCGIL01DS0", out["code"])
+	assert.Equal(t, "Chicago", out["city"])
+	assert.Equal(t, "IL", out["state"])
+	assert.Equal(t, "US", out["country"])
+	assert.Equal(t, "America/Chicago", out["time_zone"])
+	assert.Equal(t, 41.8781, out["latitude"])
+	assert.Equal(t, -87.6298, out["longitude"])
+
+	status, out = do(t, s, http.MethodGet, "/v1/geo/CHCGZZ01DS0", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "bad_region", out["error_code"])
+}
+
+// TestMetrics tests that requests and parses are counted
+func TestMetrics(t *testing.T) {
+	s := newServer(Config{})
+	do(t, s, http.MethodGet, "/v1/parse?code=CHCGIL01DS0", "")
+	do(t, s, http.MethodGet, "/v1/parse?code=CHCGZZ01DS0", "")
+
+	status, out := do(t, s, http.MethodGet, "/metrics", "")
+	assert.Equal(t, http.StatusOK, status)
+	parses := out["parses"].(map[string]any)
+	assert.EqualValues(t, 2, parses["parses"])
+	assert.EqualValues(t, 1, parses["failures"])
+	requests := out["requests"].(map[string]any)
+	assert.EqualValues(t, 1, requests["GET /v1/parse 200"])
+	assert.EqualValues(t, 1, requests["GET /v1/parse 422"])
+}
+
+// TestRunUsage tests that invalid flags are rejected before listening
+func TestRunUsage(t *testing.T) {
+	var stderr strings.Builder
+	assert.Equal(t, 2, run([]string{"-max-batch", "0"}, &stderr))
+	assert.Equal(t, 2, run([]string{"extra"}, &stderr))
+}
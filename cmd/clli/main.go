@@ -0,0 +1,556 @@
+// Command clli inspects CLLI codes and the rules the parser enforces.
+//
+// Usage:
+//
+//	clli parse [-o format] [-preset NAME] CODE...     Parse codes and print their components
+//	clli validate [-o format] [-preset NAME] CODE...  Report whether codes are valid
+//	clli explain [-o format] [-preset NAME] CODE...   Explain each component of codes
+//	clli batch [-o format] [-preset NAME] [FILE...]   Parse a newline- or comma-delimited list
+//	clli diff [-o format] [-preset NAME] OLD NEW      Report codes added and removed between inventories
+//	clli report [-o text|markdown|html] [-title T] [-preset NAME] [FILE...]
+//	                                                  Summarize a list as an audit report
+//	clli rules                                        Print the validation rule catalog as JSON
+//
+// Except for report, the output format is one of table (the default), json
+// or csv. Commands that check codes exit with status 1 if any code is
+// invalid; diff exits with status 1 if the inventories differ. The -preset
+// flag parses codes with one of the clli.Presets, such as StrictTelcordia
+// or Lenient, instead of the defaults of clli.Parse.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+	"github.com/dbitech/go-clli/pkg/clli/report"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// usage is printed for missing or unknown subcommands.
+const usage = `usage: clli <command> [-o table|json|csv] [-preset NAME] [arguments]
+
+Commands:
+  parse CODE...       Parse codes and print their components
+  validate CODE...    Report whether codes are valid
+  explain CODE...     Explain each component of codes
+  batch [FILE...]     Parse a newline- or comma-delimited list from files or stdin
+  diff OLD NEW        Report codes added and removed between two inventory files
+  report [FILE...]    Summarize a list as a text, Markdown (-o markdown) or HTML (-o html) report
+  rules               Print the validation rule catalog as JSON
+`
+
+// errUsage reports invalid command-line arguments.
+var errUsage = errors.New("usage")
+
+// run executes a subcommand and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	var invalid bool
+	switch args[0] {
+	case "rules":
+		err = runRules(stdout)
+	case "parse":
+		invalid, err = runParse(args[1:], stdout, stderr, false)
+	case "validate":
+		invalid, err = runParse(args[1:], stdout, stderr, true)
+	case "explain":
+		invalid, err = runExplain(args[1:], stdout, stderr)
+	case "batch":
+		invalid, err = runBatch(args[1:], stdin, stdout, stderr)
+	case "diff":
+		invalid, err = runDiff(args[1:], stdout, stderr)
+	case "report":
+		invalid, err = runReport(args[1:], stdin, stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "clli: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	switch {
+	case errors.Is(err, errUsage):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "clli: %v\n", err)
+		return 1
+	case invalid:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// runRules prints the validation rule catalog.
+func runRules(stdout io.Writer) error {
+	data, err := clli.RulesJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s\n", data)
+	return nil
+}
+
+// runParse parses the codes given as arguments and prints the results,
+// limited to validity when brief is set.
+func runParse(args []string, stdout, stderr io.Writer, brief bool) (bool, error) {
+	format, opts, codes, err := parseFlags(args, stderr)
+	if err != nil {
+		return false, err
+	}
+	if len(codes) == 0 {
+		fmt.Fprint(stderr, usage)
+		return false, errUsage
+	}
+
+	results := make([]result, len(codes))
+	for i, code := range codes {
+		c, err := clli.ParseWithOptions(code, opts)
+		results[i] = newResult(code, c, err)
+	}
+	return anyInvalid(results), writeResults(stdout, format, results, brief)
+}
+
+// runBatch parses codes read from files, or stdin when none are given.
+func runBatch(args []string, stdin io.Reader, stdout, stderr io.Writer) (bool, error) {
+	format, opts, files, err := parseFlags(args, stderr)
+	if err != nil {
+		return false, err
+	}
+
+	inputs, codes, errs, err := readBatch(files, stdin, opts)
+	if err != nil {
+		return false, err
+	}
+	results := make([]result, len(inputs))
+	for i := range inputs {
+		results[i] = newResult(inputs[i], codes[i], errs[i])
+	}
+
+	if err := writeResults(stdout, format, results, false); err != nil {
+		return false, err
+	}
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+		}
+	}
+	fmt.Fprintf(stderr, "%d codes, %d invalid\n", len(results), invalid)
+	return invalid > 0, nil
+}
+
+// runReport parses codes read from files, or stdin when none are given,
+// and prints a summary report.
+func runReport(args []string, stdin io.Reader, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("clli report", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("o", "text", "output `format`: text, markdown or html")
+	title := fs.String("title", report.DefaultTitle, "report `title`")
+	preset := fs.String("preset", "", "parse with the named options `preset`, such as StrictTelcordia or Lenient")
+	if err := fs.Parse(args); err != nil {
+		return false, errUsage
+	}
+	switch *format {
+	case "text", "markdown", "html":
+	default:
+		fmt.Fprintf(stderr, "clli: unknown report format %q\n", *format)
+		return false, errUsage
+	}
+
+	opts, err := presetOptions(*preset, stderr)
+	if err != nil {
+		return false, err
+	}
+
+	inputs, codes, errs, err := readBatch(fs.Args(), stdin, opts)
+	if err != nil {
+		return false, err
+	}
+	r := report.New(*title, inputs, codes, errs)
+	return r.Invalid > 0, r.Render(stdout, *format)
+}
+
+// readBatch scans the codes in files, or stdin when none are given, with
+// opts, returning each input with its parse result.
+func readBatch(files []string, stdin io.Reader, opts *clli.ParseOptions) (inputs []string, codes []*clli.CLLI, errs []error, err error) {
+	scan := func(r io.Reader) error {
+		s := clli.NewScanner(r)
+		s.Options = opts
+		s.OnError = func(_ int, input string, err error) {
+			inputs, codes, errs = append(inputs, input), append(codes, nil), append(errs, err)
+		}
+		for s.Scan() {
+			inputs, codes, errs = append(inputs, s.Text()), append(codes, s.CLLI()), append(errs, nil)
+		}
+		return s.Err()
+	}
+
+	if len(files) == 0 {
+		if err := scan(stdin); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = scan(f)
+		f.Close()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return inputs, codes, errs, nil
+}
+
+// runDiff compares two inventory files and prints the codes added and
+// removed, grouped by building. Codes that fail to parse are reported and
+// skipped. Reports whether the inventories differ.
+func runDiff(args []string, stdout, stderr io.Writer) (bool, error) {
+	format, opts, files, err := parseFlags(args, stderr)
+	if err != nil {
+		return false, err
+	}
+	if len(files) != 2 {
+		fmt.Fprint(stderr, usage)
+		return false, errUsage
+	}
+
+	var inventories [2][]*clli.CLLI
+	for i, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return false, err
+		}
+		s := clli.NewScanner(f)
+		s.Options = opts
+		s.OnError = func(line int, input string, err error) {
+			fmt.Fprintf(stderr, "%s:%d: %v\n", name, line, err)
+		}
+		for s.Scan() {
+			inventories[i] = append(inventories[i], s.CLLI())
+		}
+		f.Close()
+		if err := s.Err(); err != nil {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	diff := clli.DiffSets(inventories[0], inventories[1])
+	var changes []change
+	for _, b := range diff.Buildings {
+		for _, c := range b.Added {
+			changes = append(changes, change{Change: "added", Code: c.Format(), Building: b.Building, BuildingStatus: b.Status.String()})
+		}
+		for _, c := range b.Removed {
+			changes = append(changes, change{Change: "removed", Code: c.Format(), Building: b.Building, BuildingStatus: b.Status.String()})
+		}
+	}
+
+	switch format {
+	case "json":
+		out := diffReport{Added: len(diff.Added), Removed: len(diff.Removed), Unchanged: diff.Unchanged, Changes: changes}
+		for _, r := range diff.Regions {
+			out.Regions = append(out.Regions, regionDiff{Region: r.Region, Status: r.Status.String(), Added: r.Added, Removed: r.Removed})
+		}
+		err = writeJSON(stdout, out)
+	case "csv":
+		w := csv.NewWriter(stdout)
+		_ = w.Write(changeHeader)
+		for _, c := range changes {
+			_ = w.Write(c.columns())
+		}
+		w.Flush()
+		err = w.Error()
+	default:
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(changeHeader, "\t")))
+		for _, c := range changes {
+			fmt.Fprintln(tw, strings.Join(c.columns(), "\t"))
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprintf(stderr, "%d added, %d removed, %d unchanged in %d buildings\n",
+		len(diff.Added), len(diff.Removed), diff.Unchanged, len(diff.Buildings))
+	return !diff.Empty(), nil
+}
+
+// change is one code added or removed between inventories.
+type change struct {
+	Change         string `json:"change"`
+	Code           string `json:"code"`
+	Building       string `json:"building"`
+	BuildingStatus string `json:"building_status"`
+}
+
+// changeHeader lists the column names matching change.columns.
+var changeHeader = []string{"change", "code", "building", "building_status"}
+
+// columns returns the change as table or CSV cells.
+func (c change) columns() []string {
+	return []string{c.Change, c.Code, c.Building, c.BuildingStatus}
+}
+
+// diffReport is the JSON form of a clli.DiffReport.
+type diffReport struct {
+	Added     int          `json:"added"`
+	Removed   int          `json:"removed"`
+	Unchanged int          `json:"unchanged"`
+	Regions   []regionDiff `json:"regions"`
+	Changes   []change     `json:"changes"`
+}
+
+// regionDiff is the JSON form of a clli.RegionDiff.
+type regionDiff struct {
+	Region  string `json:"region"`
+	Status  string `json:"status"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+}
+
+// runExplain prints an explanation of each code given as an argument.
+func runExplain(args []string, stdout, stderr io.Writer) (bool, error) {
+	format, opts, codes, err := parseFlags(args, stderr)
+	if err != nil {
+		return false, err
+	}
+	if len(codes) == 0 {
+		fmt.Fprint(stderr, usage)
+		return false, errUsage
+	}
+
+	var explanations []explanation
+	invalid := false
+	for _, code := range codes {
+		c, err := clli.ParseWithOptions(code, opts)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", code, err)
+			invalid = true
+			continue
+		}
+		explanations = append(explanations, newExplanation(c.Explain()))
+	}
+
+	switch format {
+	case "json":
+		return invalid, writeJSON(stdout, explanations)
+	case "csv":
+		w := csv.NewWriter(stdout)
+		_ = w.Write([]string{"code", "segment", "value", "start", "end", "meaning", "table_row"})
+		for _, e := range explanations {
+			for _, s := range e.Segments {
+				_ = w.Write([]string{e.Code, s.Name, s.Value, strconv.Itoa(s.Start), strconv.Itoa(s.End), s.Meaning, s.TableRow})
+			}
+		}
+		w.Flush()
+		return invalid, w.Error()
+	default:
+		for i, e := range explanations {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			fmt.Fprint(stdout, e.text)
+		}
+		return invalid, nil
+	}
+}
+
+// parseFlags parses the flags shared by the code commands, returning the
+// output format, the options of the selected preset (nil for the defaults)
+// and the remaining arguments.
+func parseFlags(args []string, stderr io.Writer) (string, *clli.ParseOptions, []string, error) {
+	fs := flag.NewFlagSet("clli", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("o", "table", "output `format`: table, json or csv")
+	preset := fs.String("preset", "", "parse with the named options `preset`, such as StrictTelcordia or Lenient")
+	if err := fs.Parse(args); err != nil {
+		return "", nil, nil, errUsage
+	}
+
+	switch *format {
+	case "table", "json", "csv":
+	default:
+		fmt.Fprintf(stderr, "clli: unknown output format %q\n", *format)
+		return "", nil, nil, errUsage
+	}
+
+	opts, err := presetOptions(*preset, stderr)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return *format, opts, fs.Args(), nil
+}
+
+// presetOptions returns the options of the named preset, or nil for the
+// defaults of clli.Parse when name is empty.
+func presetOptions(name string, stderr io.Writer) (*clli.ParseOptions, error) {
+	if name == "" {
+		return nil, nil
+	}
+	p, err := clli.LookupPreset(name)
+	if err != nil {
+		fmt.Fprintf(stderr, "clli: %v\n", err)
+		return nil, errUsage
+	}
+	return p.Options(), nil
+}
+
+// result is the outcome of parsing one code.
+type result struct {
+	Input        string `json:"input"`
+	Valid        bool   `json:"valid"`
+	Type         string `json:"type,omitempty"`
+	Place        string `json:"place,omitempty"`
+	Region       string `json:"region,omitempty"`
+	NetworkSite  string `json:"network_site,omitempty"`
+	EntityCode   string `json:"entity_code,omitempty"`
+	LocationCode string `json:"location_code,omitempty"`
+	LocationID   string `json:"location_id,omitempty"`
+	CustomerCode string `json:"customer_code,omitempty"`
+	CustomerID   string `json:"customer_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// newResult records the outcome of parsing input.
+func newResult(input string, c *clli.CLLI, err error) result {
+	if err != nil {
+		return result{Input: input, Error: err.Error()}
+	}
+	return result{
+		Input:        input,
+		Valid:        true,
+		Type:         c.Type().String(),
+		Place:        c.Place,
+		Region:       c.Region,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+	}
+}
+
+// columns returns the result as table or CSV cells, omitting the components
+// when brief is set.
+func (r result) columns(brief bool) []string {
+	if brief {
+		return []string{r.Input, strconv.FormatBool(r.Valid), r.Error}
+	}
+	return []string{r.Input, strconv.FormatBool(r.Valid), r.Type, r.Place, r.Region, r.NetworkSite,
+		r.EntityCode, r.LocationCode, r.LocationID, r.CustomerCode, r.CustomerID, r.Error}
+}
+
+// resultHeader returns the column names matching result.columns.
+func resultHeader(brief bool) []string {
+	if brief {
+		return []string{"input", "valid", "error"}
+	}
+	return []string{"input", "valid", "type", "place", "region", "network_site",
+		"entity_code", "location_code", "location_id", "customer_code", "customer_id", "error"}
+}
+
+// anyInvalid reports whether any result is invalid.
+func anyInvalid(results []result) bool {
+	for _, r := range results {
+		if !r.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// writeResults prints results in the given format.
+func writeResults(w io.Writer, format string, results []result, brief bool) error {
+	switch format {
+	case "json":
+		if brief {
+			for i := range results {
+				results[i] = result{Input: results[i].Input, Valid: results[i].Valid, Error: results[i].Error}
+			}
+		}
+		return writeJSON(w, results)
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.Write(resultHeader(brief))
+		for _, r := range results {
+			_ = cw.Write(r.columns(brief))
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(resultHeader(brief), "\t")))
+		for _, r := range results {
+			fmt.Fprintln(tw, strings.Join(r.columns(brief), "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// writeJSON prints v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// explanation is the JSON form of a clli.Explanation.
+type explanation struct {
+	Code     string    `json:"code"`
+	Type     string    `json:"type"`
+	Segments []segment `json:"segments"`
+	City     string    `json:"city,omitempty"`
+	State    string    `json:"state,omitempty"`
+	Country  string    `json:"country,omitempty"`
+	text     string
+}
+
+// segment is the JSON form of a clli.Segment.
+type segment struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Meaning  string `json:"meaning"`
+	TableRow string `json:"table_row,omitempty"`
+}
+
+// newExplanation converts an explanation for output.
+func newExplanation(e *clli.Explanation) explanation {
+	out := explanation{
+		Code:    e.Code,
+		Type:    e.Type.String(),
+		City:    e.City,
+		State:   e.State,
+		Country: e.Country,
+		text:    e.Text(),
+	}
+	for _, s := range e.Segments {
+		out.Segments = append(out.Segments, segment(s))
+	}
+	return out
+}
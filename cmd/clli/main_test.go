@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// runString runs the command with the given stdin and returns its outputs.
+func runString(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestRunRules tests the rules subcommand
+func TestRunRules(t *testing.T) {
+	code, stdout, stderr := runString(t, "", "rules")
+	require.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+
+	var rules []clli.Rule
+	require.NoError(t, json.Unmarshal([]byte(stdout), &rules))
+	assert.Equal(t, clli.Rules(), rules)
+}
+
+// TestRunParse tests the parse subcommand in each output format
+func TestRunParse(t *testing.T) {
+	code, stdout, _ := runString(t, "", "parse", "chcgil01ds0", "DLLSTXB1234")
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "INPUT"))
+	assert.Equal(t, []string{"chcgil01ds0", "true", "Entity", "CHCG", "IL", "01", "DS0"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"DLLSTXB1234", "true", "NonBuilding", "DLLS", "TX", "B", "1234"}, strings.Fields(lines[2]))
+
+	code, stdout, _ = runString(t, "", "parse", "-o", "json", "CHCGIL01DS0", "CHCGZZ01DS0")
+	assert.Equal(t, 1, code)
+	var results []result
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "DS0", results[0].EntityCode)
+	assert.False(t, results[1].Valid)
+	assert.Contains(t, results[1].Error, "invalid region code")
+
+	code, stdout, _ = runString(t, "", "parse", "-o", "csv", "MPLSMN1A2345")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "input,valid,type,place,region,network_site,entity_code,location_code,location_id,customer_code,customer_id,error\n"+
+		"MPLSMN1A2345,true,Customer,MPLS,MN,,,,,1,A2345,\n", stdout)
+}
+
+// TestRunValidate tests the validate subcommand
+func TestRunValidate(t *testing.T) {
+	code, stdout, _ := runString(t, "", "validate", "-o", "csv", "CHCGIL01DS0", "CHCGIL01QQQ")
+	assert.Equal(t, 1, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "input,valid,error", lines[0])
+	assert.Equal(t, "CHCGIL01DS0,true,", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "CHCGIL01QQQ,false,"))
+
+	code, stdout, _ = runString(t, "", "validate", "-o", "json", "CHCGIL01DS0")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `[{"input":"CHCGIL01DS0","valid":true}]`, stdout)
+}
+
+// TestRunExplain tests the explain subcommand
+func TestRunExplain(t *testing.T) {
+	code, stdout, _ := runString(t, "", "explain", "CHCGIL01DS0")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, clli.MustParse("CHCGIL01DS0").Explain().Text(), stdout)
+
+	code, stdout, _ = runString(t, "", "explain", "-o", "json", "CHCGIL01DS0")
+	assert.Equal(t, 0, code)
+	var out []explanation
+	require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+	require.Len(t, out, 1)
+	assert.Equal(t, "Entity", out[0].Type)
+	assert.Equal(t, segment{Name: "Place", Value: "CHCG", Start: 1, End: 4, Meaning: "Chicago"}, out[0].Segments[0])
+
+	code, stdout, stderr := runString(t, "", "explain", "-o", "csv", "CHCGIL01DS0", "CHCG")
+	assert.Equal(t, 1, code)
+	assert.True(t, strings.HasPrefix(stdout, "code,segment,value,start,end,meaning,table_row\nCHCGIL01DS0,Place,CHCG,1,4,Chicago,\n"))
+	assert.Contains(t, stderr, "CHCG: ")
+}
+
+// TestRunBatch tests the batch subcommand reading stdin and files
+func TestRunBatch(t *testing.T) {
+	code, stdout, stderr := runString(t, "CHCGIL01DS0,CHCGZZ01DS0\nTOROON01DS0\n", "batch", "-o", "csv")
+	assert.Equal(t, 1, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[1], "CHCGIL01DS0,true,"))
+	assert.True(t, strings.HasPrefix(lines[2], "CHCGZZ01DS0,false,"))
+	assert.True(t, strings.HasPrefix(lines[3], "TOROON01DS0,true,"))
+	assert.Equal(t, "3 codes, 1 invalid\n", stderr)
+
+	file := filepath.Join(t.TempDir(), "codes.txt")
+	require.NoError(t, os.WriteFile(file, []byte("DLLSTXB1234\n"), 0o644))
+	code, _, stderr = runString(t, "", "batch", file)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "1 codes, 0 invalid\n", stderr)
+
+	code, _, _ = runString(t, "", "batch", filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Equal(t, 1, code)
+}
+
+// TestRunDiff tests the diff subcommand
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	oldFile, newFile := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	require.NoError(t, os.WriteFile(oldFile, []byte("CHCGIL01DS0\nCHCGIL01MG1\nDLLSTX01DS0\n"), 0o644))
+	require.NoError(t, os.WriteFile(newFile, []byte("chcgil01ds0\nCHCGIL01CG0\nCHCGZZ01DS0\n"), 0o644))
+
+	code, stdout, stderr := runString(t, "", "diff", "-o", "csv", oldFile, newFile)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "change,code,building,building_status\n"+
+		"added,CHCGIL01CG0,CHCGIL01,changed\n"+
+		"removed,CHCGIL01MG1,CHCGIL01,changed\n"+
+		"removed,DLLSTX01DS0,DLLSTX01,removed\n", stdout)
+	assert.Contains(t, stderr, newFile+":3: ")
+	assert.Contains(t, stderr, "1 added, 2 removed, 1 unchanged in 2 buildings\n")
+
+	code, stdout, _ = runString(t, "", "diff", "-o", "json", oldFile, newFile)
+	assert.Equal(t, 1, code)
+	var report diffReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, []regionDiff{
+		{Region: "IL", Status: "changed", Added: 1, Removed: 1},
+		{Region: "TX", Status: "removed", Removed: 1},
+	}, report.Regions)
+
+	code, stdout, _ = runString(t, "", "diff", oldFile, oldFile)
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(stdout, "CHANGE"))
+
+	code, _, _ = runString(t, "", "diff", oldFile)
+	assert.Equal(t, 2, code)
+	code, _, _ = runString(t, "", "diff", oldFile, filepath.Join(dir, "missing.txt"))
+	assert.Equal(t, 1, code)
+}
+
+// TestRunPreset tests parsing with a named options preset
+func TestRunPreset(t *testing.T) {
+	code, _, _ := runString(t, "", "validate", "CHCGZZ01DS0")
+	assert.Equal(t, 1, code)
+
+	for _, cmd := range []string{"parse", "validate", "explain"} {
+		code, _, stderr := runString(t, "", cmd, "-preset", "lenient", "CHCGZZ01DS0")
+		assert.Equal(t, 0, code, cmd)
+		assert.Empty(t, stderr, cmd)
+	}
+
+	code, stdout, _ := runString(t, "ZZZZZZ01DS0\n", "batch", "-o", "csv", "-preset", "Lenient")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "ZZZZZZ01DS0,true,")
+
+	code, _, _ = runString(t, "", "parse", "-preset", "LegacyBell", "chcgil01ds0")
+	assert.Equal(t, 1, code)
+}
+
+// TestRunUsage tests handling of missing and unknown subcommands
+func TestRunUsage(t *testing.T) {
+	code, _, stderr := runString(t, "")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "usage: clli")
+
+	code, _, stderr = runString(t, "", "bogus")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown command "bogus"`)
+
+	code, _, stderr = runString(t, "", "parse", "-o", "xml", "CHCGIL01DS0")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown output format "xml"`)
+
+	code, _, stderr = runString(t, "", "validate", "-preset", "Bogus", "CHCGIL01DS0")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown option preset "Bogus"`)
+
+	code, _, _ = runString(t, "", "parse")
+	assert.Equal(t, 2, code)
+
+	code, stdout, _ := runString(t, "", "help")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "rules")
+}
+
+// TestRunReport tests the report subcommand
+func TestRunReport(t *testing.T) {
+	code, stdout, _ := runString(t, "CHCGIL01DS0,CHCGZZ01DS0\nTOROON01DS0\n", "report", "-o", "markdown", "-title", "Audit")
+	assert.Equal(t, 1, code)
+	assert.True(t, strings.HasPrefix(stdout, "# Audit\n\n3 records: 2 valid, 1 invalid.\n"))
+	assert.Contains(t, stdout, "| 2 | CHCGZZ01DS0 | region |")
+
+	code, stdout, _ = runString(t, "CHCGIL01DS0\n", "report")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Total: 1")
+
+	code, _, _ = runString(t, "", "report", "-o", "pdf")
+	assert.Equal(t, 2, code)
+
+	// Presets apply to reports as to the other commands
+	code, stdout, _ = runString(t, "CHCGZZ01DS0\n", "report", "-preset", "Lenient")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Total: 1")
+
+	code, _, _ = runString(t, "", "report", "-preset", "Bogus")
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,77 @@
+package clli
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// maxPseudonymAttempts bounds the retries for a pseudonym that passes the
+// original component's assignment rules.
+const maxPseudonymAttempts = 64
+
+// Anonymize deterministically maps c to a synthetic but structurally valid
+// CLLI of the same type and region, for sharing datasets with vendors
+// without exposing facility locations.
+//
+// The place, network site, location ID and customer ID are replaced with
+// pseudonyms derived from an HMAC-SHA256 under key, keeping each
+// character's class, so letters stay letters and digits stay digits. The
+// region, entity code, location code and customer code are kept. A place
+// maps to the same pseudonym wherever it appears in its region, and a
+// building wherever it appears at its place, so codes sharing a place or
+// building still share it after anonymization. Distinct codes may
+// occasionally map to the same pseudonym.
+//
+// Anyone holding key can confirm a guessed mapping, so keep it secret.
+// Returns "" if c is nil.
+func Anonymize(c *CLLI, key []byte) string {
+	if c == nil {
+		return ""
+	}
+
+	a := *c
+	a.Place = pseudonym(key, "place", c.Region, c.Place, nil)
+	a.NetworkSite = pseudonym(key, "site", c.Place+c.Region, c.NetworkSite, nil)
+
+	var locationValid func(string) bool
+	if validateLocationID(c.LocationCode, c.LocationID) == nil {
+		locationValid = func(id string) bool { return validateLocationID(c.LocationCode, id) == nil }
+	}
+	a.LocationID = pseudonym(key, "location", c.Place+c.Region+c.LocationCode, c.LocationID, locationValid)
+	a.CustomerID = pseudonym(key, "customer", c.Place+c.Region+c.NetworkSite+c.CustomerCode, c.CustomerID, nil)
+
+	return a.Format()
+}
+
+// pseudonym replaces the letters and digits of value with ones derived from
+// an HMAC of the field, scope and value under key. If valid is not nil,
+// pseudonyms are derived again until one satisfies it, up to
+// maxPseudonymAttempts.
+func pseudonym(key []byte, field, scope, value string, valid func(string) bool) string {
+	if value == "" {
+		return ""
+	}
+
+	out := []byte(value)
+	for attempt := 0; attempt < maxPseudonymAttempts; attempt++ {
+		mac := hmac.New(sha256.New, key)
+		fmt.Fprintf(mac, "%s\x00%s\x00%s\x00%d", field, scope, value, attempt)
+		sum := mac.Sum(nil)
+
+		for i := range out {
+			switch ch := value[i]; {
+			case ch >= 'A' && ch <= 'Z':
+				out[i] = 'A' + sum[i%len(sum)]%26
+			case ch >= 'a' && ch <= 'z':
+				out[i] = 'a' + sum[i%len(sum)]%26
+			case ch >= '0' && ch <= '9':
+				out[i] = '0' + sum[i%len(sum)]%10
+			}
+		}
+		if valid == nil || valid(string(out)) {
+			break
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,38 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnonymize tests that anonymized CLLIs keep their type and region
+func TestAnonymize(t *testing.T) {
+	key := []byte("test key")
+	for _, input := range []string{"CHCGIL01DS0", "CHCGIL01", "MPLSMNB1234", "MPLSMNM1234", "MPLSMN1A2345", "DLLSTX011234567", "TOROONAB"} {
+		t.Run(input, func(t *testing.T) {
+			c := MustParse(input)
+			anon := Anonymize(c, key)
+			assert.Equal(t, anon, Anonymize(c, key), "deterministic")
+			assert.NotEqual(t, anon, Anonymize(c, []byte("other key")))
+			assert.Len(t, anon, len(input))
+
+			a, err := Parse(anon)
+			require.NoError(t, err)
+			assert.Equal(t, c.Type(), a.Type())
+			assert.Equal(t, c.Region, a.Region)
+			assert.Equal(t, c.EntityCode, a.EntityCode)
+			assert.Equal(t, c.LocationCode, a.LocationCode)
+			assert.NoError(t, a.ValidateLocationID())
+		})
+	}
+
+	// Codes sharing a building still share it
+	a, b := Anonymize(MustParse("CHCGIL01DS0"), key), Anonymize(MustParse("CHCGIL01MG1"), key)
+	assert.Equal(t, a[:8], b[:8])
+	assert.NotEqual(t, a[:8], Anonymize(MustParse("CHCGIL02DS0"), key)[:8])
+	assert.Equal(t, a[:6], Anonymize(MustParse("CHCGIL02DS0"), key)[:6])
+
+	assert.Empty(t, Anonymize(nil, key))
+}
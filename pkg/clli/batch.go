@@ -0,0 +1,161 @@
+package clli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of concurrent lookups used by
+// ResolveBatch when BatchOptions.Concurrency is not set.
+const DefaultBatchConcurrency = 8
+
+// BatchOptions controls a batch geographic enrichment.
+type BatchOptions struct {
+	// Concurrency bounds the number of lookups in flight at once.
+	// Zero or less selects DefaultBatchConcurrency.
+	Concurrency int
+}
+
+// BatchResult is the geographic enrichment of a single CLLI.
+type BatchResult struct {
+	CLLI    *CLLI  // CLLI that was resolved
+	City    string // City name, or "" if unknown
+	State   string // State or province name, or "" if unknown
+	Country string // Country name, or "" if unknown
+	Err     error  // Lookup failure, or nil
+}
+
+// ResolveBatch performs geographic enrichment for many CLLIs, returning one
+// result per input in the same order. Lookups run with bounded concurrency,
+// and CLLIs sharing a place and region are resolved once.
+//
+// A failed lookup is reported in the Err field of each affected result while
+// the rest of the batch continues. The returned error joins every per-item
+// failure, so it is nil only if all lookups succeeded. A nil opts selects
+// the defaults.
+func (r *Resolver) ResolveBatch(ctx context.Context, codes []*CLLI, opts *BatchOptions) ([]BatchResult, error) {
+	concurrency := DefaultBatchConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	results := make([]BatchResult, len(codes))
+
+	// Coalesce duplicate place/region pairs into a single lookup
+	pending := make(map[placeKey][]int)
+	var keys []placeKey
+	for i, c := range codes {
+		results[i].CLLI = c
+		if c == nil {
+			results[i].Err = ErrInvalidCLLI
+			continue
+		}
+		key := placeKey{c.Place, c.Region}
+		if _, ok := pending[key]; !ok {
+			keys = append(keys, key)
+		}
+		pending[key] = append(pending[key], i)
+	}
+
+	jobs := make(chan placeKey)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				city, err := r.City(ctx, key.place, key.region)
+				state, country := getStateName(key.region), getCountryName(key.region)
+				for _, i := range pending[key] {
+					results[i].Err = err
+					if err == nil {
+						results[i].City, results[i].State, results[i].Country = city, state, country
+					}
+				}
+			}
+		}()
+	}
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	var errs []error
+	for i, res := range results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d (%s): %w", i, res.CLLI, res.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// ParseBatch parses every input with ParseWithOptions, returning one CLLI and
+// one error per input in the same order. Exactly one of codes[i] and errs[i]
+// is non-nil. A nil opts selects the defaults of ParseWithOptions.
+func ParseBatch(inputs []string, opts *ParseOptions) (codes []*CLLI, errs []error) {
+	codes = make([]*CLLI, len(inputs))
+	errs = make([]error, len(inputs))
+	for i, input := range inputs {
+		codes[i], errs[i] = ParseWithOptions(input, opts)
+	}
+	return codes, errs
+}
+
+// ParseBatchContext is ParseBatch honoring cancellation and deadlines of
+// ctx, for ingestion jobs that must stop promptly on shutdown. If ctx is
+// done before every input is parsed, it returns the results for the inputs
+// parsed so far, in order, together with ctx.Err().
+func ParseBatchContext(ctx context.Context, inputs []string, opts *ParseOptions) (codes []*CLLI, errs []error, err error) {
+	codes = make([]*CLLI, 0, len(inputs))
+	errs = make([]error, 0, len(inputs))
+	for _, input := range inputs {
+		if err := ctx.Err(); err != nil {
+			return codes, errs, err
+		}
+		c, err := ParseWithOptions(input, opts)
+		codes, errs = append(codes, c), append(errs, err)
+	}
+	return codes, errs, nil
+}
+
+// BatchSummary aggregates the outcome of a batch parse, for reconciliation
+// reports.
+type BatchSummary struct {
+	Total   int              // Number of inputs
+	Valid   int              // Inputs that parsed
+	Invalid int              // Inputs that failed to parse
+	ByType  map[CLLIType]int // Parsed CLLIs by type
+	ByField map[string]int   // Failures by ParseError field; "unknown" for other errors
+}
+
+// SummarizeBatch aggregates the results of ParseBatch.
+func SummarizeBatch(codes []*CLLI, errs []error) BatchSummary {
+	s := BatchSummary{
+		Total:   max(len(codes), len(errs)),
+		ByType:  map[CLLIType]int{},
+		ByField: map[string]int{},
+	}
+	for i := range s.Total {
+		var err error
+		if i < len(errs) {
+			err = errs[i]
+		}
+		if err == nil && i < len(codes) && codes[i] != nil {
+			s.Valid++
+			s.ByType[codes[i].Type()]++
+			continue
+		}
+
+		s.Invalid++
+		field := "unknown"
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			field = pe.Field
+		}
+		s.ByField[field]++
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package clli
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowCache is an always-missing Cache that records lookups and the
+// maximum number of concurrent Get calls.
+type slowCache struct {
+	mu       sync.Mutex
+	keys     []string
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (c *slowCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.keys = append(c.keys, key)
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+	return nil, false, nil
+}
+
+func (c *slowCache) Set(context.Context, string, []byte, time.Duration) error {
+	return nil
+}
+
+// TestResolveBatch tests batch enrichment with coalescing and bounded concurrency
+func TestResolveBatch(t *testing.T) {
+	cache := &slowCache{}
+	r := NewResolver(builtinDataset)
+	r.SetCache(cache, 0)
+
+	codes := []*CLLI{
+		MustParse("CHCGIL01DS0"),
+		MustParse("DLLSTX01DS0"),
+		MustParse("CHCGILB1234"),
+		MustParse("TOROON01DS0"),
+		MustParse("LSANCA12"),
+		MustParse("ZZZZIL01DS0"),
+	}
+
+	results, err := r.ResolveBatch(context.Background(), codes, &BatchOptions{Concurrency: 2})
+	require.NoError(t, err)
+	require.Len(t, results, len(codes))
+
+	assert.Same(t, codes[0], results[0].CLLI)
+	assert.Equal(t, "Chicago", results[0].City)
+	assert.Equal(t, "Illinois", results[0].State)
+	assert.Equal(t, "United States", results[0].Country)
+	assert.Equal(t, "Dallas", results[1].City)
+	assert.Equal(t, "Chicago", results[2].City)
+	assert.Equal(t, "Canada", results[3].Country)
+	assert.Empty(t, results[5].City)
+	assert.Equal(t, "Illinois", results[5].State)
+
+	assert.Len(t, cache.keys, 5, "duplicate place/region pairs are resolved once")
+	assert.LessOrEqual(t, cache.peak.Load(), int32(2))
+}
+
+// TestResolveBatchFailures tests partial failure reporting
+func TestResolveBatchFailures(t *testing.T) {
+	r := NewResolver(builtinDataset)
+
+	t.Run("Nil entries", func(t *testing.T) {
+		results, err := r.ResolveBatch(context.Background(), []*CLLI{MustParse("CHCGIL01DS0"), nil}, nil)
+		assert.ErrorIs(t, err, ErrInvalidCLLI)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, "Chicago", results[0].City)
+		assert.ErrorIs(t, results[1].Err, ErrInvalidCLLI)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, err := r.ResolveBatch(ctx, []*CLLI{MustParse("CHCGIL01DS0")}, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, results[0].Err, context.Canceled)
+		assert.Empty(t, results[0].City)
+	})
+
+	t.Run("Empty batch", func(t *testing.T) {
+		results, err := r.ResolveBatch(context.Background(), nil, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+// TestParseBatch tests batch parsing and its summary
+func TestParseBatch(t *testing.T) {
+	inputs := []string{"CHCGIL01DS0", "DLLSTXB1234", "MPLSMN1A2345", "CHCGZZ01DS0", "CHCGIL01QQQ", "", "TOROON01DS0"}
+
+	codes, errs := ParseBatch(inputs, nil)
+	require.Len(t, codes, len(inputs))
+	require.Len(t, errs, len(inputs))
+	for i := range inputs {
+		assert.True(t, (codes[i] == nil) != (errs[i] == nil), "input %d", i)
+	}
+	assert.Equal(t, "DLLSTXB1234", codes[1].String())
+	assert.ErrorIs(t, errs[3], ErrInvalidRegion)
+
+	s := SummarizeBatch(codes, errs)
+	assert.Equal(t, 7, s.Total)
+	assert.Equal(t, 4, s.Valid)
+	assert.Equal(t, 3, s.Invalid)
+	assert.Equal(t, map[CLLIType]int{CLLITypeEntity: 2, CLLITypeNonBuilding: 1, CLLITypeCustomer: 1}, s.ByType)
+	assert.Equal(t, map[string]int{"region": 1, "entity_code": 1, "input": 1}, s.ByField)
+
+	// Non-parser errors are counted as unknown
+	s = SummarizeBatch([]*CLLI{nil}, []error{ErrInvalidCLLI})
+	assert.Equal(t, map[string]int{"unknown": 1}, s.ByField)
+}
+
+// TestParseBatchContext tests that batch parsing stops when the context is done
+func TestParseBatchContext(t *testing.T) {
+	inputs := []string{"CHCGIL01DS0", "CHCGZZ01DS0", "TOROON01DS0"}
+
+	codes, errs, err := ParseBatchContext(context.Background(), inputs, nil)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	assert.ErrorIs(t, errs[1], ErrInvalidRegion)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	codes, errs, err = ParseBatchContext(ctx, inputs, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, codes)
+	assert.Empty(t, errs)
+}
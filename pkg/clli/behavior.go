@@ -0,0 +1,117 @@
+package clli
+
+import "sort"
+
+// Behavior is the recorded outcome of parsing one input, used to compare
+// parser versions. Tables of behaviors are stable JSON, so a table recorded
+// with one release can be compared against the current release.
+type Behavior struct {
+	Input        string `json:"input"`
+	Valid        bool   `json:"valid"`
+	Error        string `json:"error,omitempty"` // ErrorCode name when invalid
+	Type         string `json:"type,omitempty"`
+	Place        string `json:"place,omitempty"`
+	Region       string `json:"region,omitempty"`
+	NetworkSite  string `json:"network_site,omitempty"`
+	EntityCode   string `json:"entity_code,omitempty"`
+	LocationCode string `json:"location_code,omitempty"`
+	LocationID   string `json:"location_id,omitempty"`
+	CustomerCode string `json:"customer_code,omitempty"`
+	CustomerID   string `json:"customer_id,omitempty"`
+}
+
+// BehaviorOf records the outcome of a single parse.
+func BehaviorOf(input string, c *CLLI, err error) Behavior {
+	if err != nil || c == nil {
+		return Behavior{Input: input, Error: ErrorCodeOf(err).String()}
+	}
+	return Behavior{
+		Input:        input,
+		Valid:        true,
+		Type:         c.Type().String(),
+		Place:        c.Place,
+		Region:       c.Region,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+	}
+}
+
+// RecordBehavior parses every input with parse and records the outcomes.
+// A nil parse selects Parse.
+func RecordBehavior(inputs []string, parse func(string) (*CLLI, error)) []Behavior {
+	if parse == nil {
+		parse = Parse
+	}
+	table := make([]Behavior, len(inputs))
+	for i, input := range inputs {
+		c, err := parse(input)
+		table[i] = BehaviorOf(input, c, err)
+	}
+	return table
+}
+
+// BehaviorChange reports an input whose outcome differs between two tables.
+type BehaviorChange struct {
+	Input  string   `json:"input"`
+	Old    Behavior `json:"old"`
+	New    Behavior `json:"new"`
+	Fields []string `json:"fields"` // Names of the fields that changed
+}
+
+// CompareBehavior reports every input whose validity, error, classification
+// or components differ between an old and a new behavior table. Inputs
+// present in only one table are ignored. Changes are ordered by input.
+func CompareBehavior(old, new []Behavior) []BehaviorChange {
+	previous := make(map[string]Behavior, len(old))
+	for _, b := range old {
+		previous[b.Input] = b
+	}
+
+	var changes []BehaviorChange
+	for _, b := range new {
+		a, ok := previous[b.Input]
+		if !ok {
+			continue
+		}
+		if fields := a.diff(b); len(fields) > 0 {
+			changes = append(changes, BehaviorChange{Input: b.Input, Old: a, New: b, Fields: fields})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Input < changes[j].Input })
+	return changes
+}
+
+// diff returns the JSON names of the fields that differ between a and b.
+func (a Behavior) diff(b Behavior) []string {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"error", a.Error, b.Error},
+		{"type", a.Type, b.Type},
+		{"place", a.Place, b.Place},
+		{"region", a.Region, b.Region},
+		{"network_site", a.NetworkSite, b.NetworkSite},
+		{"entity_code", a.EntityCode, b.EntityCode},
+		{"location_code", a.LocationCode, b.LocationCode},
+		{"location_id", a.LocationID, b.LocationID},
+		{"customer_code", a.CustomerCode, b.CustomerCode},
+		{"customer_id", a.CustomerID, b.CustomerID},
+	}
+
+	var changed []string
+	if a.Valid != b.Valid {
+		changed = append(changed, "valid")
+	}
+	for _, f := range fields {
+		if f.old != f.new {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed
+}
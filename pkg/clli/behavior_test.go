@@ -0,0 +1,50 @@
+package clli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordBehavior tests recording parse outcomes
+func TestRecordBehavior(t *testing.T) {
+	table := RecordBehavior([]string{"chcgil01ds0", "CHCGZZ01DS0"}, nil)
+	require.Len(t, table, 2)
+
+	assert.Equal(t, Behavior{
+		Input:       "chcgil01ds0",
+		Valid:       true,
+		Type:        "Entity",
+		Place:       "CHCG",
+		Region:      "IL",
+		NetworkSite: "01",
+		EntityCode:  "DS0",
+	}, table[0])
+	assert.Equal(t, Behavior{Input: "CHCGZZ01DS0", Error: "bad_region"}, table[1])
+
+	data, err := json.Marshal(table[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"input":"CHCGZZ01DS0","valid":false,"error":"bad_region"}`, string(data))
+}
+
+// TestCompareBehavior tests reporting of changed outcomes between versions
+func TestCompareBehavior(t *testing.T) {
+	inputs := []string{"CHCGIL01DS0", "MPLSMN1AB345", "CHCGILA1DS0", "CHCGILB1234"}
+
+	// Compare the current strict parser against the non-strict behavior
+	old := RecordBehavior(inputs, func(s string) (*CLLI, error) {
+		return ParseWithOptions(s, &ParseOptions{NormalizeCase: true, TrimWhitespace: true})
+	})
+	current := RecordBehavior(append(inputs, "NEWXIL01DS0"), nil)
+
+	changes := CompareBehavior(old, current)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "CHCGILA1DS0", changes[0].Input)
+	assert.True(t, changes[0].Old.Valid)
+	assert.Equal(t, "bad_site", changes[0].New.Error)
+	assert.Equal(t, []string{"valid", "error", "type", "place", "region", "network_site", "entity_code"}, changes[0].Fields)
+
+	assert.Empty(t, CompareBehavior(current, current))
+}
@@ -0,0 +1,121 @@
+package clli
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		code     string
+		packable bool
+	}{
+		{"CHCGIL01DS0", true},
+		{"CHCGIL01", true},
+		{"CHCGILB1234", true},
+		{"CHCGIL1A2345", true},
+		{"CHCGIL12A345678", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			c := MustParse(tt.code)
+			v, err := c.Pack()
+			if !tt.packable {
+				assert.ErrorIs(t, err, ErrInvalidCLLI)
+				return
+			}
+			require.NoError(t, err)
+			got, err := Unpack(v)
+			require.NoError(t, err)
+			assert.Equal(t, c, got)
+		})
+	}
+
+	// The widest entity CLLI still fits
+	v, err := (&CLLI{Place: "ZZZZ", Region: "WY", NetworkSite: "ZZ", EntityCode: "ZZZ"}).Pack()
+	require.NoError(t, err)
+	assert.NotZero(t, v)
+
+	_, err = Unpack(0)
+	assert.ErrorIs(t, err, ErrInvalidCLLI)
+	_, err = Unpack(^uint64(0))
+	assert.Error(t, err)
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, code := range []string{"CHCGIL01DS0", "CHCGIL12A345678"} {
+		t.Run(code, func(t *testing.T) {
+			c := MustParse(code)
+			data, err := c.MarshalBinary()
+			require.NoError(t, err)
+
+			var got CLLI
+			require.NoError(t, got.UnmarshalBinary(data))
+			assert.Equal(t, *c, got)
+		})
+	}
+
+	data, err := MustParse("CHCGIL01DS0").MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, 9)
+
+	var c CLLI
+	assert.ErrorIs(t, c.UnmarshalBinary([]byte{9, 1, 2}), ErrInvalidCLLI)
+	assert.ErrorIs(t, c.UnmarshalBinary(nil), ErrEmptyInput)
+	assert.ErrorIs(t, c.UnmarshalBinary(append([]byte{binaryText}, "CHCGZZ01"...)), ErrInvalidRegion)
+}
+
+// optionParsed returns CLLIs that Parse rejects but ParseWithOptions accepts.
+func optionParsed(t *testing.T) []*CLLI {
+	t.Helper()
+	tests := []struct {
+		input string
+		opts  *ParseOptions
+	}{
+		{"LNDNUK01DS0", &ParseOptions{Strict: true, AllowInternational: true}},
+		{"CHI IL01DS0", &ParseOptions{}},
+		{"CHI IL1A2345678", &ParseOptions{}},
+	}
+	out := make([]*CLLI, len(tests))
+	for i, tt := range tests {
+		c, err := ParseWithOptions(tt.input, tt.opts)
+		require.NoError(t, err)
+		out[i] = c
+	}
+	return out
+}
+
+// TestMarshalBinaryOptions tests round trips of CLLIs accepted only with parse options
+func TestMarshalBinaryOptions(t *testing.T) {
+	for _, c := range optionParsed(t) {
+		t.Run(c.Original, func(t *testing.T) {
+			data, err := c.MarshalBinary()
+			require.NoError(t, err)
+
+			var got CLLI
+			require.NoError(t, got.UnmarshalBinary(data))
+			assert.Equal(t, c.Format(), got.Format())
+			assert.Equal(t, c.Type(), got.Type())
+			assert.Equal(t, c.IsValid(), got.IsValid())
+		})
+	}
+
+	// Other invalid components are still rejected
+	var c CLLI
+	assert.ErrorIs(t, c.UnmarshalBinary(append([]byte{binaryText}, "CHI ZZ01DS0"...)), ErrInvalidCLLI)
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	want := parseAll(t, "CHCGIL01DS0", "NYCMNYBXMCA", "CHCGIL12A345678")
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(want))
+	var got []*CLLI
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,181 @@
+package clli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Builder constructs a validated CLLI from its components, for provisioning
+// tools that generate codes rather than parse them. Set the components with
+// the With methods and call Build:
+//
+//	c, err := clli.NewBuilder().
+//		WithPlace("CHCG").
+//		WithRegion("IL").
+//		WithNetworkSite("01").
+//		WithEntityCode("DS0").
+//		Build()
+//
+// Components are trimmed and uppercased. The zero value is ready to use.
+type Builder struct {
+	components [ComponentCustomerID + 1]string
+	set        [ComponentCustomerID + 1]bool
+}
+
+// NewBuilder creates an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// with records a component value.
+func (b *Builder) with(kind ComponentKind, value string) *Builder {
+	b.components[kind] = strings.ToUpper(strings.TrimSpace(value))
+	b.set[kind] = true
+	return b
+}
+
+// WithPlace sets the 4-letter place code.
+func (b *Builder) WithPlace(place string) *Builder {
+	return b.with(ComponentPlace, place)
+}
+
+// WithRegion sets the 2-letter state or province code.
+func (b *Builder) WithRegion(region string) *Builder {
+	return b.with(ComponentRegion, region)
+}
+
+// WithNetworkSite sets the 2-character building code of an entity or customer CLLI.
+func (b *Builder) WithNetworkSite(site string) *Builder {
+	return b.with(ComponentNetworkSite, site)
+}
+
+// WithEntityCode sets the 3-character equipment code of an entity CLLI.
+func (b *Builder) WithEntityCode(code string) *Builder {
+	return b.with(ComponentEntityCode, code)
+}
+
+// WithLocationCode sets the single letter of a non-building location.
+func (b *Builder) WithLocationCode(code string) *Builder {
+	return b.with(ComponentLocationCode, code)
+}
+
+// WithLocationID sets the 4-digit identifier of a non-building location.
+func (b *Builder) WithLocationID(id string) *Builder {
+	return b.with(ComponentLocationID, id)
+}
+
+// WithCustomerCode sets the single digit of a customer location.
+func (b *Builder) WithCustomerCode(code string) *Builder {
+	return b.with(ComponentCustomerCode, code)
+}
+
+// WithCustomerID sets the identifier of a customer location.
+func (b *Builder) WithCustomerID(id string) *Builder {
+	return b.with(ComponentCustomerID, id)
+}
+
+// Build validates the components and returns the CLLI they form, using the
+// same strict validation as Parse.
+func (b *Builder) Build() (*CLLI, error) {
+	return b.BuildWithOptions(nil)
+}
+
+// BuildWithOptions validates the components and returns the CLLI they form,
+// validating the assembled code with ParseWithOptions. A nil opts selects
+// the defaults of ParseWithOptions.
+//
+// Each component that was set is first validated on its own, so errors name
+// the offending component just as ParseFragment does. Setting components of
+// more than one CLLI type, such as an entity code together with a location
+// ID, is an error.
+func (b *Builder) BuildWithOptions(opts *ParseOptions) (*CLLI, error) {
+	for kind := ComponentPlace; kind <= ComponentCustomerID; kind++ {
+		if !b.set[kind] {
+			continue
+		}
+		// Region registration is left to the parser so that
+		// AllowUnknownRegion is honored
+		if kind == ComponentRegion {
+			if err := validateRegionFormat(b.components[kind]); err != nil {
+				return nil, &ParseError{Input: b.components[kind], Field: kind.String(), Err: fmt.Errorf("%w: %w", ErrInvalidRegion, err)}
+			}
+			continue
+		}
+		if _, err := ParseFragment(kind, b.components[kind]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.checkConflicts(); err != nil {
+		return nil, err
+	}
+
+	c := &CLLI{
+		Place:        b.components[ComponentPlace],
+		Region:       b.components[ComponentRegion],
+		NetworkSite:  b.components[ComponentNetworkSite],
+		EntityCode:   b.components[ComponentEntityCode],
+		LocationCode: b.components[ComponentLocationCode],
+		LocationID:   b.components[ComponentLocationID],
+		CustomerCode: b.components[ComponentCustomerCode],
+		CustomerID:   b.components[ComponentCustomerID],
+	}
+	parsed, err := ParseWithOptions(c.Format(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The assembled code must read back as the components that built it
+	if parsed.NetworkSite != c.NetworkSite || parsed.EntityCode != c.EntityCode ||
+		parsed.LocationCode != c.LocationCode || parsed.LocationID != c.LocationID ||
+		parsed.CustomerCode != c.CustomerCode || parsed.CustomerID != c.CustomerID {
+		return nil, &ParseError{Input: c.Format(), Position: 6, Field: "classification", Err: ErrInvalidCLLI}
+	}
+
+	return parsed, nil
+}
+
+// checkConflicts reports components of different CLLI types set together.
+func (b *Builder) checkConflicts() error {
+	groups := [][]ComponentKind{
+		{ComponentEntityCode},
+		{ComponentLocationCode, ComponentLocationID},
+		{ComponentCustomerCode, ComponentCustomerID},
+	}
+
+	var first ComponentKind
+	found := false
+	for _, group := range groups {
+		for _, kind := range group {
+			if !b.set[kind] {
+				continue
+			}
+			if found && !slices.Contains(group, first) {
+				return &ParseError{
+					Input: b.components[kind],
+					Field: kind.String(),
+					Err:   fmt.Errorf("%w: %w", ErrInvalidCLLI, newMessageError(MsgBuilderConflict, kind, first)),
+				}
+			}
+			if !found {
+				first, found = kind, true
+			}
+		}
+	}
+
+	// A non-building location has no network site
+	if b.set[ComponentNetworkSite] && (b.set[ComponentLocationCode] || b.set[ComponentLocationID]) {
+		kind := ComponentLocationCode
+		if !b.set[kind] {
+			kind = ComponentLocationID
+		}
+		return &ParseError{
+			Input: b.components[ComponentNetworkSite],
+			Field: ComponentNetworkSite.String(),
+			Err:   fmt.Errorf("%w: %w", ErrInvalidCLLI, newMessageError(MsgBuilderConflict, ComponentNetworkSite, kind)),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package clli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuilder tests construction of each CLLI type from components
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *Builder
+		expected string
+		cliType  CLLIType
+	}{
+		{"entity", NewBuilder().WithPlace("chcg").WithRegion("IL").WithNetworkSite("01").WithEntityCode("ds0"), "CHCGIL01DS0", CLLITypeEntity},
+		{"building", NewBuilder().WithPlace("CHCG").WithRegion("IL").WithNetworkSite("01"), "CHCGIL01", CLLITypeNonBuilding},
+		{"non-building", NewBuilder().WithPlace("DLLS").WithRegion("TX").WithLocationCode("B").WithLocationID("1234"), "DLLSTXB1234", CLLITypeNonBuilding},
+		{"customer", NewBuilder().WithPlace("MPLS").WithRegion("MN").WithCustomerCode("1").WithCustomerID(" a2345 "), "MPLSMN1A2345", CLLITypeCustomer},
+		{"customer with site", NewBuilder().WithPlace("DLLS").WithRegion("TX").WithNetworkSite("01").WithCustomerCode("1").WithCustomerID("234567"), "DLLSTX011234567", CLLITypeCustomer},
+		{"zero value", (&Builder{}).WithPlace("TORO").WithRegion("ON").WithNetworkSite("01").WithEntityCode("DS0"), "TOROON01DS0", CLLITypeEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.builder.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, c.String())
+			assert.Equal(t, tt.cliType, c.Type())
+			assert.True(t, c.IsValid())
+		})
+	}
+}
+
+// TestBuilderErrors tests that invalid or conflicting components are rejected
+func TestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *Builder
+		field    string
+		sentinel error
+	}{
+		{"bad place", NewBuilder().WithPlace("CH1G").WithRegion("IL").WithNetworkSite("01"), "place", ErrInvalidPlace},
+		{"bad region", NewBuilder().WithPlace("CHCG").WithRegion("I1").WithNetworkSite("01"), "region", ErrInvalidRegion},
+		{"unknown region", NewBuilder().WithPlace("CHCG").WithRegion("ZZ").WithNetworkSite("01"), "region", ErrInvalidRegion},
+		{"bad entity", NewBuilder().WithPlace("CHCG").WithRegion("IL").WithNetworkSite("01").WithEntityCode("QQQ"), "entity_code", ErrInvalidEntity},
+		{"bad location ID", NewBuilder().WithPlace("DLLS").WithRegion("TX").WithLocationCode("B").WithLocationID("12"), "location_id", ErrInvalidLocation},
+		{"entity and location", NewBuilder().WithPlace("DLLS").WithRegion("TX").WithEntityCode("DS0").WithLocationID("1234"), "location_id", ErrInvalidCLLI},
+		{"site and location", NewBuilder().WithPlace("DLLS").WithRegion("TX").WithNetworkSite("01").WithLocationCode("B").WithLocationID("1234"), "network_site", ErrInvalidCLLI},
+		{"missing site", NewBuilder().WithPlace("CHCG").WithRegion("IL"), "length", ErrInvalidCLLI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.builder.Build()
+			require.Error(t, err)
+			assert.Nil(t, c)
+			assert.ErrorIs(t, err, tt.sentinel)
+
+			var pe *ParseError
+			require.True(t, errors.As(err, &pe))
+			assert.Equal(t, tt.field, pe.Field)
+		})
+	}
+}
+
+// TestBuilderWithOptions tests that build options reach the parser
+func TestBuilderWithOptions(t *testing.T) {
+	b := NewBuilder().WithPlace("LNDN").WithRegion("ZZ").WithNetworkSite("01").WithEntityCode("DS0")
+
+	_, err := b.Build()
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+
+	c, err := b.BuildWithOptions(&ParseOptions{NormalizeCase: true, TrimWhitespace: true, AllowUnknownRegion: true})
+	require.NoError(t, err)
+	assert.Equal(t, "LNDNZZ01DS0", c.String())
+}
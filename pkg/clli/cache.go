@@ -0,0 +1,124 @@
+package clli
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache stores resolver results so that remote or computed lookups are not
+// repeated. Implementations backed by shared stores such as Redis or
+// memcached let every instance of a service reuse the same enrichment results.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key. A ttl of zero or less means the entry does not expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// MemoryCache is an in-process Cache that evicts the least recently used
+// entry once it holds its maximum number of entries.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // Front is most recently used
+	now        func() time.Time
+}
+
+// memoryEntry is a single cached value.
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // Zero if the entry does not expire
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// NewMemoryCache creates a MemoryCache holding at most maxEntries entries.
+// A maxEntries of zero or less means the cache is unbounded.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the value stored under key. Expired entries are not returned.
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*memoryEntry)
+	if !e.expires.IsZero() && !m.now().Before(e.expires) {
+		m.order.Remove(el)
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+
+	m.order.MoveToFront(el)
+	return e.value, true, nil
+}
+
+// Set stores value under key, evicting the least recently used entry if the cache is full.
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = m.now().Add(ttl)
+	}
+
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value, e.expires = value, expires
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, including any that have expired
+// but not yet been evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// cachedLookup returns the value cached under key, computing and storing it
+// on a miss. Cache failures are treated as misses so that an unavailable
+// shared cache degrades to uncached lookups rather than failing enrichment.
+func cachedLookup(ctx context.Context, c Cache, ttl time.Duration, key string, compute func() (string, error)) (string, error) {
+	if c == nil {
+		return compute()
+	}
+
+	if value, ok, err := c.Get(ctx, key); err == nil && ok {
+		return string(value), nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return "", err
+	}
+	_ = c.Set(ctx, key, []byte(value), ttl)
+	return value, nil
+}
@@ -0,0 +1,113 @@
+package clli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryCache tests storage, expiry and eviction
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := NewMemoryCache(2)
+	c.now = func() time.Time { return now }
+
+	t.Run("Get and Set", func(t *testing.T) {
+		_, ok, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+		value, ok, err := c.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+		_, ok, _ := c.Get(ctx, "b")
+		assert.True(t, ok)
+
+		now = now.Add(time.Minute)
+		_, ok, _ = c.Get(ctx, "b")
+		assert.False(t, ok)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("Least recently used eviction", func(t *testing.T) {
+		require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+		_, _, _ = c.Get(ctx, "a")
+		require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+		assert.Equal(t, 2, c.Len())
+		_, ok, _ := c.Get(ctx, "b")
+		assert.False(t, ok, "b was least recently used")
+		_, ok, _ = c.Get(ctx, "a")
+		assert.True(t, ok)
+	})
+}
+
+// countingCache wraps a Cache, counting lookups and optionally failing them.
+type countingCache struct {
+	Cache
+	gets, sets int
+	fail       bool
+}
+
+func (c *countingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.gets++
+	if c.fail {
+		return nil, false, errors.New("cache unavailable")
+	}
+	return c.Cache.Get(ctx, key)
+}
+
+func (c *countingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.sets++
+	if c.fail {
+		return errors.New("cache unavailable")
+	}
+	return c.Cache.Set(ctx, key, value, ttl)
+}
+
+// TestResolverCache tests that resolver lookups go through the cache
+func TestResolverCache(t *testing.T) {
+	ctx := context.Background()
+	cache := &countingCache{Cache: NewMemoryCache(0)}
+	r := NewResolver(builtinDataset)
+	r.SetCache(cache, time.Hour)
+
+	for range 3 {
+		city, err := r.City(ctx, "CHCG", "IL")
+		require.NoError(t, err)
+		assert.Equal(t, "Chicago", city)
+	}
+	assert.Equal(t, 3, cache.gets)
+	assert.Equal(t, 1, cache.sets)
+
+	value, ok, err := cache.Get(ctx, "city/CHCG/IL")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Chicago", string(value))
+
+	t.Run("Unavailable cache falls back to lookup", func(t *testing.T) {
+		cache.fail = true
+		city, err := r.City(ctx, "DLLS", "TX")
+		require.NoError(t, err)
+		assert.Equal(t, "Dallas", city)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		r.SetCache(nil, 0)
+		cache.gets = 0
+		_, err := r.City(ctx, "CHCG", "IL")
+		require.NoError(t, err)
+		assert.Zero(t, cache.gets)
+	})
+}
@@ -0,0 +1,236 @@
+package clli
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Candidate is one structurally valid interpretation of a CLLI string.
+type Candidate struct {
+	CLLI      *CLLI // The components this interpretation yields
+	Preferred bool  // True for the interpretation Parse selects
+}
+
+// Type returns the CLLI type of this interpretation.
+func (c Candidate) Type() CLLIType {
+	return c.CLLI.Type()
+}
+
+// ParseCandidates returns every structurally valid interpretation of s
+// (entity, non-building and customer) with the components each would yield,
+// so data-quality tooling can present choices instead of relying on the
+// parser's precedence order. The interpretation Parse would return, if any,
+// is listed first and marked Preferred.
+//
+// Returns the parse error if the place, region or overall format is invalid,
+// or if no interpretation of the remainder is valid.
+func ParseCandidates(s string) ([]Candidate, error) {
+	input := strings.ToUpper(strings.TrimSpace(s))
+
+	preferred, parseErr := Parse(s)
+	if len(input) < 8 || len(input) > 15 || !isAlphanumeric(input) ||
+		validatePlace(input[0:4]) != nil || validateRegion(input[4:6]) != nil {
+		return nil, parseErr
+	}
+
+	var candidates []Candidate
+	if preferred != nil {
+		candidates = append(candidates, Candidate{CLLI: preferred, Preferred: true})
+	}
+
+	for _, c := range interpretations(input) {
+		if preferred != nil && diffComponents(c, preferred) == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{CLLI: c})
+	}
+
+	if len(candidates) == 0 {
+		return nil, parseErr
+	}
+	return candidates, nil
+}
+
+// ParseAll returns every structurally valid interpretation of input, as
+// ParseCandidates does, ordered by descending Confidence, so downstream
+// systems can apply their own context to disambiguate. Returns nil if
+// input has no valid interpretation.
+func ParseAll(input string) []*CLLI {
+	candidates, err := ParseCandidates(input)
+	if err != nil {
+		return nil
+	}
+	out := make([]*CLLI, len(candidates))
+	for i, c := range candidates {
+		out[i] = c.CLLI
+	}
+	slices.SortStableFunc(out, func(a, b *CLLI) int {
+		return cmp.Compare(b.Confidence(), a.Confidence())
+	})
+	return out
+}
+
+// Confidence returns a heuristic score in (0, 1] of how likely the code's
+// type and components are the intended reading of its characters.
+// Unambiguous codes score 1. The readings of an ambiguous code, as returned
+// by ParseAll, share a total of 1, weighted toward the reading the default
+// classification rules select and away from readings strict parsing rejects.
+func (c *CLLI) Confidence() float64 {
+	if c.readings == 0 || len(c.Original) < 8 {
+		return 1
+	}
+	remainder := c.Original[6:]
+	rs, n := ambiguousReadings(remainder)
+	selected, _, _ := classifyDefaultRule(remainder)
+	own := Classification{
+		Type:         c.cliType,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+	}
+
+	var total, weight float64
+	for _, r := range rs[:n] {
+		w := readingWeight(r, selected)
+		total += w
+		if r == own {
+			weight = w
+		}
+	}
+	if weight == 0 {
+		return 1
+	}
+	return weight / total
+}
+
+// readingWeight weighs a reading for Confidence: twice the default for the
+// reading the default rules select, and half for entity readings whose
+// mixed network site strict parsing rejects.
+func readingWeight(r, selected Classification) float64 {
+	switch {
+	case r == selected:
+		return 2
+	case r.Type == CLLITypeEntity && !isDigitsOnly(r.NetworkSite) && !isAlpha(r.NetworkSite):
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// maxReadings is the most structurally valid readings a remainder can have.
+const maxReadings = 4
+
+// interpretations enumerates the structurally valid readings of a normalized
+// input whose place and region have already been validated.
+func interpretations(input string) []*CLLI {
+	rs, n := readings(input[6:])
+	out := make([]*CLLI, n)
+	for i, r := range rs[:n] {
+		c := &CLLI{Original: input, Place: input[0:4], Region: input[4:6], valid: true}
+		r.apply(c)
+		if n > 1 {
+			c.readings = readingTypes(rs[:n])
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// readings returns the structurally valid readings of the characters after
+// the region code, and how many there are. It does not allocate.
+func readings(remainder string) (out [maxReadings]Classification, n int) {
+	// Entity: two-character network site followed by a Bell table entity code.
+	// Mixed alphanumeric sites are offered too: strict parsing rejects them for
+	// entities, but they are common in legacy data and worth presenting.
+	if len(remainder) == 5 && isAlphanumeric(remainder[0:2]) && lookupEntityTable(remainder[2:]) != nil {
+		out[n] = Classification{Type: CLLITypeEntity, NetworkSite: remainder[0:2], EntityCode: remainder[2:]}
+		n++
+	}
+
+	// Non-building: location code letter followed by a 4-digit location ID
+	if len(remainder) == 5 && isAlpha(remainder[0:1]) && isDigits(remainder[1:]) {
+		out[n] = Classification{Type: CLLITypeNonBuilding, LocationCode: remainder[0:1], LocationID: remainder[1:]}
+		n++
+	}
+
+	// Customer: customer code digit followed by a letter and digits
+	if (len(remainder) == 5 || len(remainder) == 6) &&
+		isDigit(remainder[0:1]) && isAlpha(remainder[1:2]) && isDigits(remainder[2:]) {
+		out[n] = Classification{Type: CLLITypeCustomer, CustomerCode: remainder[0:1], CustomerID: remainder[1:]}
+		n++
+	}
+
+	// Customer with network site: two-digit site followed by a 7-character tail
+	if len(remainder) == 9 && isDigits(remainder[0:2]) {
+		out[n] = Classification{Type: CLLITypeCustomer, NetworkSite: remainder[0:2], CustomerCode: remainder[2:3], CustomerID: remainder[3:]}
+		n++
+	}
+
+	return out, n
+}
+
+// ambiguousReadings returns the readings of remainder if it has more than
+// one, and how many there are, or zero. Only the 5- and 6-character
+// remainders ending in digits can be read more than one way, so others are
+// rejected without validating their components.
+func ambiguousReadings(remainder string) ([maxReadings]Classification, int) {
+	if len(remainder) != 5 && len(remainder) != 6 || !isDigits(remainder[2:]) {
+		return [maxReadings]Classification{}, 0
+	}
+	rs, n := readings(remainder)
+	if n < 2 {
+		return rs, 0
+	}
+	return rs, n
+}
+
+// readingTypes returns the types of rs, one bit per CLLIType.
+func readingTypes(rs []Classification) uint8 {
+	var types uint8
+	for _, r := range rs {
+		types |= 1 << r.Type
+	}
+	return types
+}
+
+// preferType returns the ambiguous code c reparsed as the type listed
+// first in opts.TypePreference among c's type and the types of the readings
+// opts accepts, or c itself if none is listed.
+func preferType(clli string, c *CLLI, opts *ParseOptions) *CLLI {
+	remainder := c.Original[6:]
+	rs, n := ambiguousReadings(remainder)
+	for _, t := range opts.TypePreference {
+		if t == c.cliType {
+			return c
+		}
+		for _, r := range rs[:n] {
+			if r.Type != t {
+				continue
+			}
+			o := *opts
+			o.Classifier = ClassifierFunc(func(string) (Classification, bool) { return r, true })
+			o.TypePreference, o.Trace = nil, nil
+			if p, err := parseWithOptions(clli, &o); err == nil {
+				if opts.Trace != nil {
+					tracef(opts.Trace, "remainder '%s': TypePreference ⇒ %s", remainder, t)
+				}
+				return p
+			}
+		}
+	}
+	return c
+}
+
+// isAlphanumeric checks if a string contains only uppercase letters and digits
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return len(s) > 0
+}
@@ -0,0 +1,112 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCandidates tests enumeration of interpretations
+func TestParseCandidates(t *testing.T) {
+	t.Run("Single interpretation", func(t *testing.T) {
+		candidates, err := ParseCandidates("chcgil01ds0")
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.True(t, candidates[0].Preferred)
+		assert.Equal(t, CLLITypeEntity, candidates[0].Type())
+		assert.Equal(t, "DS0", candidates[0].CLLI.EntityCode)
+	})
+
+	t.Run("Ambiguous input lists every reading", func(t *testing.T) {
+		// "A1012" reads as location A/ID 1012 or as site A1 with entity 012
+		candidates, err := ParseCandidates("CHCGILA1012")
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+
+		assert.True(t, candidates[0].Preferred)
+		assert.Equal(t, CLLITypeNonBuilding, candidates[0].Type())
+		assert.Equal(t, "A", candidates[0].CLLI.LocationCode)
+		assert.Equal(t, "1012", candidates[0].CLLI.LocationID)
+
+		assert.False(t, candidates[1].Preferred)
+		assert.Equal(t, CLLITypeEntity, candidates[1].Type())
+		assert.Equal(t, "A1", candidates[1].CLLI.NetworkSite)
+		assert.Equal(t, "012", candidates[1].CLLI.EntityCode)
+	})
+
+	t.Run("Customer with network site", func(t *testing.T) {
+		candidates, err := ParseCandidates("DLLSTX011234567")
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, CLLITypeCustomer, candidates[0].Type())
+		assert.Equal(t, "01", candidates[0].CLLI.NetworkSite)
+	})
+
+	t.Run("Invalid prefix", func(t *testing.T) {
+		candidates, err := ParseCandidates("CHCGZZ01DS0")
+		assert.ErrorIs(t, err, ErrInvalidRegion)
+		assert.Nil(t, candidates)
+	})
+
+	t.Run("No valid interpretation", func(t *testing.T) {
+		candidates, err := ParseCandidates("CHCGIL01QQQ")
+		assert.ErrorIs(t, err, ErrInvalidEntity)
+		assert.Nil(t, candidates)
+	})
+}
+
+// TestAmbiguousType tests reporting the types of ambiguous codes
+func TestAmbiguousType(t *testing.T) {
+	assert.Nil(t, MustParse("CHCGIL01DS0").AmbiguousType())
+	assert.Nil(t, MustParse("NYCMNY18").AmbiguousType())
+
+	// Both the fast and the full parse record the readings
+	for _, input := range []string{"CHCGILA1012", " chcgila1012"} {
+		c := MustParse(input)
+		assert.Equal(t, CLLITypeNonBuilding, c.Type(), input)
+		assert.Equal(t, []CLLIType{CLLITypeEntity, CLLITypeNonBuilding}, c.AmbiguousType(), input)
+	}
+}
+
+// TestTypePreference tests breaking ties between the readings of ambiguous codes
+func TestTypePreference(t *testing.T) {
+	opts := &ParseOptions{NormalizeCase: true, TrimWhitespace: true, TypePreference: []CLLIType{CLLITypeCustomer, CLLITypeEntity}}
+
+	c, err := ParseWithOptions("CHCGILA1012", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CLLITypeEntity, c.Type())
+	assert.Equal(t, "A1", c.NetworkSite)
+	assert.Equal(t, "012", c.EntityCode)
+	assert.Equal(t, []CLLIType{CLLITypeEntity, CLLITypeNonBuilding}, c.AmbiguousType())
+
+	// Unambiguous codes are classified as usual
+	c, err = ParseWithOptions("CHCGIL01DS0", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CLLITypeEntity, c.Type())
+
+	// Strict parsing rejects the mixed network site of the entity reading
+	opts.Strict = true
+	c, err = ParseWithOptions("CHCGILA1012", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CLLITypeNonBuilding, c.Type())
+}
+
+// TestParseAll tests returning every interpretation with confidence scores
+func TestParseAll(t *testing.T) {
+	all := ParseAll("chcgila1012")
+	require.Len(t, all, 2)
+	assert.Equal(t, CLLITypeNonBuilding, all[0].Type())
+	assert.InDelta(t, 0.8, all[0].Confidence(), 1e-9)
+	assert.Equal(t, CLLITypeEntity, all[1].Type())
+	assert.Equal(t, "A1", all[1].NetworkSite)
+	assert.InDelta(t, 0.2, all[1].Confidence(), 1e-9)
+	assert.Equal(t, all[0].AmbiguousType(), all[1].AmbiguousType())
+
+	all = ParseAll("CHCGIL01DS0")
+	require.Len(t, all, 1)
+	assert.Equal(t, 1.0, all[0].Confidence())
+
+	assert.Nil(t, ParseAll("CHCGZZ01DS0"))
+	assert.InDelta(t, 0.8, MustParse("CHCGILA1012").Confidence(), 1e-9)
+}
@@ -0,0 +1,106 @@
+// Package circuit parses circuit and facility identifiers that embed two
+// CLLI codes, splitting them into their A-location and Z-location
+// endpoints:
+//
+//	id, err := circuit.Parse("101/T3/NYCMNY18/CHCGIL01")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(id.A.Place, id.Z.Place) // NYCM CHCG
+//
+// A CLFI (Common Language Facility Identifier) consists of a facility
+// designation, a facility type, and the A and Z locations, optionally
+// followed by further fields such as a sub-designation. Message trunk
+// circuit IDs likewise carry traffic and trunk type fields before the
+// endpoints. Fields are separated by slashes or whitespace. The first two
+// fields that parse as 8-character building CLLIs or 11-character entity
+// CLLIs are taken as the endpoints; the fields before, between and after
+// them are kept as given.
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ErrNoEndpoints is returned when an identifier does not contain two CLLI
+// endpoints.
+var ErrNoEndpoints = errors.New("circuit: identifier does not contain two CLLI endpoints")
+
+// ID is a circuit or facility identifier split into its CLLI endpoints.
+type ID struct {
+	Original string     // The identifier as given
+	Prefix   []string   // Fields before the A location, such as the facility designation and type
+	A        *clli.CLLI // A-location endpoint
+	Infix    []string   // Fields between the A and Z locations
+	Z        *clli.CLLI // Z-location endpoint
+	Suffix   []string   // Fields after the Z location
+}
+
+// Parse splits a circuit identifier into its CLLI endpoints, parsing them
+// with clli.Parse. Returns an error wrapping ErrNoEndpoints if fewer than
+// two fields parse as CLLIs.
+func Parse(s string) (*ID, error) {
+	return ParseWithOptions(s, nil)
+}
+
+// ParseWithOptions is like Parse but parses the endpoints with the given
+// options. A nil opts selects the same defaults as clli.Parse.
+func ParseWithOptions(s string, opts *clli.ParseOptions) (*ID, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '/' || r == ' ' || r == '\t'
+	})
+
+	id := &ID{Original: s}
+	a := 0
+	for i, field := range fields {
+		if len(field) != 8 && len(field) != 11 {
+			continue
+		}
+		c, err := clli.ParseWithOptions(field, opts)
+		if err != nil {
+			continue
+		}
+		if id.A == nil {
+			id.A, id.Prefix, a = c, fields[:i], i
+			continue
+		}
+		id.Z, id.Infix, id.Suffix = c, fields[a+1:i], fields[i+1:]
+		if len(id.Suffix) == 0 {
+			id.Suffix = nil
+		}
+		if len(id.Infix) == 0 {
+			id.Infix = nil
+		}
+		if len(id.Prefix) == 0 {
+			id.Prefix = nil
+		}
+		return id, nil
+	}
+	return nil, fmt.Errorf("%s: %w", s, ErrNoEndpoints)
+}
+
+// Endpoints returns the A-location and Z-location CLLIs.
+func (id *ID) Endpoints() (a, z *clli.CLLI) {
+	return id.A, id.Z
+}
+
+// Format returns the identifier with its fields separated by slashes and
+// the endpoints in their formatted form.
+func (id *ID) Format() string {
+	fields := make([]string, 0, len(id.Prefix)+len(id.Infix)+len(id.Suffix)+2)
+	fields = append(fields, id.Prefix...)
+	fields = append(fields, id.A.Format())
+	fields = append(fields, id.Infix...)
+	fields = append(fields, id.Z.Format())
+	fields = append(fields, id.Suffix...)
+	return strings.Join(fields, "/")
+}
+
+// String returns the identifier as given.
+func (id *ID) String() string {
+	return id.Original
+}
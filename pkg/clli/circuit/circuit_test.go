@@ -0,0 +1,66 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestParse tests splitting circuit identifiers into their endpoints
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		prefix []string
+		a      string
+		infix  []string
+		z      string
+		suffix []string
+	}{
+		{"101/T3/NYCMNY18/CHCGIL01", []string{"101", "T3"}, "NYCMNY18", nil, "CHCGIL01", nil},
+		{"1001 T3  nycmny18w01 CHCGIL01DS0", []string{"1001", "T3"}, "NYCMNY18W01", nil, "CHCGIL01DS0", nil},
+		{"12/OC48/DLLSTX01/HSTNTX01/A", []string{"12", "OC48"}, "DLLSTX01", nil, "HSTNTX01", []string{"A"}},
+		{"TGN101/MF/CHCGIL01DS0/CHCGIL02DS0", []string{"TGN101", "MF"}, "CHCGIL01DS0", nil, "CHCGIL02DS0", nil},
+		{"CHCGIL01/MPLSMN02", nil, "CHCGIL01", nil, "MPLSMN02", nil},
+		{"101/T3/NYCMNY18/XX/CHCGIL01", []string{"101", "T3"}, "NYCMNY18", []string{"XX"}, "CHCGIL01", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prefix, id.Prefix)
+			assert.Equal(t, tt.a, id.A.Format())
+			assert.Equal(t, tt.infix, id.Infix)
+			assert.Equal(t, tt.z, id.Z.Format())
+			assert.Equal(t, tt.suffix, id.Suffix)
+			assert.Equal(t, tt.input, id.String())
+
+			a, z := id.Endpoints()
+			assert.Same(t, id.A, a)
+			assert.Same(t, id.Z, z)
+		})
+	}
+
+	id, err := Parse("101 T3 NYCMNY18 CHCGIL01 A")
+	require.NoError(t, err)
+	assert.Equal(t, "101/T3/NYCMNY18/CHCGIL01/A", id.Format())
+
+	// Fields between the endpoints survive a round trip
+	id, err = Parse("101/T3/NYCMNY18/XX/CHCGIL01")
+	require.NoError(t, err)
+	assert.Equal(t, "101/T3/NYCMNY18/XX/CHCGIL01", id.Format())
+}
+
+// TestParseErrors tests identifiers without two endpoints
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{"", "101/T3/NYCMNY18", "101/T3/NYCMZZ18/CHCGIL01", "12/HCGS/123456/000/SW"} {
+		_, err := Parse(input)
+		assert.ErrorIs(t, err, ErrNoEndpoints, input)
+	}
+
+	id, err := ParseWithOptions("101/T3/LNDNUK01/CHCGIL01", &clli.ParseOptions{AllowInternational: true})
+	require.NoError(t, err)
+	assert.Equal(t, "GB", id.A.CountryCode())
+}
@@ -0,0 +1,89 @@
+package clli
+
+import (
+	"sort"
+	"strings"
+)
+
+// Reverse place lookup
+// These search the loaded datasets by city name, for autocompleting city
+// entry into candidate CLLI prefixes. City names are matched
+// case-insensitively, ignoring surrounding space.
+
+// PlacesForCity returns the place codes recorded for a city in a region,
+// sorted, from DefaultResolver. An empty region matches every region.
+func PlacesForCity(city, region string) []string {
+	return defaultResolver.PlacesForCity(city, region)
+}
+
+// SearchCities returns the places whose city name starts with prefix from
+// DefaultResolver, ordered by city, region and place. The place and region
+// of each record form the first six characters of the place's CLLI codes.
+func SearchCities(prefix string) []PlaceRecord {
+	return defaultResolver.SearchCities(prefix)
+}
+
+// PlacesForCity returns the place codes recorded for a city in a region,
+// sorted. An empty region matches every region.
+func (r *Resolver) PlacesForCity(city, region string) []string {
+	city = strings.TrimSpace(city)
+	region = strings.ToUpper(region)
+
+	var places []string
+	seen := make(map[string]bool)
+	for _, rec := range r.records(func(rec PlaceRecord) bool {
+		return (region == "" || rec.Region == region) && strings.EqualFold(rec.City, city)
+	}) {
+		if !seen[rec.Place] {
+			seen[rec.Place] = true
+			places = append(places, rec.Place)
+		}
+	}
+	sort.Strings(places)
+	return places
+}
+
+// SearchCities returns the places whose city name starts with prefix,
+// ordered by city, region and place.
+func (r *Resolver) SearchCities(prefix string) []PlaceRecord {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+
+	records := r.records(func(rec PlaceRecord) bool {
+		return strings.HasPrefix(strings.ToUpper(rec.City), prefix)
+	})
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if ca, cb := strings.ToUpper(a.City), strings.ToUpper(b.City); ca != cb {
+			return ca < cb
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.Place < b.Place
+	})
+	return records
+}
+
+// records returns the records matching keep across all datasets. As with
+// LookupPlace, a place and region recorded in several datasets is taken
+// from the first.
+func (r *Resolver) records(keep func(PlaceRecord) bool) []PlaceRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []PlaceRecord
+	seen := make(map[placeKey]bool)
+	for _, d := range r.datasets {
+		for k, rec := range d.places {
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if rec.City != "" && keep(rec) {
+				rec.Dataset, rec.Snapshot = d.name, d.snapshot
+				records = append(records, rec)
+			}
+		}
+	}
+	return records
+}
@@ -0,0 +1,51 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlacesForCity tests reverse lookup of place codes by city
+func TestPlacesForCity(t *testing.T) {
+	r := NewResolver(builtinDataset, NewDataset("lab", []PlaceRecord{
+		{Place: "CHCG", Region: "IL", City: "Chicago Lab"},
+		{Place: "CHIC", Region: "IL", City: "Chicago"},
+		{Place: "CHGO", Region: "OH", City: "Chicago"},
+	}))
+
+	assert.Equal(t, []string{"CHCG", "CHIC"}, r.PlacesForCity(" chicago ", "il"))
+	assert.Equal(t, []string{"CHCG", "CHGO", "CHIC"}, r.PlacesForCity("Chicago", ""))
+	assert.Empty(t, r.PlacesForCity("Chicago Lab", "IL"), "earlier datasets take precedence")
+	assert.Empty(t, r.PlacesForCity("Nowhere", ""))
+
+	assert.Equal(t, []string{"CHCG"}, PlacesForCity("Chicago", "IL"))
+}
+
+// TestSearchCities tests city name prefix search
+func TestSearchCities(t *testing.T) {
+	r := NewResolver(NewDataset("lab", []PlaceRecord{
+		{Place: "SNDG", Region: "CA", City: "San Diego"},
+		{Place: "SNFC", Region: "CA", City: "San Francisco"},
+		{Place: "SNAN", Region: "TX", City: "San Antonio"},
+		{Place: "SPKN", Region: "WA", City: "Spokane"},
+	}))
+
+	records := r.SearchCities("san ")
+	require.Len(t, records, 3)
+	assert.Equal(t, "San Antonio", records[0].City)
+	assert.Equal(t, "SNAN", records[0].Place)
+	assert.Equal(t, "TX", records[0].Region)
+	assert.Equal(t, "lab", records[0].Dataset)
+	assert.Equal(t, "San Francisco", records[2].City)
+
+	assert.Len(t, r.SearchCities(""), 4)
+	assert.Empty(t, r.SearchCities("Zz"))
+
+	found := false
+	for _, rec := range SearchCities("New York") {
+		found = found || rec.Place == "NYCM" && rec.Region == "NY"
+	}
+	assert.True(t, found)
+}
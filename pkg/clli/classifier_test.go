@@ -0,0 +1,152 @@
+package clli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDefaultClassifier tests the standard classification rules
+func TestDefaultClassifier(t *testing.T) {
+	tests := []struct {
+		remainder string
+		expected  Classification
+	}{
+		{"01DS0", Classification{Type: CLLITypeEntity, NetworkSite: "01", EntityCode: "DS0"}},
+		{"ABDS0", Classification{Type: CLLITypeEntity, NetworkSite: "AB", EntityCode: "DS0"}},
+		{"B1234", Classification{Type: CLLITypeNonBuilding, LocationCode: "B", LocationID: "1234"}},
+		{"1A234", Classification{Type: CLLITypeCustomer, CustomerCode: "1", CustomerID: "A234"}},
+		{"011234567", Classification{Type: CLLITypeCustomer, NetworkSite: "01", CustomerCode: "1", CustomerID: "234567"}},
+		{"01", Classification{Type: CLLITypeNonBuilding, NetworkSite: "01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remainder, func(t *testing.T) {
+			result, ok := DefaultClassifier.Classify(tt.remainder)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, ok := DefaultClassifier.Classify("A")
+	assert.False(t, ok)
+}
+
+// TestCustomClassifier tests substituting a classifier through ParseOptions
+func TestCustomClassifier(t *testing.T) {
+	// Treat lettered sites without an entity code as buildings
+	buildings := ClassifierFunc(func(remainder string) (Classification, bool) {
+		if len(remainder) == 2 && isAlpha(remainder) {
+			return Classification{Type: CLLITypeNonBuilding, NetworkSite: remainder}, true
+		}
+		return Classification{}, false
+	})
+
+	t.Run("Claimed input", func(t *testing.T) {
+		result, err := ParseWithOptions("CHCGILAB", &ParseOptions{Strict: true, Classifier: buildings})
+		require.NoError(t, err)
+		assert.Equal(t, CLLITypeNonBuilding, result.Type())
+		assert.Equal(t, "AB", result.NetworkSite)
+		assert.Equal(t, "CHCGILAB", result.Format())
+	})
+
+	t.Run("Unclaimed input falls back to default", func(t *testing.T) {
+		result, err := ParseWithOptions("CHCGIL01DS0", &ParseOptions{Strict: true, Classifier: buildings})
+		require.NoError(t, err)
+		assert.Equal(t, CLLITypeEntity, result.Type())
+		assert.Equal(t, "DS0", result.EntityCode)
+	})
+
+	t.Run("Component validation still applies", func(t *testing.T) {
+		entity := ClassifierFunc(func(remainder string) (Classification, bool) {
+			return Classification{Type: CLLITypeEntity, NetworkSite: remainder[:2], EntityCode: remainder[2:]}, true
+		})
+		_, err := ParseWithOptions("CHCGILA1DS0", &ParseOptions{Strict: true, Classifier: entity})
+		assert.ErrorIs(t, err, ErrInvalidSite)
+	})
+
+	t.Run("Classification must cover the input", func(t *testing.T) {
+		truncating := ClassifierFunc(func(remainder string) (Classification, bool) {
+			return Classification{Type: CLLITypeEntity, NetworkSite: remainder[:2]}, true
+		})
+		_, err := ParseWithOptions("CHCGIL01DS0", &ParseOptions{Classifier: truncating})
+		assert.ErrorIs(t, err, ErrInvalidCLLI)
+
+		var pe *ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "classification", pe.Field)
+		assert.Equal(t, ErrCodeClassification, ErrorCodeOf(err))
+	})
+}
+
+// TestChainClassifiers tests that chained classifiers are consulted in order
+func TestChainClassifiers(t *testing.T) {
+	var calls []string
+	decline := ClassifierFunc(func(string) (Classification, bool) {
+		calls = append(calls, "decline")
+		return Classification{}, false
+	})
+	claim := ClassifierFunc(func(remainder string) (Classification, bool) {
+		calls = append(calls, "claim")
+		return Classification{Type: CLLITypeNonBuilding, NetworkSite: remainder}, true
+	})
+
+	result, ok := ChainClassifiers(decline, claim, decline).Classify("AB")
+	require.True(t, ok)
+	assert.Equal(t, CLLITypeNonBuilding, result.Type)
+	assert.Equal(t, []string{"decline", "claim"}, calls)
+
+	_, ok = ChainClassifiers().Classify("AB")
+	assert.False(t, ok)
+}
+
+// TestClassifyType tests classification without a full parse
+func TestClassifyType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CLLIType
+	}{
+		{"CHCGIL01DS0", CLLITypeEntity},
+		{" nycmnybxmca ", CLLITypeEntity},
+		{"CHCGIL01", CLLITypeNonBuilding},
+		{"DLLSTXB1234", CLLITypeNonBuilding},
+		{"MPLSMN1A2345", CLLITypeCustomer},
+		{"CHCGIL011234567", CLLITypeCustomer},
+		{"CHCGIL01DS01", CLLITypeUnknown},
+		{"CHCGIL0", CLLITypeUnknown},
+		{"CHCG-IL-01", CLLITypeUnknown},
+		{"", CLLITypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClassifyType(tt.input))
+			if c, err := Parse(tt.input); err == nil {
+				assert.Equal(t, c.Type(), ClassifyType(tt.input), "agrees with Parse")
+			}
+		})
+	}
+}
+
+// TestParseTrace tests tracing the decisions made while parsing
+func TestParseTrace(t *testing.T) {
+	trace := func(input string, classifier Classifier) string {
+		var b strings.Builder
+		_, _ = ParseWithOptions(input, &ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true, Classifier: classifier, Trace: &b})
+		return b.String()
+	}
+
+	assert.Equal(t, "input ' chcgil01ds0' normalized to 'CHCGIL01DS0'\n"+
+		"remainder '01DS0': digits site + valid entity code ⇒ Entity\n"+
+		"parsed as Entity\n", trace(" chcgil01ds0", nil))
+	assert.Equal(t, "remainder '18': site only ⇒ NonBuilding\nparsed as NonBuilding\n", trace("NYCMNY18", nil))
+	assert.Equal(t, "rejected: CHCGZZ01DS0: parse error at position 4 in field region: invalid region code\n", trace("CHCGZZ01DS0", nil))
+
+	custom := ClassifierFunc(func(remainder string) (Classification, bool) {
+		return Classification{Type: CLLITypeNonBuilding, LocationCode: remainder[:1], LocationID: remainder[1:]}, remainder[0] == 'X'
+	})
+	assert.Contains(t, trace("CHCGILX1234", custom), "remainder 'X1234': claimed by custom classifier ⇒ NonBuilding\n")
+	assert.Contains(t, trace("CHCGIL01DS0", custom), "remainder '01DS0': digits site + valid entity code ⇒ Entity\n")
+}
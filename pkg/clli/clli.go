@@ -1,10 +1,13 @@
 package clli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
+	"unicode"
 )
 
 // CLLIType represents the type of CLLI code according to Bell System Practices Section 795-100-100.
@@ -63,11 +66,17 @@ type CLLI struct {
 
 	// Customer location fields (mutually exclusive with entity)
 	CustomerCode string // 1-character customer code (optional)
-	CustomerID   string // 4-character customer ID (optional)
+	CustomerID   string // 4-6 character customer ID (optional)
 
 	// Internal fields
-	cliType CLLIType // Determined CLLI type
-	valid   bool     // Validation status
+	cliType  CLLIType // Determined CLLI type
+	valid    bool     // Validation status
+	invalid  uint16   // Components accepted without passing validation, one bit per ComponentKind
+	relaxed  uint16   // Components accepted only because of relaxed options
+	inferred uint16   // Components filled in rather than read from the input
+	readings uint8    // Types of the structurally valid readings, one bit per CLLIType, if more than one
+
+	redaction *RedactionPolicy // Redaction policy of the Parser that parsed the CLLI, if it has one
 }
 
 // Regular expressions for CLLI component validation
@@ -90,7 +99,10 @@ var (
 type ParseOptions struct {
 	// Strict enables strict validation according to Bell System standards.
 	// When true, all components must conform exactly to specification.
-	// When false, some formatting variations may be accepted.
+	// When false, codes shorter than 8 characters, unregistered regions,
+	// place codes padded with spaces and entity codes outside the Bell
+	// tables are accepted, and the nonconforming components are reported
+	// by CLLI.InvalidFields.
 	Strict bool
 
 	// StrictValidation is an alias for Strict for backward compatibility.
@@ -103,17 +115,113 @@ type ParseOptions struct {
 	// TrimWhitespace removes leading and trailing whitespace before parsing.
 	// This is enabled by default to handle common input variations.
 	TrimWhitespace bool
+
+	// AllowUnknownRegion accepts any two-letter region code, not just known
+	// US states and territories and Canadian provinces. It only applies when
+	// Strict is false.
+	AllowUnknownRegion bool
+
+	// AllowInternational also accepts the international region codes of
+	// InternationalRegionRegistry, such as "MX" and "UK", in both
+	// strict and lenient parsing.
+	AllowInternational bool
+
+	// Regions is the registry of the region codes parsing recognizes
+	// without AllowUnknownRegion. Nil selects DefaultRegionRegistry, to
+	// which RegisterRegion adds; a registry of its own keeps added codes
+	// to the parsers configured with it. It cannot be combined with
+	// AllowInternational: register the international codes a parser
+	// accepts in its registry instead.
+	Regions *RegionRegistry
+
+	// Classifier determines the type and components of the characters after
+	// the region code. Nil selects DefaultClassifier.
+	Classifier Classifier
+
+	// RequireKnownPlace rejects codes whose place and region are not in
+	// PlaceReference, catching syntactically valid but nonexistent places.
+	// Non-strict parsing accepts such codes and reports the place as
+	// invalid instead.
+	RequireKnownPlace bool
+
+	// PlaceReference is the place-code reference consulted by
+	// RequireKnownPlace, typically a Resolver over an official dataset
+	// loaded with LoadDatasetCSV. Nil selects DefaultResolver.
+	PlaceReference PlaceReference
+
+	// ValidateLocationIDs checks non-building location IDs against the
+	// assignment rules of their location code letter, as
+	// CLLI.ValidateLocationID does; carrier plans are registered with
+	// SetLocationIDRange. Strict parsing rejects IDs that break the rules;
+	// non-strict parsing reports the location ID as invalid.
+	ValidateLocationIDs bool
+
+	// StrictStructure enforces the length and type matrix of the
+	// specification instead of the permissive fallbacks of the classifier:
+	// codes must have 8 (building), 11 (entity, non-building or customer),
+	// 12 or 15 (customer) characters, a building code without an entity
+	// code must have a numeric network site, an entity network site must
+	// be all digits or all letters, a location ID must be 4 digits, and a
+	// customer code must be a digit followed by a customer ID of the layout
+	// for the code's length. It requires Strict.
+	StrictStructure bool
+
+	// ExtraValidators are additional rules checked, in order, against every
+	// successfully parsed CLLI, such as company-specific assignment rules.
+	// A non-nil error rejects the CLLI; it is returned wrapped in a
+	// ParseError with field "validator". Nil entries are skipped; Validate
+	// reports them.
+	ExtraValidators []Validator
+
+	// TypePreference breaks ties between the types of inputs that have
+	// more than one structurally valid reading, such as "CHCGILA1012"
+	// (location A1012 or site A1 with entity code 012). Of the types of
+	// the readings the other options accept, the one listed first is
+	// parsed, in place of the Classifier's choice; when none is listed,
+	// the Classifier decides as usual. CLLI.AmbiguousType reports the
+	// types of every reading either way. It cannot be combined with a
+	// custom Classifier, which decides the type itself.
+	TypePreference []CLLIType
+
+	// Trace receives a line for each decision made while parsing, such as
+	// "remainder '01DS0': digits site + valid entity code ⇒ Entity", for
+	// diagnosing how a borderline code was classified. Nil disables tracing.
+	Trace io.Writer
+}
+
+// Validator checks a parsed CLLI against rules beyond the Bell System
+// standards. Implementations must be safe for concurrent use.
+type Validator interface {
+	Validate(c *CLLI) error
+}
+
+// ValidatorFunc adapts an ordinary function to the Validator interface.
+type ValidatorFunc func(c *CLLI) error
+
+// Validate calls f(c).
+func (f ValidatorFunc) Validate(c *CLLI) error {
+	return f(c)
+}
+
+// PlaceReference looks up place codes in a reference dataset.
+// Implementations must be safe for concurrent use.
+type PlaceReference interface {
+	LookupPlace(place, region string) (PlaceRecord, bool)
 }
 
+var _ PlaceReference = (*Resolver)(nil)
+
 // Common errors
 var (
-	ErrInvalidCLLI     = errors.New("invalid CLLI format")
-	ErrInvalidPlace    = errors.New("invalid place code")
-	ErrInvalidRegion   = errors.New("invalid region code")
-	ErrInvalidSite     = errors.New("invalid network site code")
-	ErrInvalidEntity   = errors.New("invalid entity code")
-	ErrInvalidLocation = errors.New("invalid location code")
-	ErrEmptyInput      = errors.New("empty CLLI input")
+	ErrInvalidCLLI     = errors.New(englishCatalog[MsgInvalidCLLI])
+	ErrInvalidPlace    = errors.New(englishCatalog[MsgInvalidPlace])
+	ErrInvalidRegion   = errors.New(englishCatalog[MsgInvalidRegion])
+	ErrInvalidSite     = errors.New(englishCatalog[MsgInvalidSite])
+	ErrInvalidEntity   = errors.New(englishCatalog[MsgInvalidEntity])
+	ErrInvalidLocation = errors.New(englishCatalog[MsgInvalidLocation])
+	ErrInvalidCustomer = errors.New(englishCatalog[MsgInvalidCustomer])
+	ErrEmptyInput      = errors.New(englishCatalog[MsgEmptyInput])
+	ErrInvalidOptions  = errors.New(englishCatalog[MsgInvalidOptions])
 )
 
 // ParseError represents a detailed parsing error
@@ -122,18 +230,72 @@ type ParseError struct {
 	Position int    // Error position (0-based)
 	Field    string // Field name that failed
 	Err      error  // Underlying error
+
+	// Start and End locate the offending characters in Input as a 0-based,
+	// half-open range, and Value holds them. Unlike Position they count any
+	// whitespace trimmed from Input. ParseWithOptions fills them in; when
+	// End is zero, Span derives them from Position and Field.
+	Start int
+	End   int
+	Value string
 }
 
 func (e *ParseError) Error() string {
 	// Match test expectations: do not include the input string in the formatted error
-	return fmt.Sprintf("parse error at position %d in field %s: %v",
-		e.Position, e.Field, e.Err)
+	return Message(DefaultLanguage, MsgParseError, e.Position, e.Field, e.Err)
+}
+
+// Localize returns the error message in the given language.
+func (e *ParseError) Localize(lang string) string {
+	return Message(lang, MsgParseError, e.Position, e.Field, Localize(e.Err, lang))
 }
 
 func (e *ParseError) Unwrap() error {
 	return e.Err
 }
 
+// Span returns the 0-based, half-open range of the offending characters in
+// Input.
+func (e *ParseError) Span() (start, end int) {
+	if e.End > e.Start {
+		return e.Start, e.End
+	}
+	return errorSpan(e.Input, 0, len(e.Input), e.Position, e.Field)
+}
+
+// Annotate renders the input with the offending characters underlined by
+// carets and followed by the error message, for reporting errors in files
+// with many rows:
+//
+//	CHCGZZ01DS0
+//	    ^^ parse error at position 4 in field region: invalid region code
+func (e *ParseError) Annotate() string {
+	start, end := e.Span()
+	return e.Input + "\n" + strings.Repeat(" ", start) + strings.Repeat("^", max(end-start, 1)) + " " + e.Error()
+}
+
+// errorSpan returns the range of the characters of field at pos in the
+// input content input[offset:offset+n], extending it to the field's width.
+func errorSpan(input string, offset, n, pos int, field string) (start, end int) {
+	limit := min(offset+n, len(input))
+	start = min(offset+pos, limit)
+	switch field {
+	case "input", "length", "validator":
+		return offset, limit
+	case "place":
+		end = start + 4
+	case "region", "network_site":
+		end = start + 2
+	case "location_id":
+		end = start + 4
+	case "entity_code", "customer_id", "classification":
+		end = limit
+	default:
+		end = start + 1
+	}
+	return start, min(end, limit)
+}
+
 // Parse creates a new CLLI instance from a string with default strict validation.
 // This function parses Common Language Location Identifier codes according to
 // Bell System Practices Section 795-100-100.
@@ -146,7 +308,19 @@ func (e *ParseError) Unwrap() error {
 //
 // Returns a parsed CLLI struct or an error if the input is invalid.
 func Parse(clli string) (*CLLI, error) {
-	return ParseWithOptions(clli, &ParseOptions{
+	if h := CurrentMetricsHook(); h != nil {
+		return observeParse(h, func() (*CLLI, error) { return parseDefault(clli) })
+	}
+	return parseDefault(clli)
+}
+
+// parseDefault implements Parse without reporting to the metrics hook.
+func parseDefault(clli string) (*CLLI, error) {
+	var c CLLI
+	if parseFast(clli, &c) {
+		return &c, nil
+	}
+	return parseInput(clli, &ParseOptions{
 		Strict:         true,
 		NormalizeCase:  true,
 		TrimWhitespace: true,
@@ -162,6 +336,15 @@ func Parse(clli string) (*CLLI, error) {
 //
 // Returns a parsed CLLI struct or an error if parsing fails.
 func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
+	if h := CurrentMetricsHook(); h != nil {
+		return observeParse(h, func() (*CLLI, error) { return parseInput(clli, opts) })
+	}
+	return parseInput(clli, opts)
+}
+
+// parseInput implements ParseWithOptions without reporting to the metrics
+// hook.
+func parseInput(clli string, opts *ParseOptions) (*CLLI, error) {
 	// Use default options if nil provided
 	if opts == nil {
 		opts = &ParseOptions{
@@ -171,6 +354,29 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 		}
 	}
 
+	c, err := parseWithOptions(clli, opts)
+	if c != nil && c.readings != 0 && len(opts.TypePreference) > 0 {
+		c = preferType(clli, c, opts)
+	}
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.End == 0 {
+		// Locate the offending characters in the untrimmed input
+		offset, n := 0, len(clli)
+		if opts.TrimWhitespace {
+			trimmed := strings.TrimLeftFunc(clli, unicode.IsSpace)
+			offset, n = len(clli)-len(trimmed), len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+		}
+		pe.Start, pe.End = errorSpan(clli, offset, n, pe.Position, pe.Field)
+		pe.Value = clli[pe.Start:pe.End]
+	}
+	if opts.Trace != nil {
+		traceResult(opts.Trace, c, err)
+	}
+	return c, err
+}
+
+// parseWithOptions implements ParseWithOptions for non-nil opts.
+func parseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 	// Check for empty input before any processing
 	if strings.TrimSpace(clli) == "" {
 		return nil, fmt.Errorf("%s: %w", clli, &ParseError{
@@ -189,6 +395,9 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 	if opts.NormalizeCase {
 		input = strings.ToUpper(input)
 	}
+	if opts.Trace != nil && input != clli {
+		tracef(opts.Trace, "input '%s' normalized to '%s'", clli, input)
+	}
 
 	// Check overall length constraints first (before component validation)
 	// In strict mode, enforce standard CLLI minimum length of 8 characters
@@ -221,9 +430,19 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 		})
 	}
 
-	// Check for completely invalid characters (symbols, etc.) that make this not a CLLI
+	if opts.StrictStructure && !structuralLength(len(input)) {
+		return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+			Input:    clli,
+			Position: 0,
+			Field:    "length",
+			Err:      fmt.Errorf("%w: %w", ErrInvalidCLLI, newMessageError(MsgStructureLength, len(input))),
+		})
+	}
+
+	// Check for completely invalid characters (symbols, etc.) that make this not a CLLI.
+	// Non-strict parsing allows the spaces padding a short place code.
 	for i, r := range input {
-		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) && !(r == ' ' && !opts.Strict && i > 0 && i < 4) {
 			// Found a non-alphanumeric character - this should be treated as a character error
 			// regardless of position for the test expectations
 			return nil, fmt.Errorf("%s: %w", clli, &ParseError{
@@ -252,26 +471,59 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 
 	// Do not extract/validate entity code until type is determined
 
-	// Validate place component first (most specific error)
-	if err := validatePlace(place); err != nil {
+	// Components that non-strict parsing accepts without them passing
+	// validation, or only because of relaxed options
+	var invalid, relaxed uint16
+
+	// Validate place component first (most specific error). Non-strict parsing
+	// accepts short place codes padded with spaces.
+	if err := validatePlace(place); err != nil && !(!opts.Strict && isAlpha(strings.TrimRight(place, " "))) {
 		return nil, fmt.Errorf("%s: %w", clli, &ParseError{
 			Input:    clli,
 			Position: 0,
 			Field:    "place",
 			Err:      ErrInvalidPlace,
 		})
+	} else if err != nil {
+		invalid |= 1 << ComponentPlace
 	}
 
 	// Then validate region component if we have enough input
 	if len(input) > 4 {
-		if err := validateRegion(region); err != nil {
-			// If the region has symbols and we didn't catch it above, this is component-specific
-			return nil, fmt.Errorf("%s: %w", clli, &ParseError{
-				Input:    clli,
-				Position: 4,
-				Field:    "region",
-				Err:      ErrInvalidRegion,
-			})
+		if err := validateRegionIn(region, opts.regionRegistry()); err != nil && !regionAccepted(region, opts) {
+			if opts.Strict || !isAlpha(region) {
+				// If the region has symbols and we didn't catch it above, this is component-specific
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 4,
+					Field:    "region",
+					Err:      ErrInvalidRegion,
+				})
+			}
+			invalid |= 1 << ComponentRegion
+		} else if err != nil {
+			if _, ok := internationalRegionRegistry.Lookup(region); !ok || !opts.AllowInternational {
+				relaxed |= 1 << ComponentRegion
+			}
+		}
+	}
+
+	// Check the place exists in the reference dataset
+	if opts.RequireKnownPlace && len(input) >= 6 && invalid&(1<<ComponentPlace) == 0 {
+		ref := opts.PlaceReference
+		if ref == nil {
+			ref = DefaultResolver()
+		}
+		if _, ok := ref.LookupPlace(strings.TrimRight(place, " "), region); !ok {
+			if opts.Strict {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 0,
+					Field:    "place",
+					Err:      fmt.Errorf("%w: %w", ErrInvalidPlace, newMessageError(MsgPlaceUnknown, strings.TrimRight(place, " "), region)),
+				})
+			}
+			invalid |= 1 << ComponentPlace
 		}
 	}
 
@@ -339,46 +591,49 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 		Original: input,
 		Place:    strings.TrimRight(actualPlace, " "), // Remove padding spaces
 		Region:   actualRegion,
-		valid:    true,
+	}
+	if len(input) < 6 {
+		result.inferred |= 1 << ComponentRegion
 	}
 
 	// Now determine the type and populate type-specific fields
 	if len(input) >= 8 {
-		remainder := input[6:]
-
-		// Check if this is a 15-character Customer CLLI
-		if len(remainder) == 9 && isDigits(remainder[0:2]) {
-			// 15-character Customer CLLI: PPPPRRNNXXXXXXX where NN is network site, XXXXXXX is entity code
-			result.NetworkSite = remainder[0:2]
-			result.EntityCode = remainder[2:]
-			result.cliType = CLLITypeCustomer
-		} else if len(remainder) >= 5 && isDigitsOnly(remainder[0:2]) && isValidEntityCode(remainder[2:]) {
-			// Entity CLLI: PPPPRRNNXXX where NN is digits, XXX is entity code
-			result.NetworkSite = remainder[0:2]
-			result.EntityCode = remainder[2:]
-			result.cliType = CLLITypeEntity
-		} else if len(remainder) >= 5 && isAlpha(remainder[0:1]) && isDigits(remainder[1:]) {
-			// Non-building CLLI: PPPPRRXNNNN where X is location code, NNNN is location ID
-			result.LocationCode = remainder[0:1]
-			result.LocationID = remainder[1:]
-			result.cliType = CLLITypeNonBuilding
-		} else if len(remainder) >= 5 && isDigit(remainder[0:1]) && isAlpha(remainder[1:2]) && isDigits(remainder[2:]) {
-			// Customer CLLI: PPPPRRNCCCCC where N is customer code, CCCCC is customer ID
-			result.CustomerCode = remainder[0:1]
-			result.CustomerID = remainder[1:]
-			result.cliType = CLLITypeCustomer
-		} else if len(remainder) == 2 && isDigitsOnly(remainder) {
-			// Special case: 8-character CLLI (PPPPRRNN) - treat as non-building per test expectations
-			result.NetworkSite = remainder
-			result.cliType = CLLITypeNonBuilding
-		} else {
-			// Default: treat as entity with alphanumeric network site
-			if len(remainder) >= 2 {
-				result.NetworkSite = remainder[0:2]
-				if len(remainder) > 2 {
-					result.EntityCode = remainder[2:]
-				}
-				result.cliType = CLLITypeEntity
+		classification, err := classify(input[6:], opts.Classifier, opts.Trace)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+				Input:    clli,
+				Position: 6,
+				Field:    "classification",
+				Err:      err,
+			})
+		}
+		classification.apply(result)
+		if opts.StrictStructure {
+			if err := validateStructure(classification); err != nil {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 6,
+					Field:    "network_site",
+					Err:      fmt.Errorf("%w: %w", ErrInvalidSite, err),
+				})
+			}
+			if pe := validateTail(classification); pe != nil {
+				pe.Input = clli
+				pe.Position += 6
+				return nil, fmt.Errorf("%s: %w", clli, pe)
+			}
+		}
+
+		// 12-character customer CLLIs: PPPPRRNAXXXX where N is the customer
+		// code class and AXXXX is a letter followed by four digits
+		if len(input) == 12 && isDigit(input[6:7]) {
+			if err := validateCustomerTail(input[6:7], input[7:]); err != nil {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 7,
+					Field:    "customer_id",
+					Err:      fmt.Errorf("%w: %w", ErrInvalidCustomer, err),
+				})
 			}
 		}
 	} else {
@@ -389,6 +644,37 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 		result.cliType = CLLITypeNonBuilding
 	}
 
+	// Location IDs must follow the assignment rules of their letter
+	if opts.ValidateLocationIDs {
+		if err := result.ValidateLocationID(); err != nil {
+			if opts.Strict {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 7,
+					Field:    "location_id",
+					Err:      err,
+				})
+			}
+			invalid |= 1 << ComponentLocationID
+		}
+	}
+
+	// Entity network sites must be two digits or two letters; mixed sites
+	// only occur on non-building and customer CLLIs
+	if result.cliType == CLLITypeEntity && result.EntityCode != "" {
+		if err := validateNetworkSite(result.NetworkSite); err != nil {
+			if opts.Strict {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 6,
+					Field:    "network_site",
+					Err:      ErrInvalidSite,
+				})
+			}
+			relaxed |= 1 << ComponentNetworkSite
+		}
+	}
+
 	// Post-classification validation for entity codes only
 	if result.cliType == CLLITypeEntity && result.EntityCode != "" {
 		// Entity code must be 2-3 characters for entity CLLIs
@@ -400,16 +686,40 @@ func ParseWithOptions(clli string, opts *ParseOptions) (*CLLI, error) {
 				Err:      ErrInvalidEntity,
 			})
 		}
+		// Non-strict parsing accepts entity codes outside the Bell tables
 		if err := validateEntityCode(result.EntityCode); err != nil {
+			if opts.Strict {
+				return nil, fmt.Errorf("%s: %w", clli, &ParseError{
+					Input:    clli,
+					Position: 8,
+					Field:    "entity_code",
+					Err:      ErrInvalidEntity,
+				})
+			}
+			invalid |= 1 << ComponentEntityCode
+		}
+	}
+
+	result.invalid, result.relaxed = invalid, relaxed
+	result.valid = invalid == 0
+	if len(input) >= 8 {
+		rs, n := ambiguousReadings(input[6:])
+		result.readings = readingTypes(rs[:n])
+	}
+
+	for _, v := range opts.ExtraValidators {
+		if v == nil {
+			continue
+		}
+		if err := v.Validate(result); err != nil {
 			return nil, fmt.Errorf("%s: %w", clli, &ParseError{
 				Input:    clli,
-				Position: 8,
-				Field:    "entity_code",
-				Err:      ErrInvalidEntity,
+				Position: 0,
+				Field:    "validator",
+				Err:      err,
 			})
 		}
 	}
-
 	return result, nil
 }
 
@@ -474,7 +784,7 @@ func isValidEntityCode(s string) bool {
 func MustParse(clli string) *CLLI {
 	c, err := Parse(clli)
 	if err != nil {
-		panic(fmt.Sprintf("MustParse failed for input %q: %v", clli, err))
+		panic(Message(DefaultLanguage, MsgMustParseFailure, clli, err))
 	}
 	return c
 }
@@ -485,7 +795,7 @@ func MustParse(clli string) *CLLI {
 // Place codes must be 1-4 uppercase letters, typically representing a city or location.
 func validatePlace(place string) error {
 	if place == "" {
-		return fmt.Errorf("place code cannot be empty")
+		return newMessageError(MsgPlaceEmpty)
 	}
 
 	// Remove trailing spaces for validation
@@ -493,13 +803,13 @@ func validatePlace(place string) error {
 
 	// Must be exactly 4 characters for valid places
 	if len(trimmed) != 4 {
-		return fmt.Errorf("place code must be exactly 4 characters")
+		return newMessageError(MsgPlaceLength)
 	}
 
 	// Check for invalid characters (digits, special characters)
 	for _, r := range trimmed {
 		if !(r >= 'A' && r <= 'Z') {
-			return fmt.Errorf("place code contains invalid character: %c", r)
+			return newMessageError(MsgPlaceCharacter, r)
 		}
 	}
 
@@ -509,41 +819,75 @@ func validatePlace(place string) error {
 // validateRegion validates a region code component.
 // Region codes must be exactly 2 uppercase letters representing state/province codes.
 func validateRegion(region string) error {
+	return validateRegionIn(region, defaultRegionRegistry)
+}
+
+// validateRegionIn is like validateRegion but checks the region is
+// registered in r instead of the default registry.
+func validateRegionIn(region string, r *RegionRegistry) error {
+	if err := validateRegionFormat(region); err != nil {
+		return err
+	}
+
+	// Check the region is registered (US states and territories and Canadian provinces by default)
+	if _, ok := r.Lookup(region); !ok {
+		return newMessageError(MsgRegionUnknown, region)
+	}
+
+	return nil
+}
+
+// regionRegistry returns the registry of the region codes recognized by
+// parsing with o.
+func (o *ParseOptions) regionRegistry() *RegionRegistry {
+	if o != nil && o.Regions != nil {
+		return o.Regions
+	}
+	return defaultRegionRegistry
+}
+
+// regionAccepted reports whether opts accept a region code that is not in
+// the registry of opts.
+func regionAccepted(region string, opts *ParseOptions) bool {
+	if opts.AllowUnknownRegion && !opts.Strict && isAlpha(region) {
+		return true
+	}
+	if opts.AllowInternational {
+		_, ok := internationalRegionRegistry.Lookup(region)
+		return ok
+	}
+	return false
+}
+
+// validateRegionFormat checks that a region code is exactly 2 uppercase letters.
+func validateRegionFormat(region string) error {
 	if region == "" {
-		return fmt.Errorf("region code cannot be empty")
+		return newMessageError(MsgRegionEmpty)
 	}
 
 	if len(region) != 2 {
-		return fmt.Errorf("region code must be exactly 2 characters")
+		return newMessageError(MsgRegionLength)
 	}
 
 	// Check for invalid characters
 	for _, r := range region {
 		if !(r >= 'A' && r <= 'Z') {
-			return fmt.Errorf("region code contains invalid character: %c", r)
+			return newMessageError(MsgRegionCharacter, r)
 		}
 	}
 
-	// Check if it's a valid US state or Canadian province
-	validRegions := map[string]bool{
-		// US States
-		"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true,
-		"DE": true, "FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true,
-		"IA": true, "KS": true, "KY": true, "LA": true, "ME": true, "MD": true, "MA": true,
-		"MI": true, "MN": true, "MS": true, "MO": true, "MT": true, "NE": true, "NV": true,
-		"NH": true, "NJ": true, "NM": true, "NY": true, "NC": true, "ND": true, "OH": true,
-		"OK": true, "OR": true, "PA": true, "RI": true, "SC": true, "SD": true, "TN": true,
-		"TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true, "WI": true,
-		"WY": true, "DC": true,
-		// Canadian Provinces
-		"AB": true, "BC": true, "MB": true, "NB": true, "NL": true, "NT": true, "NS": true,
-		"NU": true, "ON": true, "PE": true, "QC": true, "SK": true, "YT": true,
-	}
+	return nil
+}
 
-	if !validRegions[region] {
-		return fmt.Errorf("invalid region code: %s", region)
+// validateCustomerTail validates the customer code and 5-character customer
+// identifier of a 12-character customer CLLI.
+func validateCustomerTail(code, id string) error {
+	if !isDigit(code) {
+		return newMessageError(MsgCustomerCode)
+	}
+	if len(id) != 5 || !isAlpha(id[:1]) || !isDigitsOnly(id[1:]) {
+		return newMessageError(MsgCustomerTail, id)
 	}
-
 	return nil
 }
 
@@ -551,11 +895,11 @@ func validateRegion(region string) error {
 // Network site codes must be exactly 2 characters, either all digits or all letters.
 func validateNetworkSite(site string) error {
 	if site == "" {
-		return fmt.Errorf("network site code cannot be empty")
+		return newMessageError(MsgSiteEmpty)
 	}
 
 	if len(site) != 2 {
-		return fmt.Errorf("network site code must be exactly 2 characters")
+		return newMessageError(MsgSiteLength)
 	}
 
 	// Must be all digits OR all letters, not mixed
@@ -563,13 +907,13 @@ func validateNetworkSite(site string) error {
 	isAllAlpha := isAlpha(site)
 
 	if !isAllDigits && !isAllAlpha {
-		return fmt.Errorf("network site code must be either all digits or all letters")
+		return newMessageError(MsgSiteMixed)
 	}
 
 	// Check for valid characters
 	for _, r := range site {
 		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
-			return fmt.Errorf("network site code contains invalid character: %c", r)
+			return newMessageError(MsgSiteCharacter, r)
 		}
 	}
 
@@ -580,38 +924,95 @@ func validateNetworkSite(site string) error {
 // Entity CLLI network site codes must be exactly 2 digits.
 func validateNetworkSiteDigitsOnly(site string) error {
 	if site == "" {
-		return fmt.Errorf("network site code cannot be empty")
+		return newMessageError(MsgSiteEmpty)
 	}
 
 	if len(site) != 2 {
-		return fmt.Errorf("network site code must be exactly 2 characters")
+		return newMessageError(MsgSiteLength)
 	}
 
 	// Check for digits only
 	for _, r := range site {
 		if !(r >= '0' && r <= '9') {
-			return fmt.Errorf("network site code contains invalid character: %c", r)
+			return newMessageError(MsgSiteCharacter, r)
 		}
 	}
 
 	return nil
 }
 
+// structuralLength reports whether some CLLI type has n characters under
+// ParseOptions.StrictStructure.
+func structuralLength(n int) bool {
+	return n == 8 || n == 11 || n == 12 || n == 15
+}
+
+// validateStructure checks the network site of a classification under
+// ParseOptions.StrictStructure: building codes without an entity code need
+// a numeric site, and entity sites must not mix letters and digits.
+func validateStructure(c Classification) error {
+	if c.NetworkSite == "" || c.Type == CLLITypeCustomer {
+		return nil
+	}
+	if c.EntityCode == "" && !isDigitsOnly(c.NetworkSite) {
+		return newMessageError(MsgSiteBuilding, c.NetworkSite)
+	}
+	if c.EntityCode != "" && !isDigitsOnly(c.NetworkSite) && !isAlpha(c.NetworkSite) {
+		return newMessageError(MsgSiteMixed)
+	}
+	return nil
+}
+
+// validateTail checks the components after the network site of a
+// classification under ParseOptions.StrictStructure: a location ID must be
+// 4 digits, and a customer code a digit followed by a customer ID of the
+// layout for its length. The returned error's position is relative to the
+// classified remainder.
+func validateTail(c Classification) *ParseError {
+	switch c.Type {
+	case CLLITypeNonBuilding:
+		if c.LocationID != "" && (len(c.LocationID) != 4 || !isDigitsOnly(c.LocationID)) {
+			return &ParseError{
+				Position: 1,
+				Field:    "location_id",
+				Err:      fmt.Errorf("%w: %w", ErrInvalidLocation, newMessageError(MsgLocationID)),
+			}
+		}
+	case CLLITypeCustomer:
+		offset := len(c.NetworkSite)
+		if !isDigit(c.CustomerCode) {
+			return &ParseError{
+				Position: offset,
+				Field:    "customer_code",
+				Err:      fmt.Errorf("%w: %w", ErrInvalidCustomer, newMessageError(MsgCustomerCode)),
+			}
+		}
+		if !isCustomerID(c.CustomerID) || (c.NetworkSite != "") != (len(c.CustomerID) == 6) {
+			return &ParseError{
+				Position: offset + 1,
+				Field:    "customer_id",
+				Err:      fmt.Errorf("%w: %w", ErrInvalidCustomer, newMessageError(MsgCustomerID)),
+			}
+		}
+	}
+	return nil
+}
+
 // validateNetworkSiteAlphanumeric validates a network site code for non-building and customer CLLIs.
 // Non-building and customer CLLI network site codes can be alphanumeric (A-Z, 0-9).
 func validateNetworkSiteAlphanumeric(site string) error {
 	if site == "" {
-		return fmt.Errorf("network site code cannot be empty")
+		return newMessageError(MsgSiteEmpty)
 	}
 
 	if len(site) != 2 {
-		return fmt.Errorf("network site code must be exactly 2 characters")
+		return newMessageError(MsgSiteLength)
 	}
 
 	// Check for valid alphanumeric characters (A-Z, 0-9)
 	for _, r := range site {
 		if !((r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
-			return fmt.Errorf("network site code contains invalid character: %c", r)
+			return newMessageError(MsgSiteCharacter, r)
 		}
 	}
 
@@ -622,119 +1023,35 @@ func validateNetworkSiteAlphanumeric(site string) error {
 // Entity codes must be exactly 3 characters following Bell System patterns.
 func validateEntityCode(code string) error {
 	if code == "" {
-		return fmt.Errorf("entity code cannot be empty")
+		return newMessageError(MsgEntityEmpty)
 	}
 
 	if len(code) != 3 {
-		return fmt.Errorf("entity code must be exactly 3 characters")
-	}
-
-	// Strict entity code validation per Bell tables B–E, using patterns that
-	// cover the unit tests and real-world samples used in integration.
-
-	// Normalize input
-	c := code
-
-	// Quick alphanumeric check and exact length 3 were already done
-	if !isValidEntityCode(c) || len(c) != 3 {
-		return fmt.Errorf("invalid entity code pattern: %s", c)
-	}
-
-	// Helper: check membership in a set
-	inSet := func(s string, set map[string]struct{}) bool {
-		_, ok := set[s]
-		return ok
+		return newMessageError(MsgEntityLength)
 	}
 
-	// Table B: two-letter equipment prefixes + any alnum
-	// Include prefixes seen in tests and integration (adds RT, SW, MS, XC)
-	tbPrefixes := map[string]struct{}{
-		"MG": {}, "SG": {}, "CG": {}, "DS": {}, "RL": {}, "PS": {}, "RP": {}, "CM": {},
-		"VS": {}, "OS": {}, "OL": {}, "RT": {}, "SW": {}, "MS": {}, "XC": {},
-	}
-	if inSet(c[:2], tbPrefixes) {
-		// allow any alphanumeric third char (accepts DS0/RT1/SW1 etc.)
-		return nil
-	}
-
-	// Table B numeric variants: [0-9]{2}[12AZ]
-	if c[0] >= '0' && c[0] <= '9' && c[1] >= '0' && c[1] <= '9' {
-		if strings.ContainsRune("12AZ", rune(c[2])) {
-			return nil
-		}
-	}
-
-	// Table B T-suffix: [CB0-9][0-9]T
-	if (c[0] == 'C' || c[0] == 'B' || (c[0] >= '0' && c[0] <= '9')) && (c[1] >= '0' && c[1] <= '9') && c[2] == 'T' {
-		return nil
-	}
-
-	// Table B GT: [0-9]GT
-	if (c[0] >= '0' && c[0] <= '9') && c[1] == 'G' && c[2] == 'T' {
-		return nil
-	}
-
-	// Table B: Z[A-Z]Z
-	if c[0] == 'Z' && (c[1] >= 'A' && c[1] <= 'Z') && c[2] == 'Z' {
-		return nil
-	}
-
-	// Table B: RS[0-9]
-	if c[0] == 'R' && c[1] == 'S' && (c[2] >= '0' && c[2] <= '9') {
-		return nil
-	}
-
-	// Table B: X[A-Z]X
-	if c[0] == 'X' && (c[1] >= 'A' && c[1] <= 'Z') && c[2] == 'X' {
-		return nil
-	}
-
-	// Table B: CT[12AZ]
-	if c[0] == 'C' && c[1] == 'T' && strings.ContainsRune("12AZ", rune(c[2])) {
-		return nil
-	}
-
-	// Table C: [0-9][CDBINQWMVROLPEUTZ0-9]B
-	if (c[0] >= '0' && c[0] <= '9') &&
-		(strings.ContainsRune("CDBINQWMVROLPEUTZ", rune(c[1])) || (c[1] >= '0' && c[1] <= '9')) &&
-		c[2] == 'B' {
-		return nil
-	}
-
-	// Table D: [0-9][AXCTWDEINPQ]D
-	if (c[0] >= '0' && c[0] <= '9') && strings.ContainsRune("AXCTWDEINPQ", rune(c[1])) && c[2] == 'D' {
-		return nil
-	}
-
-	// Table D: [A-Z0-9][UM]D
-	if ((c[0] >= 'A' && c[0] <= 'Z') || (c[0] >= '0' && c[0] <= '9')) && strings.ContainsRune("UM", rune(c[1])) && c[2] == 'D' {
-		return nil
-	}
-
-	// Table E: Q[0-9][0-9]
-	if c[0] == 'Q' && (c[1] >= '0' && c[1] <= '9') && (c[2] >= '0' && c[2] <= '9') {
-		return nil
+	// Strict entity code validation per Bell tables B–E
+	if lookupEntityTable(code) == nil {
+		return newMessageError(MsgEntityPattern, code)
 	}
+	return nil
+}
 
-	// Table E: limit acceptance to patterns/examples used by tests
-	//  - F23, A12, E45, K67, M89, P01, S34, T56, W78
-	//  - FAA, AAA, EZZ
-	//  - KA1, M2Z
-	switch c {
-	case "F23", "A12", "E45", "K67", "M89", "P01", "S34", "T56", "W78",
-		"FAA", "AAA", "EZZ", "KA1", "M2Z":
-		return nil
+// entityTableRow returns the Bell table row (B–E) matched by a three-character
+// entity code, or "" if the code matches no row.
+func entityTableRow(c string) string {
+	if e := lookupEntityTable(c); e != nil {
+		return e.name()
 	}
-
-	// Reject anything else in strict mode
-	return fmt.Errorf("invalid entity code pattern: %s", c)
+	return ""
 }
 
 // determineCLLIType analyzes a CLLI structure to determine its type.
 // This implements the classification logic according to Bell System standards.
 func determineCLLIType(clli *CLLI) CLLIType {
-	// Calculate total length to help with classification
-	totalLen := len(clli.Place) + len(clli.Region) + len(clli.NetworkSite) + len(clli.EntityCode) +
+	// Calculate total length to help with classification, counting the
+	// padding of short place codes as Format does
+	totalLen := max(len(clli.Place), 4) + len(clli.Region) + len(clli.NetworkSite) + len(clli.EntityCode) +
 		len(clli.LocationCode) + len(clli.LocationID) + len(clli.CustomerCode) + len(clli.CustomerID)
 
 	// Customer CLLIs have customer code and customer ID populated
@@ -752,9 +1069,9 @@ func determineCLLIType(clli *CLLI) CLLIType {
 		return CLLITypeEntity
 	}
 
-	// For 8-character CLLIs (PPPPRRNN), if network site is populated but no entity/location/customer fields,
-	// treat as NonBuilding CLLI (this matches test expectations)
-	if totalLen == 8 && clli.NetworkSite != "" &&
+	// For 8-character CLLIs (PPPPRRNN), if a numeric network site is populated but no entity/location/customer
+	// fields, treat as NonBuilding CLLI (this matches test expectations and DefaultClassifier)
+	if totalLen == 8 && isDigitsOnly(clli.NetworkSite) &&
 		clli.EntityCode == "" && clli.LocationCode == "" && clli.CustomerCode == "" {
 		return CLLITypeNonBuilding
 	}
@@ -774,15 +1091,60 @@ func (c *CLLI) Type() CLLIType {
 	return c.cliType
 }
 
+// AmbiguousType returns the types of every structurally valid reading of
+// the code, in CLLIType order, if it has more than one, such as Entity and
+// NonBuilding for "CHCGILA1012", or nil if its type is unambiguous. The
+// parsed type is one of them; ParseCandidates returns the readings in full.
+func (c *CLLI) AmbiguousType() []CLLIType {
+	if c.readings == 0 {
+		return nil
+	}
+	var types []CLLIType
+	for t := CLLITypeEntity; t <= CLLITypeCustomer; t++ {
+		if c.readings&(1<<t) != 0 {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
 // IsValid returns true if the CLLI was successfully parsed and validated.
 // This indicates that all components conform to Bell System standards.
+// Codes accepted by non-strict parsing with nonconforming components are
+// not valid; InvalidFields lists those components.
 func (c *CLLI) IsValid() bool {
 	return c.valid
 }
 
+// FieldValid reports whether a component passed validation. Non-strict
+// parsing accepts some nonconforming components, such as unregistered
+// regions, short place codes and entity codes outside the Bell tables, and
+// records them here instead of rejecting the code.
+func (c *CLLI) FieldValid(kind ComponentKind) bool {
+	return c.invalid&(1<<kind) == 0
+}
+
+// InvalidFields returns the components accepted without passing
+// validation, in code order, or nil if there are none.
+func (c *CLLI) InvalidFields() []ComponentKind {
+	var kinds []ComponentKind
+	for kind := ComponentPlace; kind <= ComponentCustomerID; kind++ {
+		if !c.FieldValid(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
 // String returns the original CLLI string as provided during parsing.
 // This preserves the exact input format for round-trip consistency.
+// If a redaction policy masks components, the code is instead formatted
+// with those components masked; the policy is that of the Parser that
+// parsed the CLLI, or else the one set with SetRedactionPolicy.
 func (c *CLLI) String() string {
+	if p := c.redactionPolicy(); len(p.Components) > 0 {
+		return p.Redact(c)
+	}
 	return c.Original
 }
 
@@ -843,14 +1205,16 @@ func (c *CLLI) ValidateEntityCode() bool {
 // These methods provide geographic information based on the CLLI's region code.
 
 // CountryCode returns the ISO 3166-1 alpha-2 country code for this CLLI's region.
-// Currently supports US states and Canadian provinces.
+// Supports the regions of DefaultRegionRegistry and the international regions of
+// InternationalRegionRegistry.
 // Returns empty string if the region is not recognized.
 func (c *CLLI) CountryCode() string {
 	return getCountryCode(c.Region)
 }
 
 // CountryName returns the full country name for this CLLI's region.
-// Currently supports US states and Canadian provinces.
+// Supports the regions of DefaultRegionRegistry and the international regions of
+// InternationalRegionRegistry.
 // Returns empty string if the region is not recognized.
 func (c *CLLI) CountryName() string {
 	return getCountryName(c.Region)
@@ -868,17 +1232,20 @@ func (c *CLLI) StateCode() string {
 }
 
 // StateName returns the full state or province name for this CLLI's region.
-// Currently supports US states and Canadian provinces.
+// Supports the regions of DefaultRegionRegistry and the international regions of
+// InternationalRegionRegistry.
 // Returns empty string if the region is not recognized.
 func (c *CLLI) StateName() string {
 	return getStateName(c.Region)
 }
 
 // CityName returns the city name for this CLLI's place code if known.
-// This requires a mapping database of place codes to cities.
-// Returns empty string if the place code is not in the database.
+// Names are looked up with DefaultGeoResolver; see SetGeoResolver to plug
+// in a larger place database. Returns empty string if the place code is
+// not in the database or the lookup fails.
 func (c *CLLI) CityName() string {
-	return getCityName(c.Place, c.Region)
+	city, _ := DefaultGeoResolver().City(context.Background(), c.Place, c.Region)
+	return city
 }
 
 // EntityType returns a description of the entity type if this is an entity CLLI.
@@ -889,22 +1256,9 @@ func (c *CLLI) EntityType() string {
 		return ""
 	}
 
-	// Basic entity type mapping based on common patterns
-	// This is a simplified implementation - a full system would have comprehensive tables
-	switch {
-	case strings.HasPrefix(c.EntityCode, "DS"):
-		return "Digital Switch"
-	case strings.HasPrefix(c.EntityCode, "RT"):
-		return "Router"
-	case strings.HasPrefix(c.EntityCode, "SW"):
-		return "Switch"
-	case strings.HasPrefix(c.EntityCode, "MS"):
-		return "Multiplexer"
-	case strings.HasPrefix(c.EntityCode, "XC"):
-		return "Cross-Connect"
-	default:
-		return "Network Equipment"
-	}
+	// Reserved code families are described in place of the equipment
+	info, _ := DescribeEntityCode(c.EntityCode)
+	return info.Description
 }
 
 // LocationType returns a description of the location type for non-building CLLIs.
@@ -926,6 +1280,34 @@ func (c *CLLI) LocationType() string {
 	}
 }
 
+// locationCodeMeanings describes the non-building location code letters
+// defined by Bell System Practices Section 795-100-100.
+var locationCodeMeanings = map[string]string{
+	"B": "Pole",
+	"E": "End of cable",
+	"J": "Junction",
+	"M": "Manhole",
+	"P": "Repeater point",
+}
+
+// DescribeLocationCode returns the meaning of a non-building location code
+// letter, e.g. "Manhole" for "M". It returns false for unassigned letters.
+func DescribeLocationCode(code string) (string, bool) {
+	meaning, ok := locationCodeMeanings[strings.ToUpper(code)]
+	return meaning, ok
+}
+
+// LocationCodeMeaning returns the meaning of the location code of a
+// non-building CLLI, e.g. "Pole" for MPLSMNB1234. Returns an empty string
+// for other CLLI types and unassigned letters.
+func (c *CLLI) LocationCodeMeaning() string {
+	if c.cliType != CLLITypeNonBuilding {
+		return ""
+	}
+	meaning, _ := DescribeLocationCode(c.LocationCode)
+	return meaning
+}
+
 // Geographic resolution helper functions
 // These provide basic geographic lookups for US states and Canadian provinces.
 
@@ -972,48 +1354,20 @@ var cityMappings = map[string]map[string]string{
 
 // getCountryCode returns the ISO 3166-1 alpha-2 country code for a region.
 func getCountryCode(region string) string {
-	if _, exists := usStates[region]; exists {
-		return "US"
-	}
-	if _, exists := canadianProvinces[region]; exists {
-		return "CA"
-	}
-	return ""
+	info, _ := lookupRegion(region)
+	return info.CountryCode
 }
 
 // getCountryName returns the full country name for a region.
 func getCountryName(region string) string {
-	if _, exists := usStates[region]; exists {
-		return "United States"
-	}
-	if _, exists := canadianProvinces[region]; exists {
-		return "Canada"
-	}
-	return ""
+	info, _ := lookupRegion(region)
+	return info.CountryName
 }
 
 // getStateName returns the full state or province name for a region.
 func getStateName(region string) string {
-	if name, exists := usStates[region]; exists {
-		return name
-	}
-	if name, exists := canadianProvinces[region]; exists {
-		return name
-	}
-	return ""
-}
-
-// getCityName returns the city name for a place code and region combination.
-func getCityName(place, region string) string {
-	// Normalize place code by removing trailing spaces
-	normalizedPlace := strings.TrimRight(place, " ")
-
-	if regionMap, exists := cityMappings[normalizedPlace]; exists {
-		if city, exists := regionMap[region]; exists {
-			return city
-		}
-	}
-	return ""
+	info, _ := lookupRegion(region)
+	return info.Name
 }
 
 // Package-level validation functions for external use
@@ -1112,6 +1466,11 @@ func IsEntityCLLI(clli string) bool {
 
 	remaining := clli[6:]
 
+	// Alphabetic network sites are valid for entities with a Bell table entity code
+	if len(remaining) == 5 && isAlpha(remaining[:2]) && validateEntityCode(remaining[2:]) == nil {
+		return true
+	}
+
 	// Check if it matches entity pattern: digits + entity code
 	if len(remaining) >= 2 {
 		// Try to find where network site ends and entity code begins
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestCLLIType tests the CLLIType enum and string representation
@@ -52,6 +53,45 @@ func TestParseError(t *testing.T) {
 		assert.True(t, errors.Is(err, ErrInvalidPlace))
 		assert.Equal(t, ErrInvalidPlace, err.Unwrap())
 	})
+
+	t.Run("Spans", func(t *testing.T) {
+		tests := []struct {
+			input      string
+			start, end int
+			value      string
+		}{
+			{"CHCGZZ01DS0", 4, 6, "ZZ"},
+			{"  chcgzz01ds0 ", 6, 8, "zz"},
+			{"CHCGIL01QQQ", 8, 11, "QQQ"},
+			{"CHCG#L01DS0", 4, 5, "#"},
+			{"CHCG", 0, 4, "CHCG"},
+			{"CH1GIL01DS0", 0, 4, "CH1G"},
+		}
+		for _, tt := range tests {
+			_, err := Parse(tt.input)
+			var pe *ParseError
+			require.ErrorAs(t, err, &pe, tt.input)
+			assert.Equal(t, tt.start, pe.Start, tt.input)
+			assert.Equal(t, tt.end, pe.End, tt.input)
+			assert.Equal(t, tt.value, pe.Value, tt.input)
+		}
+
+		// Spans are derived for errors built without them
+		start, end := (&ParseError{Input: "CHCGIL0XDS0", Position: 6, Field: "network_site"}).Span()
+		assert.Equal(t, []int{6, 8}, []int{start, end})
+	})
+
+	t.Run("Annotate", func(t *testing.T) {
+		_, err := Parse("CHCGZZ01DS0")
+		var pe *ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "CHCGZZ01DS0\n"+
+			"    ^^ parse error at position 4 in field region: invalid region code", pe.Annotate())
+
+		_, err = Parse("  ")
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "  \n^^ parse error at position 0 in field input: empty CLLI input", pe.Annotate())
+	})
 }
 
 // TestParse tests the main Parse function
@@ -165,6 +205,33 @@ func TestParse(t *testing.T) {
 	})
 }
 
+// TestCustomerFields tests population of customer-specific fields across customer formats
+func TestCustomerFields(t *testing.T) {
+	tests := []struct {
+		input        string
+		networkSite  string
+		customerCode string
+		customerID   string
+	}{
+		{"MPLSMN1A234", "", "1", "A234"},         // 11-character customer CLLI
+		{"MPLSMN1A2345", "", "1", "A2345"},       // 12-character customer CLLI
+		{"DLLSTX011234567", "01", "1", "234567"}, // 15-character customer CLLI with network site
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, CLLITypeCustomer, c.Type())
+			assert.Equal(t, tt.networkSite, c.NetworkSite)
+			assert.Equal(t, tt.customerCode, c.CustomerCode)
+			assert.Equal(t, tt.customerID, c.CustomerID)
+			assert.Empty(t, c.EntityCode)
+			assert.Equal(t, tt.input, c.Format())
+		})
+	}
+}
+
 // TestParseWithOptions tests parsing with different options
 func TestParseWithOptions(t *testing.T) {
 	t.Run("Strict mode", func(t *testing.T) {
@@ -361,6 +428,19 @@ func TestEntityAndLocationTypes(t *testing.T) {
 		}
 	})
 
+	t.Run("LocationCodeMeaning", func(t *testing.T) {
+		assert.Equal(t, "Pole", MustParse("MPLSMNB1234").LocationCodeMeaning())
+		assert.Equal(t, "Manhole", MustParse("MPLSMNM1234").LocationCodeMeaning())
+		assert.Equal(t, "", MustParse("MPLSMNC1234").LocationCodeMeaning())
+		assert.Equal(t, "", MustParse("CHCGIL01DS0").LocationCodeMeaning())
+
+		meaning, ok := DescribeLocationCode("j")
+		assert.True(t, ok)
+		assert.Equal(t, "Junction", meaning)
+		_, ok = DescribeLocationCode("C")
+		assert.False(t, ok)
+	})
+
 	t.Run("LocationType for customer CLLI", func(t *testing.T) {
 		c := &CLLI{
 			CustomerCode: "1",
@@ -425,3 +505,56 @@ func BenchmarkGeographicResolution(b *testing.B) {
 		_ = c.CityName()
 	}
 }
+
+// TestAlphabeticNetworkSiteEntities tests entity classification with letter network sites
+func TestAlphabeticNetworkSiteEntities(t *testing.T) {
+	t.Run("Valid alphabetic sites", func(t *testing.T) {
+		for _, input := range []string{"MPLSMNMSDS1", "NYCMNYPSRS1", "HSTXTXMACT1", "SNJPCAXAXAX"} {
+			c, err := Parse(input)
+			assert.NoError(t, err, input)
+			assert.Equal(t, CLLITypeEntity, c.Type(), input)
+			assert.Equal(t, input[6:8], c.NetworkSite, input)
+			assert.Equal(t, input[8:], c.EntityCode, input)
+			assert.True(t, IsEntityCLLI(input), input)
+		}
+	})
+
+	t.Run("Invalid entity code on alphabetic site", func(t *testing.T) {
+		_, err := Parse("MPLSMNMSXYZ")
+		assert.ErrorIs(t, err, ErrInvalidEntity)
+		assert.False(t, IsEntityCLLI("MPLSMNMSXYZ"))
+	})
+
+	t.Run("Mixed sites are rejected in strict mode", func(t *testing.T) {
+		_, err := Parse("CHCGILA0DS0")
+		assert.ErrorIs(t, err, ErrInvalidSite)
+
+		c, err := ParseWithOptions("CHCGILA0DS0", &ParseOptions{Strict: false})
+		assert.NoError(t, err)
+		assert.Equal(t, CLLITypeEntity, c.Type())
+	})
+}
+
+// TestTwelveCharacterCustomerTail tests structural validation of 12-character customer CLLIs
+func TestTwelveCharacterCustomerTail(t *testing.T) {
+	invalid := []string{
+		"MPLSMN1AB345", // Letter in ID digit section
+		"MPLSMN123456", // Digit instead of letter for ID first char
+		"MPLSMN1A234B", // Letter at end of ID
+	}
+
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			assert.ErrorIs(t, err, ErrInvalidCustomer)
+			assert.Equal(t, MsgCustomerTail, MessageIDOf(err))
+			assert.Equal(t, ErrCodeBadCustomer, ErrorCodeOf(err))
+
+			var pe *ParseError
+			if assert.True(t, errors.As(err, &pe)) {
+				assert.Equal(t, "customer_id", pe.Field)
+				assert.Equal(t, 7, pe.Position)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package clligen generates random valid CLLIs, for test suites of
+// downstream systems that need realistic synthetic codes at scale.
+//
+// Generation is driven by a caller-supplied *rand.Rand, so a fixed seed
+// reproduces the same codes:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	c, err := clligen.RandomEntity(r, &clligen.Options{Region: "IL", EntityTable: clli.EntityTableB})
+//
+// Every generated code parses with clli.Parse. For coherent inventories of
+// buildings and the codes located there, see cllitest.GenerateInventory.
+package clligen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Options constrains generated CLLIs. A nil *Options selects the defaults.
+type Options struct {
+	// Region fixes the region code. Empty picks a random region from
+	// clli.DefaultRegionRegistry.
+	Region string
+
+	// PlacePrefix fixes the first letters of the place code; the rest are
+	// random. At most 4 letters.
+	PlacePrefix string
+
+	// EntityTable limits RandomEntity to one Bell entity table. The default
+	// draws from every table.
+	EntityTable clli.EntityTable
+}
+
+// RandomEntity returns a random entity CLLI, such as "KQZBTX47DS1".
+// Reserved entity code families are never generated.
+// Returns an error wrapping clli.ErrInvalidOptions if opts is invalid.
+func RandomEntity(r *rand.Rand, opts *Options) (*clli.CLLI, error) {
+	base, err := building(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	var table clli.EntityTable
+	if opts != nil {
+		table = opts.EntityTable
+	}
+	codes := entityCodes(table)
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("%w: unknown entity table %q", clli.ErrInvalidOptions, table)
+	}
+	return clli.Parse(base + codes[r.IntN(len(codes))])
+}
+
+// RandomNonBuilding returns a random non-building location CLLI, such as
+// "KQZBTXB1234".
+// Returns an error wrapping clli.ErrInvalidOptions if opts is invalid.
+func RandomNonBuilding(r *rand.Rand, opts *Options) (*clli.CLLI, error) {
+	prefix, err := placeRegion(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return clli.Parse(fmt.Sprintf("%s%s%04d", prefix, letters(r, 1), r.IntN(10000)))
+}
+
+// RandomCustomer returns a random 12-character customer location CLLI,
+// such as "KQZBTX1A2345".
+// Returns an error wrapping clli.ErrInvalidOptions if opts is invalid.
+func RandomCustomer(r *rand.Rand, opts *Options) (*clli.CLLI, error) {
+	prefix, err := placeRegion(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return clli.Parse(fmt.Sprintf("%s%d%s%04d", prefix, r.IntN(10), letters(r, 1), r.IntN(10000)))
+}
+
+// building returns a random building code: place, region and a numeric
+// network site from 01 to 99.
+func building(r *rand.Rand, opts *Options) (string, error) {
+	prefix, err := placeRegion(r, opts)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%02d", prefix, 1+r.IntN(99)), nil
+}
+
+// placeRegion returns a random place and region honoring opts.
+func placeRegion(r *rand.Rand, opts *Options) (string, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+
+	prefix := strings.ToUpper(o.PlacePrefix)
+	if len(prefix) > 4 || strings.Trim(prefix, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("%w: place prefix %q must be at most 4 letters", clli.ErrInvalidOptions, o.PlacePrefix)
+	}
+
+	region := strings.ToUpper(o.Region)
+	if region == "" {
+		regions := clli.DefaultRegionRegistry().Regions()
+		region = regions[r.IntN(len(regions))].Code
+	} else if err := clli.ValidateRegion(region, true); err != nil {
+		return "", fmt.Errorf("%w: region %q: %w", clli.ErrInvalidOptions, o.Region, err)
+	}
+
+	return prefix + letters(r, 4-len(prefix)) + region, nil
+}
+
+// letters returns n random uppercase letters.
+func letters(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('A' + r.IntN(26))
+	}
+	return string(b)
+}
+
+// tableCodes caches the assignable entity codes of each table.
+var tableCodes sync.Map // clli.EntityTable -> []string
+
+// entityCodes returns the assignable entity codes of table, enumerated
+// once with clli.GenerateEntityCLLIs.
+func entityCodes(table clli.EntityTable) []string {
+	if codes, ok := tableCodes.Load(table); ok {
+		return codes.([]string)
+	}
+	base := &clli.CLLI{Place: "XXXX", Region: "IL", NetworkSite: "01"}
+	var codes []string
+	for code := range clli.GenerateEntityCLLIs(base, table) {
+		codes = append(codes, code[8:])
+	}
+	tableCodes.Store(table, codes)
+	return codes
+}
@@ -0,0 +1,78 @@
+package clligen
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+func TestRandom(t *testing.T) {
+	generators := map[clli.CLLIType]func(*rand.Rand, *Options) (*clli.CLLI, error){
+		clli.CLLITypeEntity:      RandomEntity,
+		clli.CLLITypeNonBuilding: RandomNonBuilding,
+		clli.CLLITypeCustomer:    RandomCustomer,
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for want, generate := range generators {
+		t.Run(want.String(), func(t *testing.T) {
+			for range 500 {
+				c, err := generate(r, nil)
+				require.NoError(t, err)
+				assert.Equal(t, want, c.Type(), c.Format())
+				_, err = clli.Parse(c.Format())
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRandomOptions(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	opts := &Options{Region: "il", PlacePrefix: "CH", EntityTable: clli.EntityTableB}
+	for range 200 {
+		c, err := RandomEntity(r, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "IL", c.Region)
+		assert.True(t, strings.HasPrefix(c.Place, "CH"), c.Place)
+		info, ok := c.EntityCategory()
+		require.True(t, ok, c.Format())
+		assert.Equal(t, "B", info.Table, c.Format())
+	}
+
+	c, err := RandomCustomer(r, &Options{PlacePrefix: "NYCM"})
+	require.NoError(t, err)
+	assert.Equal(t, "NYCM", c.Place)
+}
+
+func TestRandomReproducible(t *testing.T) {
+	generate := func() []string {
+		r := rand.New(rand.NewPCG(7, 7))
+		var codes []string
+		for range 10 {
+			c, err := RandomEntity(r, nil)
+			require.NoError(t, err)
+			codes = append(codes, c.Format())
+		}
+		return codes
+	}
+	assert.Equal(t, generate(), generate())
+}
+
+func TestRandomInvalidOptions(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, opts := range []*Options{
+		{Region: "ZZ"},
+		{PlacePrefix: "CHCGO"},
+		{PlacePrefix: "C1"},
+		{EntityTable: "Q"},
+	} {
+		_, err := RandomEntity(r, opts)
+		assert.ErrorIs(t, err, clli.ErrInvalidOptions, "%+v", opts)
+	}
+}
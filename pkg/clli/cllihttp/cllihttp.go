@@ -0,0 +1,219 @@
+// Package cllihttp validates CLLIs in HTTP requests for Go services.
+//
+// Middleware checks named path and query parameters before the wrapped
+// handler runs, rejecting requests with an RFC 7807 problem-details
+// response and passing the parsed CLLIs on in the request context:
+//
+//	mux.Handle("GET /circuits/{clli}", cllihttp.Middleware(&cllihttp.Options{
+//		PathParams: []string{"clli"},
+//	})(circuits))
+//
+//	func circuits(w http.ResponseWriter, r *http.Request) {
+//		c, _ := cllihttp.FromContext(r.Context(), "clli")
+//		...
+//	}
+//
+// Handler serves a standalone validation endpoint, described by the
+// OpenAPI document returned by OpenAPI.
+package cllihttp
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ProblemType identifies invalid-CLLI problems in the "type" member of
+// problem-details responses.
+const ProblemType = "https://pkg.go.dev/github.com/dbitech/go-clli/pkg/clli/cllihttp#ProblemType"
+
+// ProblemContentType is the media type of problem-details responses.
+const ProblemContentType = "application/problem+json"
+
+// Options configures Middleware and Handler. A nil *Options selects the
+// defaults.
+type Options struct {
+	// PathParams and QueryParams name the request parameters Middleware
+	// validates. Path parameters require the wrapped handler to be
+	// registered with a http.ServeMux pattern naming them. Absent query
+	// parameters are accepted unless RequireQuery is set.
+	PathParams   []string
+	QueryParams  []string
+	RequireQuery bool
+
+	// Parser parses parameters. Nil uses clli.Parse.
+	Parser *clli.Parser
+
+	// Status is the HTTP status of problem responses. Zero selects
+	// http.StatusBadRequest.
+	Status int
+}
+
+// Problem is an RFC 7807 problem-details response for invalid CLLIs.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam describes one rejected parameter of a Problem.
+type InvalidParam struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Reason    string `json:"reason"`
+	ErrorCode string `json:"error_code"` // clli.ErrorCode name, such as "bad_region"
+}
+
+// contextKey is the type of the context key holding parsed parameters.
+type contextKey struct{}
+
+// FromContext returns the CLLI parsed from the named parameter by
+// Middleware. Reports false if the parameter was absent or not validated.
+func FromContext(ctx context.Context, name string) (*clli.CLLI, bool) {
+	params, _ := ctx.Value(contextKey{}).(map[string]*clli.CLLI)
+	c, ok := params[name]
+	return c, ok
+}
+
+// Middleware returns middleware that validates the CLLI parameters named
+// in opts. Requests with an invalid or missing required parameter receive
+// a Problem listing every failure; the wrapped handler is not called.
+func Middleware(opts *Options) func(http.Handler) http.Handler {
+	o := withDefaults(opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			params := make(map[string]*clli.CLLI)
+			var invalid []InvalidParam
+			check := func(name, value string) {
+				c, err := o.parse(value)
+				if err != nil {
+					invalid = append(invalid, newInvalidParam(name, value, err))
+					return
+				}
+				params[name] = c
+			}
+
+			for _, name := range o.PathParams {
+				check(name, r.PathValue(name))
+			}
+			query := r.URL.Query()
+			for _, name := range o.QueryParams {
+				switch {
+				case query.Has(name):
+					check(name, query.Get(name))
+				case o.RequireQuery:
+					invalid = append(invalid, missingParam(name))
+				}
+			}
+
+			if len(invalid) > 0 {
+				WriteProblem(w, r, o.Status, invalid)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, params)))
+		})
+	}
+}
+
+// Handler returns a handler validating the CLLI in the "code" query
+// parameter. A valid code is answered with status 200 and its expanded
+// JSON encoding; an invalid or missing code with a Problem. See OpenAPI for
+// the full description.
+func Handler(opts *Options) http.Handler {
+	o := withDefaults(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if !query.Has("code") {
+			WriteProblem(w, r, o.Status, []InvalidParam{missingParam("code")})
+			return
+		}
+		c, err := o.parse(query.Get("code"))
+		if err != nil {
+			WriteProblem(w, r, o.Status, []InvalidParam{newInvalidParam("code", query.Get("code"), err)})
+			return
+		}
+		data, err := c.MarshalJSONForm(clli.JSONExpanded)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(append(data, '\n'))
+	})
+}
+
+//go:embed openapi.json
+var openAPI []byte
+
+// OpenAPI returns the OpenAPI 3 document describing Handler and the
+// Problem schema, for inclusion in a service's API description.
+func OpenAPI() []byte {
+	return append([]byte(nil), openAPI...)
+}
+
+// WriteProblem writes a Problem response listing the invalid parameters.
+// A zero status selects http.StatusBadRequest.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, invalid []InvalidParam) {
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+	p := Problem{
+		Type:          ProblemType,
+		Title:         "Invalid CLLI",
+		Status:        status,
+		Instance:      r.URL.RequestURI(),
+		InvalidParams: invalid,
+	}
+	if len(invalid) == 1 {
+		p.Detail = fmt.Sprintf("parameter %s: %s", invalid[0].Name, invalid[0].Reason)
+	} else {
+		p.Detail = fmt.Sprintf("%d parameters are invalid", len(invalid))
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// newInvalidParam describes a parameter that failed to parse.
+func newInvalidParam(name, value string, err error) InvalidParam {
+	reason := err.Error()
+	var pe *clli.ParseError
+	if errors.As(err, &pe) {
+		reason = pe.Error() // Without the input, which is already in Value
+	}
+	return InvalidParam{Name: name, Value: value, Reason: reason, ErrorCode: clli.ErrorCodeOf(err).String()}
+}
+
+// missingParam describes a required parameter that is absent.
+func missingParam(name string) InvalidParam {
+	return InvalidParam{Name: name, Reason: "missing", ErrorCode: clli.ErrCodeEmpty.String()}
+}
+
+// withDefaults copies opts, applying defaults.
+func withDefaults(opts *Options) Options {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.Status == 0 {
+		o.Status = http.StatusBadRequest
+	}
+	return o
+}
+
+// parse parses a parameter value with the configured parser.
+func (o Options) parse(value string) (*clli.CLLI, error) {
+	if o.Parser != nil {
+		return o.Parser.Parse(value)
+	}
+	return clli.Parse(value)
+}
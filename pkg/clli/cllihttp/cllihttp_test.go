@@ -0,0 +1,111 @@
+package cllihttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// decodeProblem decodes a problem-details response.
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	return p
+}
+
+func TestMiddleware(t *testing.T) {
+	var got *clli.CLLI
+	var gotQuery bool
+	mux := http.NewServeMux()
+	mux.Handle("GET /circuits/{clli}", Middleware(&Options{
+		PathParams:  []string{"clli"},
+		QueryParams: []string{"peer"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context(), "clli")
+		_, gotQuery = FromContext(r.Context(), "peer")
+	})))
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve("/circuits/chcgil01ds0")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "CHCGIL01DS0", got.Format())
+	assert.False(t, gotQuery)
+
+	rec = serve("/circuits/CHCGIL01DS0?peer=DLLSTXB1234")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, gotQuery)
+
+	// Every invalid parameter is reported
+	got = nil
+	rec = serve("/circuits/CHCGZZ01DS0?peer=BAD")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Nil(t, got)
+	p := decodeProblem(t, rec)
+	assert.Equal(t, ProblemType, p.Type)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+	assert.Equal(t, "/circuits/CHCGZZ01DS0?peer=BAD", p.Instance)
+	require.Len(t, p.InvalidParams, 2)
+	assert.Equal(t, InvalidParam{
+		Name:      "clli",
+		Value:     "CHCGZZ01DS0",
+		Reason:    "parse error at position 4 in field region: invalid region code",
+		ErrorCode: "bad_region",
+	}, p.InvalidParams[0])
+	assert.Equal(t, "peer", p.InvalidParams[1].Name)
+	assert.Equal(t, "length", p.InvalidParams[1].ErrorCode)
+}
+
+func TestMiddlewareRequireQuery(t *testing.T) {
+	h := Middleware(&Options{QueryParams: []string{"code"}, RequireQuery: true, Status: http.StatusUnprocessableEntity})(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	p := decodeProblem(t, rec)
+	assert.Equal(t, "parameter code: missing", p.Detail)
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?code=CHCGIL01DS0", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "CHCGIL01DS0", body["code"])
+	assert.Equal(t, "DS0", body["entity"])
+
+	for _, target := range []string{"/", "/?code=CHCG"} {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		decodeProblem(t, rec)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(OpenAPI(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/")
+}
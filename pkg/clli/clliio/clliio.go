@@ -0,0 +1,226 @@
+// Package clliio reads CSV and TSV files in which one column holds a CLLI
+// code, and enriches each row with the code's type and geography. It turns
+// the clli package into an enrichment step for network inventory pipelines:
+//
+//	err := clliio.Enrich(ctx, os.Stdout, os.Stdin, &clliio.Options{
+//		Header: true,
+//		Column: "clli",
+//	})
+//
+// Rows whose code fails to parse are still written, with the parse error in
+// the appended error column, so no inventory row is silently dropped.
+package clliio
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// EnrichedColumns are the names of the columns appended to every row.
+var EnrichedColumns = []string{"clli_type", "city", "state", "country", "clli_error"}
+
+// Options controls how rows are read and enriched.
+type Options struct {
+	// Comma is the field separator. Zero selects ','; use '\t' for TSV.
+	Comma rune
+
+	// Header states that the first row names the columns.
+	Header bool
+
+	// Column selects the CLLI column, either by header name (matched
+	// case-insensitively, requires Header) or by 0-based index.
+	// Empty selects column 0.
+	Column string
+
+	// ParseOptions controls how codes are parsed. Nil selects the same
+	// defaults as clli.Parse.
+	ParseOptions *clli.ParseOptions
+
+	// Resolver supplies city names. Nil selects clli.DefaultGeoResolver.
+	Resolver clli.GeoResolver
+}
+
+// Row is a single input row and the enrichment of its CLLI column.
+type Row struct {
+	Line    int        // 1-based line number in the input
+	Fields  []string   // Original fields
+	CLLI    *clli.CLLI // Parsed code, or nil if it failed to parse
+	Err     error      // Parse failure, or nil
+	City    string     // City name, or "" if unknown
+	State   string     // State or province name, or "" if unknown
+	Country string     // Country name, or "" if unknown
+}
+
+// Enriched returns the original fields followed by the EnrichedColumns values.
+func (r *Row) Enriched() []string {
+	out := make([]string, 0, len(r.Fields)+len(EnrichedColumns))
+	out = append(out, r.Fields...)
+
+	var typ, errText string
+	if r.CLLI != nil {
+		typ = r.CLLI.Type().String()
+	}
+	if r.Err != nil {
+		errText = r.Err.Error()
+	}
+	return append(out, typ, r.City, r.State, r.Country, errText)
+}
+
+// Reader reads rows from a delimited file and enriches their CLLI column.
+type Reader struct {
+	opts     Options
+	r        *csv.Reader
+	resolver clli.GeoResolver
+	header   []string
+	column   int
+	started  bool
+}
+
+// NewReader creates a Reader reading from r. A nil opts selects the defaults.
+func NewReader(r io.Reader, opts *Options) *Reader {
+	rd := &Reader{r: csv.NewReader(r)}
+	if opts != nil {
+		rd.opts = *opts
+	}
+	if rd.opts.Comma != 0 {
+		rd.r.Comma = rd.opts.Comma
+	}
+	rd.r.FieldsPerRecord = -1
+	rd.r.LazyQuotes = true
+
+	rd.resolver = rd.opts.Resolver
+	if rd.resolver == nil {
+		rd.resolver = clli.DefaultGeoResolver()
+	}
+	return rd
+}
+
+// Header returns the header row, reading it if necessary. It returns nil
+// when Options.Header is not set.
+func (rd *Reader) Header() ([]string, error) {
+	if err := rd.start(); err != nil {
+		return nil, err
+	}
+	return rd.header, nil
+}
+
+// Read returns the next enriched row, or io.EOF when the input is
+// exhausted. Parse failures are reported in Row.Err rather than as an error;
+// the returned error is reserved for malformed input, a missing CLLI column
+// and context cancellation.
+func (rd *Reader) Read(ctx context.Context) (*Row, error) {
+	if err := rd.start(); err != nil {
+		return nil, err
+	}
+
+	fields, err := rd.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := rd.r.FieldPos(0)
+
+	row := &Row{Line: line, Fields: fields}
+	if rd.column >= len(fields) {
+		row.Err = fmt.Errorf("line %d: %w", line, clli.ErrEmptyInput)
+		return row, nil
+	}
+
+	row.CLLI, row.Err = clli.ParseWithOptions(fields[rd.column], rd.opts.ParseOptions)
+	if row.Err != nil {
+		return row, nil
+	}
+
+	if row.City, err = rd.resolver.City(ctx, row.CLLI.Place, row.CLLI.Region); err != nil {
+		return nil, err
+	}
+	row.State, row.Country = row.CLLI.StateName(), row.CLLI.CountryName()
+	return row, nil
+}
+
+// start reads the header row and resolves the CLLI column on first use.
+func (rd *Reader) start() error {
+	if rd.started {
+		return nil
+	}
+	rd.started = true
+
+	if rd.opts.Header {
+		header, err := rd.r.Read()
+		if err != nil {
+			return err
+		}
+		rd.header = header
+	}
+
+	column, err := rd.resolveColumn()
+	if err != nil {
+		return err
+	}
+	rd.column = column
+	return nil
+}
+
+// resolveColumn returns the index of the CLLI column.
+func (rd *Reader) resolveColumn() (int, error) {
+	name := strings.TrimSpace(rd.opts.Column)
+	if name == "" {
+		return 0, nil
+	}
+	if i, err := strconv.Atoi(name); err == nil {
+		if i < 0 {
+			return 0, fmt.Errorf("clliio: negative column index %d", i)
+		}
+		return i, nil
+	}
+	for i, h := range rd.header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("clliio: column %q not found in header", name)
+}
+
+// Enrich copies delimited rows from src to dst, appending EnrichedColumns to
+// each row (and to the header row when Options.Header is set). Output uses
+// the same separator as the input. A nil opts selects the defaults.
+func Enrich(ctx context.Context, dst io.Writer, src io.Reader, opts *Options) error {
+	rd := NewReader(src, opts)
+	w := csv.NewWriter(dst)
+	w.Comma = rd.r.Comma
+
+	header, err := rd.Header()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if header != nil {
+		if err := w.Write(append(append([]string(nil), header...), EnrichedColumns...)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		row, err := rd.Read(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.Write(row.Enriched()); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
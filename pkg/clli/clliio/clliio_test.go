@@ -0,0 +1,72 @@
+package clliio
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestEnrich tests enrichment of a CSV file selected by header name
+func TestEnrich(t *testing.T) {
+	src := "id,Site CLLI,owner\n1,CHCGIL01DS0,ops\n2,CHCGZZ01DS0,eng\n3,torooN01ds0,ops\n"
+
+	var out strings.Builder
+	require.NoError(t, Enrich(context.Background(), &out, strings.NewReader(src), &Options{Header: true, Column: "site clli"}))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "id,Site CLLI,owner,clli_type,city,state,country,clli_error", lines[0])
+	assert.Equal(t, "1,CHCGIL01DS0,ops,Entity,Chicago,Illinois,United States,", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "2,CHCGZZ01DS0,eng,,,,,"), lines[2])
+	assert.Contains(t, lines[2], "invalid region code")
+	assert.Equal(t, "3,torooN01ds0,ops,Entity,Toronto,Ontario,Canada,", lines[3])
+}
+
+// TestReaderTSV tests reading a headerless TSV file by column index
+func TestReaderTSV(t *testing.T) {
+	src := "a\tDLLSTXB1234\nb\n"
+	rd := NewReader(strings.NewReader(src), &Options{Comma: '\t', Column: "1"})
+
+	header, err := rd.Header()
+	require.NoError(t, err)
+	assert.Nil(t, header)
+
+	row, err := rd.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, row.Line)
+	assert.Equal(t, clli.CLLITypeNonBuilding, row.CLLI.Type())
+	assert.Equal(t, "Texas", row.State)
+
+	row, err = rd.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, row.Line)
+	assert.ErrorIs(t, row.Err, clli.ErrEmptyInput)
+	assert.Nil(t, row.CLLI)
+
+	_, err = rd.Read(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+// TestReaderErrors tests column selection failures and cancellation
+func TestReaderErrors(t *testing.T) {
+	_, err := NewReader(strings.NewReader("a,b\n"), &Options{Header: true, Column: "clli"}).Read(context.Background())
+	assert.ErrorContains(t, err, `column "clli" not found`)
+
+	_, err = NewReader(strings.NewReader("a\n"), &Options{Column: "-1"}).Read(context.Background())
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewReader(strings.NewReader("CHCGIL01DS0\n"), nil).Read(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var out strings.Builder
+	assert.NoError(t, Enrich(context.Background(), &out, strings.NewReader(""), &Options{Header: true}))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,147 @@
+// Package clliotel provides OpenTelemetry tracing and metrics for CLLI processing.
+//
+// An Instrumentation records parse outcomes as OTel metrics (it implements
+// clli.MetricsHook) and wraps batch parses and other long-running operations,
+// such as dataset loads or remote resolver calls, in spans annotated with
+// record counts and error codes.
+//
+// clliotel is a module of its own, so importing the core clli package does
+// not pull in the OpenTelemetry SDK.
+package clliotel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ScopeName is the instrumentation scope used for tracers and meters.
+const ScopeName = "github.com/dbitech/go-clli/pkg/clli/clliotel"
+
+// Attribute keys attached to spans and metrics.
+const (
+	AttrOperation   = attribute.Key("clli.operation")
+	AttrRecordCount = attribute.Key("clli.records")
+	AttrErrorCount  = attribute.Key("clli.errors")
+	AttrErrorCode   = attribute.Key("clli.error_code")
+	AttrType        = attribute.Key("clli.type")
+)
+
+// Config selects the providers used by an Instrumentation.
+// Nil providers fall back to the global OTel providers.
+type Config struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// Instrumentation emits OTel spans and metrics for CLLI operations.
+// It is safe for concurrent use.
+type Instrumentation struct {
+	tracer   trace.Tracer
+	parses   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+var _ clli.MetricsHook = (*Instrumentation)(nil)
+
+// New creates an Instrumentation from cfg.
+// Returns an error if the metric instruments cannot be created.
+func New(cfg Config) (*Instrumentation, error) {
+	tp := cfg.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := cfg.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(ScopeName)
+	parses, err := meter.Int64Counter("clli.parses",
+		metric.WithDescription("Number of CLLI parses by resulting type and error code."))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("clli.parse.duration",
+		metric.WithDescription("Time spent parsing CLLI codes."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:   tp.Tracer(ScopeName),
+		parses:   parses,
+		duration: duration,
+	}, nil
+}
+
+// OnParse implements clli.MetricsHook.
+func (in *Instrumentation) OnParse(result *clli.CLLI, code clli.ErrorCode, duration time.Duration) {
+	typ := clli.CLLITypeUnknown
+	if result != nil {
+		typ = result.Type()
+	}
+	attrs := metric.WithAttributes(AttrType.String(typ.String()), AttrErrorCode.String(code.String()))
+
+	ctx := context.Background()
+	in.parses.Add(ctx, 1, attrs)
+	in.duration.Record(ctx, duration.Seconds(), attrs)
+}
+
+// ParseBatch parses every input with p inside a single "clli.ParseBatch" span.
+// The span records the number of records and failures, and one event per
+// distinct error code encountered. Results and errors are index-aligned with inputs.
+func (in *Instrumentation) ParseBatch(ctx context.Context, p *clli.Parser, inputs []string) ([]*clli.CLLI, []error) {
+	_, span := in.tracer.Start(ctx, "clli.ParseBatch",
+		trace.WithAttributes(AttrOperation.String("parse_batch"), AttrRecordCount.Int(len(inputs))))
+	defer span.End()
+
+	results := make([]*clli.CLLI, len(inputs))
+	errs := make([]error, len(inputs))
+	failures := map[clli.ErrorCode]int{}
+
+	for i, input := range inputs {
+		results[i], errs[i] = p.Parse(input)
+		if errs[i] != nil {
+			failures[clli.ErrorCodeOf(errs[i])]++
+		}
+	}
+
+	total := 0
+	for code, n := range failures {
+		total += n
+		span.AddEvent("clli.parse_failures", trace.WithAttributes(
+			AttrErrorCode.String(code.String()), AttrErrorCount.Int(n)))
+	}
+	span.SetAttributes(AttrErrorCount.Int(total))
+	if total > 0 {
+		span.SetStatus(codes.Error, "batch contained invalid CLLIs")
+	}
+
+	return results, errs
+}
+
+// Run executes fn inside a span named "clli.<operation>", for wrapping dataset
+// loads, remote resolver calls and similar operations. The error returned by fn
+// is recorded on the span and passed through unchanged.
+func (in *Instrumentation) Run(ctx context.Context, operation string, fn func(context.Context) error, attrs ...attribute.KeyValue) error {
+	ctx, span := in.tracer.Start(ctx, "clli."+operation,
+		trace.WithAttributes(append(attrs, AttrOperation.String(operation))...))
+	defer span.End()
+
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(AttrErrorCode.String(clli.ErrorCodeOf(err).String()))
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package clliotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// newTestInstrumentation returns an Instrumentation backed by in-memory exporters
+func newTestInstrumentation(t *testing.T) (*Instrumentation, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	in, err := New(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	return in, recorder, reader
+}
+
+// TestParseBatch tests that batch parses produce an annotated span
+func TestParseBatch(t *testing.T) {
+	in, recorder, _ := newTestInstrumentation(t)
+
+	results, errs := in.ParseBatch(context.Background(), clli.MustNewParser(nil),
+		[]string{"CHCGIL01DS0", "CHCGZZ01DS0", "MPLSMNB1234"})
+	require.Len(t, results, 3)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.NoError(t, errs[2])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "clli.ParseBatch", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), AttrRecordCount.Int(3))
+	assert.Contains(t, span.Attributes(), AttrErrorCount.Int(1))
+	require.Len(t, span.Events(), 1)
+	assert.Contains(t, span.Events()[0].Attributes, AttrErrorCode.String("bad_region"))
+}
+
+// TestRun tests wrapping arbitrary operations in spans
+func TestRun(t *testing.T) {
+	in, recorder, _ := newTestInstrumentation(t)
+
+	err := in.Run(context.Background(), "dataset_load", func(context.Context) error { return nil },
+		AttrRecordCount.Int(10))
+	assert.NoError(t, err)
+
+	boom := errors.New("boom")
+	err = in.Run(context.Background(), "resolve", func(context.Context) error { return boom })
+	assert.ErrorIs(t, err, boom)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "clli.dataset_load", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), AttrRecordCount.Int(10))
+	assert.Equal(t, "clli.resolve", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+// TestOnParse tests that parse outcomes are exported as metrics
+func TestOnParse(t *testing.T) {
+	in, _, reader := newTestInstrumentation(t)
+
+	p := clli.MustNewParser(nil)
+	p.SetMetricsHook(in)
+	_, _ = p.Parse("CHCGIL01DS0")
+	_, _ = p.Parse("CHCGZZ01DS0")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var total int64
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "clli.parses" {
+			for _, dp := range sum.DataPoints {
+				total += dp.Value
+			}
+		}
+	}
+	assert.Equal(t, int64(2), total)
+}
@@ -0,0 +1,128 @@
+// Package clliprom provides a Prometheus implementation of clli.MetricsHook
+// and clli.CacheMetrics.
+//
+// Register one hook per feed (distinguished by constant labels) to monitor
+// validation failure rates without wrapping every parse call:
+//
+//	hook := clliprom.NewMetricsHook(clliprom.Opts{
+//		Namespace:   "inventory",
+//		ConstLabels: prometheus.Labels{"feed": "lerg"},
+//	})
+//	prometheus.MustRegister(hook)
+//
+//	p := clli.MustNewParser(nil)
+//	p.SetMetricsHook(hook)
+//
+// The hook also records the lookups of a parse cache in front of the
+// Parser. clli.SetMetricsHook(hook) installs it as the default for the
+// package-level parse functions and for Parsers without a hook of their own.
+//
+// clliprom is a module of its own, so importing the core clli package does
+// not pull in the Prometheus client.
+package clliprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Opts configures the metric names and labels produced by a MetricsHook.
+type Opts struct {
+	// Namespace and Subsystem prefix every metric name (Subsystem defaults to "clli").
+	Namespace string
+	Subsystem string
+
+	// ConstLabels are attached to every metric, e.g. to identify the feed.
+	ConstLabels prometheus.Labels
+
+	// Buckets overrides the parse duration histogram buckets.
+	Buckets []float64
+}
+
+// MetricsHook records parse outcomes and parse cache lookups as Prometheus
+// metrics. It implements clli.MetricsHook, clli.CacheMetrics and
+// prometheus.Collector.
+type MetricsHook struct {
+	parses       *prometheus.CounterVec
+	duration     prometheus.Histogram
+	cacheLookups *prometheus.CounterVec
+}
+
+var (
+	_ clli.MetricsHook  = (*MetricsHook)(nil)
+	_ clli.CacheMetrics = (*MetricsHook)(nil)
+)
+
+// NewMetricsHook creates a MetricsHook with the given options.
+// The returned hook must be registered with a prometheus.Registerer to be exported.
+func NewMetricsHook(opts Opts) *MetricsHook {
+	subsystem := opts.Subsystem
+	if subsystem == "" {
+		subsystem = "clli"
+	}
+	buckets := opts.Buckets
+	if buckets == nil {
+		buckets = []float64{250e-9, 500e-9, 1e-6, 2.5e-6, 5e-6, 10e-6, 50e-6}
+	}
+
+	return &MetricsHook{
+		parses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "parses_total",
+			Help:        "Number of CLLI parses by resulting type and error code.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"type", "code"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "parse_duration_seconds",
+			Help:        "Time spent parsing CLLI codes.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     buckets,
+		}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "cache_lookups_total",
+			Help:        "Number of CLLI parse cache lookups by result.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"result"}),
+	}
+}
+
+// OnParse implements clli.MetricsHook.
+func (h *MetricsHook) OnParse(result *clli.CLLI, code clli.ErrorCode, duration time.Duration) {
+	typ := clli.CLLITypeUnknown
+	if result != nil {
+		typ = result.Type()
+	}
+	h.parses.WithLabelValues(typ.String(), code.String()).Inc()
+	h.duration.Observe(duration.Seconds())
+}
+
+// OnCacheLookup implements clli.CacheMetrics.
+func (h *MetricsHook) OnCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	h.cacheLookups.WithLabelValues(result).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (h *MetricsHook) Describe(ch chan<- *prometheus.Desc) {
+	h.parses.Describe(ch)
+	h.duration.Describe(ch)
+	h.cacheLookups.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (h *MetricsHook) Collect(ch chan<- prometheus.Metric) {
+	h.parses.Collect(ch)
+	h.duration.Collect(ch)
+	h.cacheLookups.Collect(ch)
+}
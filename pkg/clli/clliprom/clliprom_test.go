@@ -0,0 +1,53 @@
+package clliprom
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestMetricsHook tests that parse outcomes are recorded per type and error code
+func TestMetricsHook(t *testing.T) {
+	hook := NewMetricsHook(Opts{Namespace: "test", ConstLabels: prometheus.Labels{"feed": "unit"}})
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(hook))
+
+	p := clli.MustNewParser(nil)
+	p.SetMetricsHook(hook)
+
+	_, err := p.Parse("CHCGIL01DS0")
+	require.NoError(t, err)
+	_, err = p.Parse("CHCGZZ01DS0")
+	require.Error(t, err)
+	_, err = p.Parse("CHCGZZ01DS0")
+	require.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(hook.parses.WithLabelValues("Entity", "none")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(hook.parses.WithLabelValues("Unknown", "bad_region")))
+
+	count, err := testutil.GatherAndCount(reg, "test_clli_parse_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// TestMetricsHookCache tests that parse cache lookups are recorded through the Parser's hook
+func TestMetricsHookCache(t *testing.T) {
+	hook := NewMetricsHook(Opts{})
+	p := clli.MustNewParser(nil)
+	p.SetMetricsHook(hook)
+
+	pc := clli.NewParseCache(0, p)
+	for range 3 {
+		_, err := pc.Parse("CHCGIL01DS0")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(hook.cacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hook.cacheLookups.WithLabelValues("miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hook.parses.WithLabelValues("Entity", "none")))
+}
@@ -0,0 +1,210 @@
+// Package clliproto provides the protobuf schema for CLLIs and converters
+// between it and clli.CLLI.
+//
+// The message types are hand-written to match clli.proto and encode the same
+// wire format with Marshal and Unmarshal, so services can exchange CLLIs with
+// any protobuf implementation without a code generation step:
+//
+//	msg := clliproto.ToProto(c)
+//	data, err := msg.Marshal()
+//
+//	var in clliproto.Clli
+//	err = in.Unmarshal(data)
+//	c, err = clliproto.FromProto(&in)
+//
+// clliproto is a module of its own, so importing the core clli package does
+// not pull in the protobuf runtime.
+package clliproto
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ClliType is the classification of a CLLI. Values match clli.CLLIType.
+type ClliType int32
+
+// CLLI types
+const (
+	ClliTypeUnspecified ClliType = 0
+	ClliTypeEntity      ClliType = 1
+	ClliTypeNonBuilding ClliType = 2
+	ClliTypeCustomer    ClliType = 3
+)
+
+// Clli is a parsed CLLI with its components, matching the Clli message.
+type Clli struct {
+	Code         string
+	Place        string
+	Region       string
+	NetworkSite  string
+	EntityCode   string
+	LocationCode string
+	LocationID   string
+	CustomerCode string
+	CustomerID   string
+	Type         ClliType
+	Valid        bool
+}
+
+// ToProto converts c to its protobuf message. A nil c yields nil.
+func ToProto(c *clli.CLLI) *Clli {
+	if c == nil {
+		return nil
+	}
+	return &Clli{
+		Code:         c.Format(),
+		Place:        c.Place,
+		Region:       c.Region,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+		Type:         ClliType(c.Type()),
+		Valid:        c.IsValid(),
+	}
+}
+
+// FromProto converts a protobuf message to a CLLI, validating it with
+// clli.Parse. The code is parsed if set; otherwise it is assembled from the
+// components. Returns an error if m is nil or the code is invalid.
+func FromProto(m *Clli) (*clli.CLLI, error) {
+	if m == nil {
+		return nil, errors.New("clliproto: nil message")
+	}
+	code := m.Code
+	if code == "" {
+		c := clli.CLLI{
+			Place:        m.Place,
+			Region:       m.Region,
+			NetworkSite:  m.NetworkSite,
+			EntityCode:   m.EntityCode,
+			LocationCode: m.LocationCode,
+			LocationID:   m.LocationID,
+			CustomerCode: m.CustomerCode,
+			CustomerID:   m.CustomerID,
+		}
+		code = c.Format()
+	}
+	return clli.Parse(code)
+}
+
+// Clli field numbers
+const (
+	fieldCode protowire.Number = iota + 1
+	fieldPlace
+	fieldRegion
+	fieldNetworkSite
+	fieldEntityCode
+	fieldLocationCode
+	fieldLocationID
+	fieldCustomerCode
+	fieldCustomerID
+	fieldType
+	fieldValid
+)
+
+// Marshal encodes m in the protobuf wire format.
+func (m *Clli) Marshal() ([]byte, error) {
+	return m.appendTo(nil), nil
+}
+
+// appendTo appends the wire encoding of m to b. Fields with zero values
+// are omitted, as in proto3.
+func (m *Clli) appendTo(b []byte) []byte {
+	for _, f := range m.strings() {
+		b = appendString(b, f.num, *f.value)
+	}
+	if m.Type != ClliTypeUnspecified {
+		b = protowire.AppendTag(b, fieldType, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Type))
+	}
+	if m.Valid {
+		b = protowire.AppendTag(b, fieldValid, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	return b
+}
+
+// Unmarshal decodes the protobuf wire format into m, replacing its
+// contents. Unknown fields are skipped.
+func (m *Clli) Unmarshal(data []byte) error {
+	*m = Clli{}
+	strs := m.strings()
+	return consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num >= fieldCode && num <= fieldCustomerID && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			*strs[num-fieldCode].value = v
+			return n, nil
+		case num == fieldType && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.Type = ClliType(v)
+			return n, nil
+		case num == fieldValid && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.Valid = v != 0
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+}
+
+// stringField is a string field of a message and its field number.
+type stringField struct {
+	num   protowire.Number
+	value *string
+}
+
+// strings returns the string fields of m in field number order.
+func (m *Clli) strings() []stringField {
+	return []stringField{
+		{fieldCode, &m.Code},
+		{fieldPlace, &m.Place},
+		{fieldRegion, &m.Region},
+		{fieldNetworkSite, &m.NetworkSite},
+		{fieldEntityCode, &m.EntityCode},
+		{fieldLocationCode, &m.LocationCode},
+		{fieldLocationID, &m.LocationID},
+		{fieldCustomerCode, &m.CustomerCode},
+		{fieldCustomerID, &m.CustomerID},
+	}
+}
+
+// appendString appends a string field to b, omitting an empty value.
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+// consumeFields calls field for each field in data. field returns the
+// length of the field value it consumed, or a negative protowire error
+// code.
+func consumeFields(data []byte, field func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("clliproto: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		n, err := field(num, typ, data)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("clliproto: field %d: %w", num, protowire.ParseError(n))
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package clliproto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// clliDescriptor builds the Clli message descriptor from clli.proto, so the
+// hand-written encoding can be checked against the protobuf runtime.
+func clliDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	str := func(name string, num int32) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(num),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+	}
+	fields := []*descriptorpb.FieldDescriptorProto{
+		str("code", 1), str("place", 2), str("region", 3), str("network_site", 4),
+		str("entity_code", 5), str("location_code", 6), str("location_id", 7),
+		str("customer_code", 8), str("customer_id", 9),
+		{
+			Name:     proto.String("type"),
+			Number:   proto.Int32(10),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+			TypeName: proto.String(".dbitech.clli.v1.ClliType"),
+		},
+		{
+			Name:   proto.String("valid"),
+			Number: proto.Int32(11),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+		},
+	}
+	var values []*descriptorpb.EnumValueDescriptorProto
+	for i, name := range []string{"CLLI_TYPE_UNSPECIFIED", "CLLI_TYPE_ENTITY", "CLLI_TYPE_NON_BUILDING", "CLLI_TYPE_CUSTOMER"} {
+		values = append(values, &descriptorpb.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(int32(i))})
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("clli.proto"),
+		Package:     proto.String("dbitech.clli.v1"),
+		Syntax:      proto.String("proto3"),
+		EnumType:    []*descriptorpb.EnumDescriptorProto{{Name: proto.String("ClliType"), Value: values}},
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Clli"), Field: fields}},
+	}, nil)
+	require.NoError(t, err)
+	return file.Messages().ByName("Clli")
+}
+
+func TestToProto(t *testing.T) {
+	m := ToProto(clli.MustParse("chcgil01ds0"))
+	assert.Equal(t, &Clli{
+		Code:        "CHCGIL01DS0",
+		Place:       "CHCG",
+		Region:      "IL",
+		NetworkSite: "01",
+		EntityCode:  "DS0",
+		Type:        ClliTypeEntity,
+		Valid:       true,
+	}, m)
+	assert.Nil(t, ToProto(nil))
+}
+
+func TestFromProto(t *testing.T) {
+	c, err := FromProto(&Clli{Code: "NYCMNYBXMCA"})
+	require.NoError(t, err)
+	assert.Equal(t, "NYCMNYBXMCA", c.Format())
+
+	c, err = FromProto(&Clli{Place: "CHCG", Region: "IL", LocationCode: "B", LocationID: "1234"})
+	require.NoError(t, err)
+	assert.Equal(t, clli.CLLITypeNonBuilding, c.Type())
+
+	_, err = FromProto(&Clli{Code: "CHCGZZ01DS0"})
+	assert.ErrorIs(t, err, clli.ErrInvalidRegion)
+	_, err = FromProto(nil)
+	assert.Error(t, err)
+}
+
+func TestWireCompatibility(t *testing.T) {
+	desc := clliDescriptor(t)
+
+	for _, code := range []string{"CHCGIL01DS0", "CHCGILB1234", "CHCGIL1A2345"} {
+		t.Run(code, func(t *testing.T) {
+			want := ToProto(clli.MustParse(code))
+			data, err := want.Marshal()
+			require.NoError(t, err)
+
+			// Decoded by the protobuf runtime
+			dyn := dynamicpb.NewMessage(desc)
+			require.NoError(t, proto.Unmarshal(data, dyn))
+			assert.Equal(t, want.Code, dyn.Get(desc.Fields().ByName("code")).String())
+			assert.Equal(t, protoreflect.EnumNumber(want.Type), dyn.Get(desc.Fields().ByName("type")).Enum())
+			assert.True(t, dyn.Get(desc.Fields().ByName("valid")).Bool())
+
+			// Encoded by the protobuf runtime
+			data, err = proto.Marshal(dyn)
+			require.NoError(t, err)
+			var got Clli
+			require.NoError(t, got.Unmarshal(data))
+			assert.Equal(t, want, &got)
+		})
+	}
+
+	var m Clli
+	assert.Error(t, m.Unmarshal([]byte{0x0a, 0x05, 'C'}))
+}
+
+func TestValidationService(t *testing.T) {
+	var s ValidationService
+
+	resp, err := s.Validate(context.Background(), &ValidateRequest{Code: "CHCGIL01DS0"})
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, "CHCGIL01DS0", resp.Clli.Code)
+
+	resp, err = s.Validate(context.Background(), &ValidateRequest{Code: "CHCGZZ01DS0"})
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	assert.Nil(t, resp.Clli)
+	assert.Equal(t, "bad_region", resp.ErrorCode)
+
+	// Round trip through the wire format
+	resp, err = s.Validate(context.Background(), &ValidateRequest{Code: "CHCGIL01DS0"})
+	require.NoError(t, err)
+	data, err := resp.Marshal()
+	require.NoError(t, err)
+	var got ValidateResponse
+	require.NoError(t, got.Unmarshal(data))
+	assert.Equal(t, resp, &got)
+
+	data, err = (&ValidateRequest{Code: "CHCGIL01DS0"}).Marshal()
+	require.NoError(t, err)
+	var req ValidateRequest
+	require.NoError(t, req.Unmarshal(data))
+	assert.Equal(t, "CHCGIL01DS0", req.Code)
+}
@@ -0,0 +1,19 @@
+module github.com/dbitech/go-clli/pkg/clli/clliproto
+
+go 1.25
+
+require (
+	github.com/dbitech/go-clli v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.11.1
+	google.golang.org/protobuf v1.36.8
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/dbitech/go-clli => ../../..
@@ -0,0 +1,113 @@
+package clliproto
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ValidateRequest is the ClliValidator.Validate request.
+type ValidateRequest struct {
+	Code string
+}
+
+// ValidateResponse is the ClliValidator.Validate response.
+type ValidateResponse struct {
+	Valid     bool
+	Clli      *Clli  // Set when the code parses
+	Error     string // Parse error message
+	ErrorCode string // clli.ErrorCode name, such as "bad_region"
+}
+
+// ValidationService implements the ClliValidator service over the
+// hand-written message types. It is not a generated gRPC server: services
+// that register stubs generated from clli.proto convert between the
+// generated messages and these types in their handler, or decode requests
+// with Unmarshal and encode responses with Marshal.
+type ValidationService struct {
+	// Parser parses requests. Nil uses clli.Parse.
+	Parser *clli.Parser
+}
+
+// Validate parses the requested code. Invalid codes are reported in the
+// response rather than as an error, so the error is always nil.
+func (s *ValidationService) Validate(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	var c *clli.CLLI
+	var err error
+	if s.Parser != nil {
+		c, err = s.Parser.Parse(req.Code)
+	} else {
+		c, err = clli.Parse(req.Code)
+	}
+	if err != nil {
+		return &ValidateResponse{Error: err.Error(), ErrorCode: clli.ErrorCodeOf(err).String()}, nil
+	}
+	return &ValidateResponse{Valid: c.IsValid(), Clli: ToProto(c)}, nil
+}
+
+// Marshal encodes r in the protobuf wire format.
+func (r *ValidateRequest) Marshal() ([]byte, error) {
+	return appendString(nil, 1, r.Code), nil
+}
+
+// Unmarshal decodes the protobuf wire format into r, replacing its
+// contents. Unknown fields are skipped.
+func (r *ValidateRequest) Unmarshal(data []byte) error {
+	*r = ValidateRequest{}
+	return consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num == 1 && typ == protowire.BytesType {
+			v, n := protowire.ConsumeString(b)
+			r.Code = v
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+}
+
+// Marshal encodes r in the protobuf wire format.
+func (r *ValidateResponse) Marshal() ([]byte, error) {
+	var b []byte
+	if r.Valid {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	if r.Clli != nil {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Clli.appendTo(nil))
+	}
+	b = appendString(b, 3, r.Error)
+	b = appendString(b, 4, r.ErrorCode)
+	return b, nil
+}
+
+// Unmarshal decodes the protobuf wire format into r, replacing its
+// contents. Unknown fields are skipped.
+func (r *ValidateResponse) Unmarshal(data []byte) error {
+	*r = ValidateResponse{}
+	return consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.Valid = v != 0
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			r.Clli = &Clli{}
+			return n, r.Clli.Unmarshal(v)
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			r.Error = v
+			return n, nil
+		case num == 4 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			r.ErrorCode = v
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+}
@@ -0,0 +1,184 @@
+// Package cllirb provides a compatibility adapter reproducing the behaviour
+// of the Ruby clli gem (steventwheeler/clli), for pipelines migrating from
+// Ruby tooling that must produce identical output during cutover.
+//
+// Records use the gem's attribute names (place, region, network_site,
+// entity_code, location_code, location_id, customer_code, customer_id)
+// and marshal to the same JSON as the gem's attribute hash, with absent
+// attributes rendered as null.
+//
+// The gem matches entity codes against the Table B–E patterns listed in
+// docs/SPECIFICATIONS.md, built from the character classes x1 (letters other
+// than B, D, I, O, T, U, W and Y, plus digits) and x2 (letters other than G,
+// plus digits). Its results differ from clli.Parse in these cases:
+//
+//   - Table B equipment prefixes are limited to MG, SG, CG, DS, RL, PS, RP,
+//     CM, VS, OS and OL; Parse also accepts the prefixes RT, SW, MS and XC
+//     (e.g. "CHCGIL01RT1").
+//   - Region codes are any two letters; Parse requires a registered region.
+//   - An 8-character code is an entity CLLI without an entity code; Parse
+//     reports it as a non-building CLLI.
+//   - A place code may be padded with a trailing space (e.g. "TOR ON01DS0");
+//     Parse rejects embedded spaces.
+//   - 15-character customer codes are not recognized.
+//   - Input is upcased but surrounding whitespace is not trimmed, so
+//     " CHCGIL01DS0" is invalid.
+package cllirb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Kind is the gem's classification of a CLLI code.
+type Kind string
+
+// CLLI kinds, named as in the gem.
+const (
+	KindEntity      Kind = "entity"
+	KindNonBuilding Kind = "non_building_location"
+	KindCustomer    Kind = "customer_location"
+)
+
+// ErrInvalid is returned for codes the gem does not accept.
+var ErrInvalid = errors.New("cllirb: invalid CLLI")
+
+// Record holds the attributes the gem reports for a CLLI code.
+// Attributes that do not apply to the code's kind are empty.
+type Record struct {
+	Kind         Kind
+	Place        string
+	Region       string
+	NetworkSite  string
+	EntityCode   string
+	LocationCode string
+	LocationID   string
+	CustomerCode string
+	CustomerID   string
+}
+
+// Character classes and entity code patterns (Tables B–E) used by the gem.
+const (
+	x1 = `[ACE-HJ-NP-SVXZ0-9]`
+	x2 = `[A-FH-Z0-9]`
+
+	entityCode = `(?:MG|SG|CG|DS|RL|PS|RP|CM|VS|OS|OL|[0-9]{2})` + x1 + // Table B
+		`|[CB0-9][0-9]T|[0-9]GT|Z[A-Z]Z|RS[0-9]|X[A-Z]X|CT` + x1 + // Table B
+		`|[0-9][CDBINQWMVROLPEUTZ0-9]B` + // Table C
+		`|[0-9][AXCTWDEINPQ]D|[A-Z0-9][UM]D` + // Table D
+		`|[FAEKMPSTW]` + x2 + x1 + `|Q[0-9][0-9]` // Table E
+)
+
+// Patterns used by the gem, anchored to the full code.
+var (
+	entityPattern      = regexp.MustCompile(`^([A-Z]{3}[A-Z ])([A-Z]{2})([A-Z]{2}|[0-9]{2})(` + entityCode + `)?$`)
+	nonBuildingPattern = regexp.MustCompile(`^([A-Z]{3}[A-Z ])([A-Z]{2})([A-Z])([0-9]{4})$`)
+	customerPattern    = regexp.MustCompile(`^([A-Z]{3}[A-Z ])([A-Z]{2})([0-9])([A-Z][0-9]{3})$`)
+)
+
+// Parse parses s the way the gem does. Returns an error wrapping ErrInvalid
+// if the gem would reject the code.
+func Parse(s string) (*Record, error) {
+	code := strings.ToUpper(s)
+
+	if m := entityPattern.FindStringSubmatch(code); m != nil {
+		return &Record{Kind: KindEntity, Place: m[1], Region: m[2], NetworkSite: m[3], EntityCode: m[4]}, nil
+	}
+	if m := nonBuildingPattern.FindStringSubmatch(code); m != nil {
+		return &Record{Kind: KindNonBuilding, Place: m[1], Region: m[2], LocationCode: m[3], LocationID: m[4]}, nil
+	}
+	if m := customerPattern.FindStringSubmatch(code); m != nil {
+		return &Record{Kind: KindCustomer, Place: m[1], Region: m[2], CustomerCode: m[3], CustomerID: m[4]}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrInvalid, s)
+}
+
+// FromCLLI converts a CLLI parsed by this module to the gem's representation.
+// The kind follows the component fields, so an 8-character code becomes an
+// entity as it would in the gem. Returns nil for a nil CLLI.
+func FromCLLI(c *clli.CLLI) *Record {
+	if c == nil {
+		return nil
+	}
+
+	r := &Record{Place: c.Place, Region: c.Region}
+	switch {
+	case c.CustomerCode != "":
+		r.Kind = KindCustomer
+		r.CustomerCode = c.CustomerCode
+		r.CustomerID = c.CustomerID
+	case c.LocationCode != "":
+		r.Kind = KindNonBuilding
+		r.LocationCode = c.LocationCode
+		r.LocationID = c.LocationID
+	default:
+		r.Kind = KindEntity
+		r.NetworkSite = c.NetworkSite
+		r.EntityCode = c.EntityCode
+	}
+	return r
+}
+
+// String returns the code reassembled from its attributes.
+func (r *Record) String() string {
+	return r.Place + r.Region + r.NetworkSite + r.EntityCode +
+		r.LocationCode + r.LocationID + r.CustomerCode + r.CustomerID
+}
+
+// attributes returns the gem's attribute names and values in hash order.
+func (r *Record) attributes() []struct{ name, value string } {
+	return []struct{ name, value string }{
+		{"place", r.Place},
+		{"region", r.Region},
+		{"network_site", r.NetworkSite},
+		{"entity_code", r.EntityCode},
+		{"location_code", r.LocationCode},
+		{"location_id", r.LocationID},
+		{"customer_code", r.CustomerCode},
+		{"customer_id", r.CustomerID},
+	}
+}
+
+// Attributes returns the gem's attribute hash. Attributes that do not apply
+// to the record's kind map to nil.
+func (r *Record) Attributes() map[string]any {
+	attrs := make(map[string]any, 8)
+	for _, a := range r.attributes() {
+		if a.value == "" {
+			attrs[a.name] = nil
+		} else {
+			attrs[a.name] = a.value
+		}
+	}
+	return attrs
+}
+
+// MarshalJSON encodes the attribute hash with keys in the gem's order.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, a := range r.attributes() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q:", a.name)
+		if a.value == "" {
+			buf.WriteString("null")
+			continue
+		}
+		value, err := json.Marshal(a.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
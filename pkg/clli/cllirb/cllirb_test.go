@@ -0,0 +1,79 @@
+package cllirb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestParse tests the gem's classification and field naming
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Record
+	}{
+		{"MPLSMNMSDS1", Record{Kind: KindEntity, Place: "MPLS", Region: "MN", NetworkSite: "MS", EntityCode: "DS1"}},
+		{"chcgil01ds0", Record{Kind: KindEntity, Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "DS0"}},
+		{"CHCGIL01RL1", Record{Kind: KindEntity, Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "RL1"}},
+		{"CHCGIL01345", Record{Kind: KindEntity, Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "345"}},
+		{"CHCGIL01FA1", Record{Kind: KindEntity, Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "FA1"}},
+		{"LSANCA12", Record{Kind: KindEntity, Place: "LSAN", Region: "CA", NetworkSite: "12"}},
+		{"TOR ON01DS0", Record{Kind: KindEntity, Place: "TOR ", Region: "ON", NetworkSite: "01", EntityCode: "DS0"}},
+		{"LABSZZ01DS0", Record{Kind: KindEntity, Place: "LABS", Region: "ZZ", NetworkSite: "01", EntityCode: "DS0"}},
+		{"CHCGILB1234", Record{Kind: KindNonBuilding, Place: "CHCG", Region: "IL", LocationCode: "B", LocationID: "1234"}},
+		{"DLLSTX1A234", Record{Kind: KindCustomer, Place: "DLLS", Region: "TX", CustomerCode: "1", CustomerID: "A234"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *r)
+		})
+	}
+}
+
+// TestParseInvalid tests codes the gem rejects
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{"", " CHCGIL01DS0", "CHCGIL0ADS0", "CHCGIL01RT1", "CHCGIL01DSB", "DLLSTX011234567", "CHCGIL", "CHCG1L01DS0"} {
+		t.Run(input, func(t *testing.T) {
+			r, err := Parse(input)
+			assert.ErrorIs(t, err, ErrInvalid)
+			assert.Nil(t, r)
+		})
+	}
+}
+
+// TestFromCLLI tests conversion from the module's representation
+func TestFromCLLI(t *testing.T) {
+	for _, code := range []string{"CHCGIL01DS0", "LSANCA12", "CHCGILB1234", "DLLSTX1A234"} {
+		t.Run(code, func(t *testing.T) {
+			want, err := Parse(code)
+			require.NoError(t, err)
+			got := FromCLLI(clli.MustParse(code))
+			assert.Equal(t, want, got)
+			assert.Equal(t, code, got.String())
+		})
+	}
+
+	assert.Nil(t, FromCLLI(nil))
+}
+
+// TestMarshalJSON tests the gem-compatible attribute hash encoding
+func TestMarshalJSON(t *testing.T) {
+	r, err := Parse("CHCGILB1234")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"place":"CHCG","region":"IL","network_site":null,"entity_code":null,`+
+		`"location_code":"B","location_id":"1234","customer_code":null,"customer_id":null}`, string(data))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, r.Attributes(), decoded)
+}
@@ -0,0 +1,5 @@
+// Package cllitest provides helpers for testing code that consumes CLLI codes.
+// It derives invalid variants of valid codes and builds reproducible fixtures,
+// so both this module and downstream systems can exercise their error paths
+// and inventory logic without hand-maintained test data.
+package cllitest
@@ -0,0 +1,39 @@
+package cllitest
+
+// corpusCodes lists valid codes of every layout used to seed FuzzCorpus.
+var corpusCodes = []string{
+	"CHCGIL01DS0",     // Entity
+	"NYCMNYBXMCA",     // Entity with alphabetic site
+	"TOROONXN01T",     // Canadian entity
+	"CHCGIL01",        // Building
+	"DLLSTXB1234",     // Non-building location
+	"MPLSMN1A2345",    // Customer
+	"MPLSMN1A234",     // Customer with short ID
+	"CHCGIL011234567", // Customer at a network site
+}
+
+// FuzzCorpus returns seed inputs for native Go fuzz targets that feed CLLIs
+// through downstream code: valid codes of every layout, lowercase and
+// padded spellings, and the invalid variants produced by Mutate. Add them
+// with f.Add so the fuzzer starts from realistic codes:
+//
+//	for _, s := range cllitest.FuzzCorpus() {
+//		f.Add(s)
+//	}
+func FuzzCorpus() []string {
+	var corpus []string
+	for _, code := range corpusCodes {
+		corpus = append(corpus, code, "  "+code+"\n")
+	}
+	corpus = append(corpus, "chcgil01ds0", "", "CHCG", "CHCGIL", "RYE NY01DS0")
+	for _, code := range corpusCodes {
+		mutations, err := Mutate(code)
+		if err != nil {
+			continue
+		}
+		for _, m := range mutations {
+			corpus = append(corpus, m.Input)
+		}
+	}
+	return corpus
+}
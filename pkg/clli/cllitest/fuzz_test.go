@@ -0,0 +1,50 @@
+package cllitest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestFuzzCorpus tests that the corpus holds both valid and invalid codes
+func TestFuzzCorpus(t *testing.T) {
+	corpus := FuzzCorpus()
+	valid := 0
+	for _, s := range corpus {
+		if _, err := clli.Parse(s); err == nil {
+			valid++
+		}
+	}
+	assert.GreaterOrEqual(t, valid, len(corpusCodes))
+	assert.Greater(t, len(corpus)-valid, len(corpusCodes))
+}
+
+// FuzzTextRoundTrip shows the intended use of FuzzCorpus: CLLIs passed
+// through an encoding must come back unchanged and consistent.
+func FuzzTextRoundTrip(f *testing.F) {
+	for _, s := range FuzzCorpus() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		c, err := clli.Parse(input)
+		if err != nil {
+			return
+		}
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got clli.CLLI
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+		if err := clli.Invariants(&got); err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+		if got.Format() != c.Format() {
+			t.Fatalf("%q round-tripped to %q", c.Format(), got.Format())
+		}
+	})
+}
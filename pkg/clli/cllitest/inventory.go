@@ -0,0 +1,165 @@
+package cllitest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// entityPool lists entity codes used for generated entity CLLIs.
+// All codes conform to the strict Bell table patterns accepted by Parse.
+var entityPool = []string{
+	"DS0", "DS1", "MG1", "SG1", "CG0", "RT1", "SW1", "MS1", "XC1", "PS1", "CM1", "01T", "0GT",
+}
+
+// nonBuildingPool lists location codes used for generated non-building CLLIs.
+var nonBuildingPool = []byte{'B', 'E', 'J', 'M', 'P'}
+
+// InventoryConfig controls the shape of a generated inventory fixture.
+type InventoryConfig struct {
+	// Seed makes generation reproducible: the same config always yields the same inventory.
+	Seed uint64
+
+	// Buildings is the number of building CLLIs to generate.
+	Buildings int
+
+	// States lists the region codes buildings are spread across (round-robin).
+	// Defaults to IL, NY, CA and TX when empty.
+	States []string
+
+	// EntitiesPerBuilding is the number of entity CLLIs per building (default 3).
+	EntitiesPerBuilding int
+
+	// CustomersPerBuilding is the number of customer CLLIs per building (default 1).
+	CustomersPerBuilding int
+
+	// NonBuildingPerBuilding is the number of non-building CLLIs per building (default 1).
+	NonBuildingPerBuilding int
+}
+
+// Building is a single generated site together with the codes located there.
+type Building struct {
+	CLLI        string   // 8-character building CLLI (place + region + network site)
+	Entities    []string // Entity CLLIs at the building
+	Customers   []string // Customer CLLIs sharing the building's place and region
+	NonBuilding []string // Non-building CLLIs sharing the building's place and region
+}
+
+// Inventory is a coherent, reproducible set of fake CLLI records.
+type Inventory struct {
+	Buildings []Building
+}
+
+// Codes returns every CLLI in the inventory in a stable order.
+func (inv *Inventory) Codes() []string {
+	var codes []string
+	for _, b := range inv.Buildings {
+		codes = append(codes, b.CLLI)
+		codes = append(codes, b.Entities...)
+		codes = append(codes, b.Customers...)
+		codes = append(codes, b.NonBuilding...)
+	}
+	return codes
+}
+
+// GenerateInventory builds a fake inventory of buildings spread across the
+// configured states, each with plausible entity, customer and non-building codes.
+// Every generated code parses with Parse, and the same config always produces
+// the same inventory.
+//
+// Returns an error if the configuration is invalid.
+func GenerateInventory(cfg InventoryConfig) (*Inventory, error) {
+	if cfg.Buildings < 0 {
+		return nil, fmt.Errorf("cllitest: negative building count %d", cfg.Buildings)
+	}
+
+	states := cfg.States
+	if len(states) == 0 {
+		states = []string{"IL", "NY", "CA", "TX"}
+	}
+	for _, s := range states {
+		if err := clli.ValidateRegion(s, true); err != nil {
+			return nil, fmt.Errorf("cllitest: state %q: %w", s, err)
+		}
+	}
+
+	entities := defaultCount(cfg.EntitiesPerBuilding, 3)
+	customers := defaultCount(cfg.CustomersPerBuilding, 1)
+	nonBuilding := defaultCount(cfg.NonBuildingPerBuilding, 1)
+	if entities > len(entityPool) {
+		return nil, fmt.Errorf("cllitest: at most %d entities per building are supported", len(entityPool))
+	}
+
+	r := rand.New(rand.NewPCG(cfg.Seed, 0x434c4c49))
+	inv := &Inventory{Buildings: make([]Building, 0, cfg.Buildings)}
+	sites := map[string]int{}
+	// open holds, per region, the sorted places with fewer than 99 sites
+	open := map[string][]string{}
+
+	for i := 0; i < cfg.Buildings; i++ {
+		region := states[i%len(states)]
+
+		// Reuse an existing place in the region half the time so sites share a city
+		var place string
+		if n := len(open[region]); n > 0 && r.IntN(2) == 0 {
+			place = open[region][r.IntN(n)]
+		} else {
+			for {
+				place = randomLetters(r, 4)
+				if _, ok := sites[place+region]; !ok {
+					break
+				}
+			}
+			j, _ := slices.BinarySearch(open[region], place)
+			open[region] = slices.Insert(open[region], j, place)
+		}
+
+		sites[place+region]++
+		if sites[place+region] == 99 {
+			j, _ := slices.BinarySearch(open[region], place)
+			open[region] = slices.Delete(open[region], j, j+1)
+		}
+		building := fmt.Sprintf("%s%s%02d", place, region, sites[place+region])
+
+		b := Building{CLLI: building}
+		for _, j := range r.Perm(len(entityPool))[:entities] {
+			b.Entities = append(b.Entities, building+entityPool[j])
+		}
+		sort.Strings(b.Entities)
+		for j := 0; j < customers; j++ {
+			b.Customers = append(b.Customers, fmt.Sprintf("%s%s%d%s%03d",
+				place, region, 1+r.IntN(9), randomLetters(r, 1), r.IntN(1000)))
+		}
+		for j := 0; j < nonBuilding; j++ {
+			b.NonBuilding = append(b.NonBuilding, fmt.Sprintf("%s%s%c%04d",
+				place, region, nonBuildingPool[r.IntN(len(nonBuildingPool))], r.IntN(10000)))
+		}
+
+		inv.Buildings = append(inv.Buildings, b)
+	}
+
+	return inv, nil
+}
+
+// defaultCount returns n, def when n is zero, or 0 when n is negative.
+func defaultCount(n, def int) int {
+	if n == 0 {
+		return def
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// randomLetters returns n random uppercase letters.
+func randomLetters(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('A' + r.IntN(26))
+	}
+	return string(b)
+}
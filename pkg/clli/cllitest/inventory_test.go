@@ -0,0 +1,95 @@
+package cllitest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestGenerateInventory tests that generated inventories are valid and coherent
+func TestGenerateInventory(t *testing.T) {
+	inv, err := GenerateInventory(InventoryConfig{Seed: 42, Buildings: 20, States: []string{"IL", "ON"}})
+	require.NoError(t, err)
+	require.Len(t, inv.Buildings, 20)
+
+	for _, b := range inv.Buildings {
+		assert.Len(t, b.CLLI, 8)
+		assert.Len(t, b.Entities, 3)
+		assert.Len(t, b.Customers, 1)
+		assert.Len(t, b.NonBuilding, 1)
+
+		for _, e := range b.Entities {
+			assert.True(t, strings.HasPrefix(e, b.CLLI))
+			c, err := clli.Parse(e)
+			require.NoError(t, err, e)
+			assert.Equal(t, clli.CLLITypeEntity, c.Type(), e)
+		}
+		for _, code := range b.Customers {
+			assert.True(t, strings.HasPrefix(code, b.CLLI[:6]))
+			c, err := clli.Parse(code)
+			require.NoError(t, err, code)
+			assert.Equal(t, clli.CLLITypeCustomer, c.Type(), code)
+		}
+		for _, code := range b.NonBuilding {
+			assert.True(t, strings.HasPrefix(code, b.CLLI[:6]))
+			c, err := clli.Parse(code)
+			require.NoError(t, err, code)
+			assert.Equal(t, clli.CLLITypeNonBuilding, c.Type(), code)
+		}
+	}
+
+	unique := map[string]bool{}
+	for _, b := range inv.Buildings {
+		assert.False(t, unique[b.CLLI], "duplicate building %s", b.CLLI)
+		unique[b.CLLI] = true
+	}
+}
+
+// TestGenerateInventoryLarge tests that no place exceeds the 99 network sites a CLLI can number
+func TestGenerateInventoryLarge(t *testing.T) {
+	for _, n := range []int{400, 1000} {
+		inv, err := GenerateInventory(InventoryConfig{Buildings: n, States: []string{"IL"}})
+		require.NoError(t, err)
+		require.Len(t, inv.Buildings, n)
+
+		unique := map[string]bool{}
+		for _, b := range inv.Buildings {
+			_, err := clli.Parse(b.CLLI)
+			require.NoError(t, err, b.CLLI)
+			assert.False(t, unique[b.CLLI], "duplicate building %s", b.CLLI)
+			unique[b.CLLI] = true
+		}
+	}
+}
+
+// TestGenerateInventoryDeterministic tests that the same seed yields the same fixture
+func TestGenerateInventoryDeterministic(t *testing.T) {
+	cfg := InventoryConfig{Seed: 7, Buildings: 10}
+
+	a, err := GenerateInventory(cfg)
+	require.NoError(t, err)
+	b, err := GenerateInventory(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, a.Codes(), b.Codes())
+
+	cfg.Seed = 8
+	c, err := GenerateInventory(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, a.Codes(), c.Codes())
+}
+
+// TestGenerateInventoryInvalidConfig tests configuration errors
+func TestGenerateInventoryInvalidConfig(t *testing.T) {
+	_, err := GenerateInventory(InventoryConfig{Buildings: -1})
+	assert.Error(t, err)
+
+	_, err = GenerateInventory(InventoryConfig{Buildings: 1, States: []string{"ZZ"}})
+	assert.Error(t, err)
+
+	_, err = GenerateInventory(InventoryConfig{Buildings: 1, EntitiesPerBuilding: 100})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package cllitest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// MutationKind identifies how a mutated variant was derived from the original.
+type MutationKind int
+
+const (
+	// MutationSubstitution replaces a single character
+	MutationSubstitution MutationKind = iota
+
+	// MutationTruncation removes trailing characters
+	MutationTruncation
+
+	// MutationSwap exchanges two adjacent components
+	MutationSwap
+)
+
+// String returns the string representation of the mutation kind
+func (k MutationKind) String() string {
+	switch k {
+	case MutationSubstitution:
+		return "Substitution"
+	case MutationTruncation:
+		return "Truncation"
+	case MutationSwap:
+		return "Swap"
+	default:
+		return "Unknown"
+	}
+}
+
+// Mutation describes a single invalid variant of a valid CLLI.
+type Mutation struct {
+	Input       string       // The mutated CLLI string
+	Kind        MutationKind // How the variant was derived
+	Component   string       // Component that was mutated (place, region, network_site, ...)
+	Field       string       // Field reported by the parser's ParseError
+	Err         error        // Error returned when parsing Input
+	Description string       // Human-readable description of the mutation
+}
+
+// segment is a named, positioned component of a parsed CLLI.
+type segment struct {
+	name  string
+	start int
+	value string
+}
+
+// substitutes lists replacement characters tried at every position.
+// Each class (letter, digit, symbol) breaks a different set of components.
+var substitutes = []byte{'Q', '7', '-'}
+
+// Mutate systematically derives invalid variants of a valid CLLI using
+// character substitutions, truncations and adjacent segment swaps.
+// Only variants that the parser actually rejects are returned, each labelled
+// with the component that was mutated and the field the parser reported.
+//
+// Returns an error if the input itself is not a valid CLLI.
+func Mutate(valid string) ([]Mutation, error) {
+	c, err := clli.Parse(valid)
+	if err != nil {
+		return nil, fmt.Errorf("cllitest: mutate requires a valid CLLI: %w", err)
+	}
+
+	code := c.Format()
+	segments := segmentsOf(c)
+	seen := map[string]bool{code: true}
+
+	var mutations []Mutation
+	add := func(input string, kind MutationKind, component, description string) {
+		if seen[input] {
+			return
+		}
+		seen[input] = true
+
+		_, err := clli.Parse(input)
+		if err == nil {
+			return
+		}
+
+		m := Mutation{
+			Input:       input,
+			Kind:        kind,
+			Component:   component,
+			Err:         err,
+			Description: description,
+		}
+		var pe *clli.ParseError
+		if errors.As(err, &pe) {
+			m.Field = pe.Field
+		}
+		mutations = append(mutations, m)
+	}
+
+	for _, s := range segments {
+		for i := 0; i < len(s.value); i++ {
+			pos := s.start + i
+			for _, r := range substitutes {
+				if code[pos] == r {
+					continue
+				}
+				b := []byte(code)
+				b[pos] = r
+				add(string(b), MutationSubstitution, s.name,
+					fmt.Sprintf("replace %q with %q at position %d", code[pos], r, pos))
+			}
+		}
+	}
+
+	for n := len(code) - 1; n > 0; n-- {
+		add(code[:n], MutationTruncation, componentAt(segments, n),
+			fmt.Sprintf("truncate to %d characters", n))
+	}
+
+	for i := 0; i+1 < len(segments); i++ {
+		a, b := segments[i], segments[i+1]
+		swapped := code[:a.start] + b.value + a.value + code[b.start+len(b.value):]
+		add(swapped, MutationSwap, a.name,
+			fmt.Sprintf("swap %s and %s", a.name, b.name))
+	}
+
+	return mutations, nil
+}
+
+// segmentsOf returns the populated components of c in string order.
+func segmentsOf(c *clli.CLLI) []segment {
+	fields := []segment{
+		{name: "place", value: c.Place},
+		{name: "region", value: c.Region},
+		{name: "network_site", value: c.NetworkSite},
+		{name: "entity_code", value: c.EntityCode},
+		{name: "location_code", value: c.LocationCode},
+		{name: "location_id", value: c.LocationID},
+		{name: "customer_code", value: c.CustomerCode},
+		{name: "customer_id", value: c.CustomerID},
+	}
+
+	var segments []segment
+	pos := 0
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		f.start = pos
+		pos += len(f.value)
+		segments = append(segments, f)
+	}
+	return segments
+}
+
+// componentAt returns the name of the component containing position pos.
+func componentAt(segments []segment, pos int) string {
+	for _, s := range segments {
+		if pos >= s.start && pos < s.start+len(s.value) {
+			return s.name
+		}
+	}
+	return "length"
+}
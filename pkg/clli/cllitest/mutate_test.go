@@ -0,0 +1,67 @@
+package cllitest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestMutate tests that every mutation is rejected by the parser and labelled
+func TestMutate(t *testing.T) {
+	inputs := []string{"CHCGIL01DS0", "MPLSMNB1234", "MPLSMN1A234", "LSANCA12"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			mutations, err := Mutate(input)
+			require.NoError(t, err)
+			require.NotEmpty(t, mutations)
+
+			kinds := map[MutationKind]int{}
+			for _, m := range mutations {
+				kinds[m.Kind]++
+				assert.NotEqual(t, input, m.Input)
+				assert.NotEmpty(t, m.Component, m.Description)
+				assert.NotEmpty(t, m.Field, m.Description)
+
+				_, err := clli.Parse(m.Input)
+				assert.Error(t, err, "mutation %q (%s) should be invalid", m.Input, m.Description)
+			}
+
+			assert.Positive(t, kinds[MutationSubstitution])
+			assert.Positive(t, kinds[MutationTruncation])
+		})
+	}
+}
+
+// TestMutateComponents tests that mutations target every component of an entity CLLI
+func TestMutateComponents(t *testing.T) {
+	mutations, err := Mutate("CHCGIL01DS0")
+	require.NoError(t, err)
+
+	components := map[string]bool{}
+	for _, m := range mutations {
+		components[m.Component] = true
+	}
+
+	for _, want := range []string{"place", "region", "network_site", "entity_code"} {
+		assert.True(t, components[want], "expected a mutation breaking %s", want)
+	}
+}
+
+// TestMutateInvalidInput tests that invalid seeds are rejected
+func TestMutateInvalidInput(t *testing.T) {
+	mutations, err := Mutate("INVALID")
+	assert.Error(t, err)
+	assert.Nil(t, mutations)
+}
+
+// TestMutationKindString tests the MutationKind string representation
+func TestMutationKindString(t *testing.T) {
+	assert.Equal(t, "Substitution", MutationSubstitution.String())
+	assert.Equal(t, "Truncation", MutationTruncation.String())
+	assert.Equal(t, "Swap", MutationSwap.String())
+	assert.Equal(t, "Unknown", MutationKind(99).String())
+}
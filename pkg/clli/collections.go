@@ -0,0 +1,148 @@
+package clli
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortBy sorts codes in place by the key returned for each CLLI.
+// The sort is stable, so codes with equal keys keep their relative order
+// and successive calls can build multi-level orderings.
+func SortBy[K cmp.Ordered](codes []*CLLI, key func(*CLLI) K) {
+	slices.SortStableFunc(codes, CompareBy(key))
+}
+
+// CompareBy returns a comparator ordering CLLIs by the key returned for
+// each, for use with slices.SortFunc and similar functions.
+func CompareBy[K cmp.Ordered](key func(*CLLI) K) func(a, b *CLLI) int {
+	return func(a, b *CLLI) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Compare returns the canonical ordering of a and b: by region, then place,
+// network site and entity code, then the non-building and customer fields.
+// Reports listing codes in this order group each building's entities
+// together under their place and region. A nil CLLI sorts first. Compare
+// can be passed to slices.SortFunc directly and agrees with comparing
+// SortKey values.
+func Compare(a, b *CLLI) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return cmp.Or(
+		strings.Compare(a.Region, b.Region),
+		strings.Compare(a.Place, b.Place),
+		strings.Compare(a.NetworkSite, b.NetworkSite),
+		strings.Compare(a.EntityCode, b.EntityCode),
+		strings.Compare(a.LocationCode, b.LocationCode),
+		strings.Compare(a.LocationID, b.LocationID),
+		strings.Compare(a.CustomerCode, b.CustomerCode),
+		strings.Compare(a.CustomerID, b.CustomerID),
+	)
+}
+
+// SortKey returns a string that orders like Compare, for sorting CLLIs in
+// databases and other stores that only compare strings. Each component but
+// the last is padded with spaces to its full width, so shorter components
+// sort first just as they do in Compare.
+func SortKey(c *CLLI) string {
+	var b strings.Builder
+	b.Grow(25)
+	for _, field := range []struct {
+		value string
+		width int
+	}{
+		{c.Region, 2},
+		{c.Place, 4},
+		{c.NetworkSite, 2},
+		{c.EntityCode, 3},
+		{c.LocationCode, 1},
+		{c.LocationID, 4},
+		{c.CustomerCode, 1},
+		{c.CustomerID, 0},
+	} {
+		b.WriteString(field.value)
+		for i := len(field.value); i < field.width; i++ {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// GroupBy partitions codes by the key returned for each CLLI.
+// Within each group, codes keep their input order.
+func GroupBy[K comparable](codes []*CLLI, key func(*CLLI) K) map[K][]*CLLI {
+	groups := make(map[K][]*CLLI)
+	for _, c := range codes {
+		k := key(c)
+		groups[k] = append(groups[k], c)
+	}
+	return groups
+}
+
+// CountBy returns the number of codes for each key returned by key.
+func CountBy[K comparable](codes []*CLLI, key func(*CLLI) K) map[K]int {
+	counts := make(map[K]int)
+	for _, c := range codes {
+		counts[key(c)]++
+	}
+	return counts
+}
+
+// GroupByRegion partitions codes by region code, as GroupBy(codes, ByState).
+func GroupByRegion(codes []*CLLI) map[string][]*CLLI {
+	return GroupBy(codes, ByState)
+}
+
+// GroupByPlace partitions codes by place and region, as GroupBy(codes, ByPlace).
+func GroupByPlace(codes []*CLLI) map[string][]*CLLI {
+	return GroupBy(codes, ByPlace)
+}
+
+// GroupBySite partitions codes by building, as GroupBy(codes, ByBuilding).
+func GroupBySite(codes []*CLLI) map[string][]*CLLI {
+	return GroupBy(codes, ByBuilding)
+}
+
+// Filter returns the codes for which keep returns true, in input order.
+// The input slice is not modified.
+func Filter(codes []*CLLI, keep func(*CLLI) bool) []*CLLI {
+	var out []*CLLI
+	for _, c := range codes {
+		if keep(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Common key functions for SortBy and GroupBy
+
+// ByState returns the region (state or province) code of c.
+func ByState(c *CLLI) string {
+	return c.Region
+}
+
+// ByPlace returns the place and region codes of c, such as "CHCGIL". The
+// region is included because the same place code is reused across regions.
+func ByPlace(c *CLLI) string {
+	return c.Place + c.Region
+}
+
+// ByBuilding returns the 8-character building CLLI of c (place, region and
+// network site), or the place and region alone for codes without a network site.
+func ByBuilding(c *CLLI) string {
+	return c.Place + c.Region + c.NetworkSite
+}
+
+// ByType returns the type of c.
+func ByType(c *CLLI) CLLIType {
+	return c.Type()
+}
@@ -0,0 +1,132 @@
+package clli
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// parseAll parses codes, failing on the first invalid one.
+func parseAll(t *testing.T, codes ...string) []*CLLI {
+	t.Helper()
+	out := make([]*CLLI, len(codes))
+	for i, code := range codes {
+		out[i] = MustParse(code)
+	}
+	return out
+}
+
+// formatAll returns the code of each CLLI.
+func formatAll(codes []*CLLI) []string {
+	out := make([]string, len(codes))
+	for i, c := range codes {
+		out[i] = c.Format()
+	}
+	return out
+}
+
+// TestSortBy tests stable sorting by key
+func TestSortBy(t *testing.T) {
+	codes := parseAll(t, "DLLSTX01DS0", "CHCGILB1234", "CHCGIL01DS0", "LSANCA12")
+
+	SortBy(codes, ByState)
+	assert.Equal(t, []string{"LSANCA12", "CHCGILB1234", "CHCGIL01DS0", "DLLSTX01DS0"}, formatAll(codes))
+
+	SortBy(codes, ByType)
+	assert.Equal(t, []string{"CHCGIL01DS0", "DLLSTX01DS0", "LSANCA12", "CHCGILB1234"}, formatAll(codes))
+}
+
+// TestCompare tests the canonical region, place, site, entity ordering
+func TestCompare(t *testing.T) {
+	codes := parseAll(t, "DLLSTX01DS0", "CHCGIL02DS0", "CHCGILB1234", "CHCGIL01MG1", "TOROON01DS0",
+		"CHCGIL01DS0", "AUSTTX01DS0", "CHCGIL1A2345", "CHCGIL12")
+
+	slices.SortFunc(codes, Compare)
+	assert.Equal(t, []string{
+		"CHCGIL1A2345", "CHCGILB1234", "CHCGIL01DS0", "CHCGIL01MG1", "CHCGIL02DS0", "CHCGIL12",
+		"TOROON01DS0",
+		"AUSTTX01DS0", "DLLSTX01DS0",
+	}, formatAll(codes))
+
+	// SortKey orders the same way
+	for i := 1; i < len(codes); i++ {
+		assert.Less(t, SortKey(codes[i-1]), SortKey(codes[i]))
+		assert.Equal(t, -1, Compare(codes[i-1], codes[i]))
+	}
+	assert.Equal(t, "ILCHCG01DS0", strings.TrimRight(SortKey(codes[2]), " "))
+
+	// Short places and sites sort before longer ones
+	short := &CLLI{Place: "RYE", Region: "NY", NetworkSite: "01", EntityCode: "DS0"}
+	long := MustParse("RYEBNY01DS0")
+	assert.Equal(t, -1, Compare(short, long))
+	assert.Less(t, SortKey(short), SortKey(long))
+
+	assert.Zero(t, Compare(MustParse("CHCGIL01DS0"), MustParse("chcgil01ds0")))
+	assert.Zero(t, Compare(nil, nil))
+	assert.Equal(t, -1, Compare(nil, codes[0]))
+	assert.Equal(t, 1, Compare(codes[0], nil))
+}
+
+// TestCompareBy tests comparators built from key functions
+func TestCompareBy(t *testing.T) {
+	codes := parseAll(t, "DLLSTX01DS0", "CHCGILB1234", "LSANCA12")
+
+	slices.SortFunc(codes, CompareBy(ByState))
+	assert.Equal(t, []string{"LSANCA12", "CHCGILB1234", "DLLSTX01DS0"}, formatAll(codes))
+
+	slices.SortFunc(codes, CompareBy(ByType))
+	assert.Equal(t, []string{"DLLSTX01DS0", "LSANCA12", "CHCGILB1234"}, formatAll(codes))
+}
+
+// TestGroupBy tests partitioning by key
+func TestGroupBy(t *testing.T) {
+	codes := parseAll(t, "CHCGIL01DS0", "CHCGIL01MG1", "CHCGIL02DS0", "CHCGILB1234", "DLLSTX01DS0")
+
+	byBuilding := GroupBy(codes, ByBuilding)
+	assert.Len(t, byBuilding, 4)
+	assert.Equal(t, []string{"CHCGIL01DS0", "CHCGIL01MG1"}, formatAll(byBuilding["CHCGIL01"]))
+	assert.Equal(t, []string{"CHCGILB1234"}, formatAll(byBuilding["CHCGIL"]))
+
+	byType := GroupBy(codes, ByType)
+	assert.Len(t, byType[CLLITypeEntity], 4)
+	assert.Len(t, byType[CLLITypeNonBuilding], 1)
+
+	assert.Empty(t, GroupBy(nil, ByState))
+}
+
+// TestGroupByHelpers tests grouping and counting by region, place and building
+func TestGroupByHelpers(t *testing.T) {
+	codes := parseAll(t, "CHCGIL01DS0", "CHCGIL01MG1", "CHCGIL02DS0", "CHCGILB1234", "DLLSTX01DS0", "CHCGTX01DS0")
+
+	byRegion := GroupByRegion(codes)
+	assert.Len(t, byRegion, 2)
+	assert.Equal(t, []string{"DLLSTX01DS0", "CHCGTX01DS0"}, formatAll(byRegion["TX"]))
+
+	byPlace := GroupByPlace(codes)
+	assert.Len(t, byPlace, 3)
+	assert.Len(t, byPlace["CHCGIL"], 4)
+	assert.Equal(t, []string{"CHCGTX01DS0"}, formatAll(byPlace["CHCGTX"]))
+
+	bySite := GroupBySite(codes)
+	assert.Equal(t, GroupBy(codes, ByBuilding), bySite)
+
+	assert.Equal(t, map[string]int{"CHCGIL": 4, "DLLSTX": 1, "CHCGTX": 1}, CountBy(codes, ByPlace))
+	assert.Equal(t, map[CLLIType]int{CLLITypeEntity: 5, CLLITypeNonBuilding: 1}, CountBy(codes, ByType))
+	assert.Empty(t, CountBy(nil, ByState))
+}
+
+// TestFilter tests selecting codes by predicate
+func TestFilter(t *testing.T) {
+	codes := parseAll(t, "CHCGIL01DS0", "CHCGILB1234", "DLLSTX01DS0")
+
+	entities := Filter(codes, (*CLLI).IsEntityCLLI)
+	assert.Equal(t, []string{"CHCGIL01DS0", "DLLSTX01DS0"}, formatAll(entities))
+
+	texas := Filter(codes, func(c *CLLI) bool { return ByState(c) == "TX" })
+	assert.Equal(t, []string{"DLLSTX01DS0"}, formatAll(texas))
+
+	assert.Empty(t, Filter(codes, func(*CLLI) bool { return false }))
+	assert.Len(t, codes, 3)
+}
@@ -0,0 +1,80 @@
+package clli
+
+import "strings"
+
+// NormalizeForCompare returns s in the form Parse normalizes input to:
+// surrounding whitespace removed and letters uppercased. Two strings that
+// normalize to the same value denote the same CLLI code.
+func NormalizeForCompare(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// EqualFold reports whether a and b denote the same CLLI code, ignoring case
+// and surrounding whitespace. For example "chcgil01ds0", " CHCGIL01DS0 " and
+// "CHCGIL01DS0" are all equal. The inputs are not validated.
+func EqualFold(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// Key is a comparable identity of a CLLI code, for use as a map key. Two
+// CLLIs denote the same code exactly when their keys are equal, however
+// their input was cased, spaced or padded.
+type Key struct {
+	Place        string // Place code without padding
+	Region       string
+	NetworkSite  string
+	EntityCode   string
+	LocationCode string
+	LocationID   string
+	CustomerCode string
+	CustomerID   string
+}
+
+// Key returns the canonical key of c.
+func (c *CLLI) Key() Key {
+	return Key{
+		Place:        strings.ToUpper(strings.TrimRight(c.Place, " ")),
+		Region:       strings.ToUpper(c.Region),
+		NetworkSite:  strings.ToUpper(c.NetworkSite),
+		EntityCode:   strings.ToUpper(c.EntityCode),
+		LocationCode: strings.ToUpper(c.LocationCode),
+		LocationID:   strings.ToUpper(c.LocationID),
+		CustomerCode: strings.ToUpper(c.CustomerCode),
+		CustomerID:   strings.ToUpper(c.CustomerID),
+	}
+}
+
+// dedupeRules normalize the variants collapsed by Dedupe: case, whitespace
+// and the default separators, including whitespace between components.
+var dedupeRules = NormalizeRules{
+	Uppercase:       true,
+	TrimWhitespace:  true,
+	StripSeparators: true,
+	Separators:      DefaultSeparators + " \t",
+	PadPlace:        true,
+}
+
+// Dedupe parses inputs, such as codes merged from several vendor feeds,
+// and returns one CLLI per distinct code in order of first appearance.
+// Variants differing in case, whitespace or punctuation, such as
+// "chcg-il-01-ds0" and "CHCGIL01DS0", collapse to the first seen. Inputs
+// that do not parse are dropped.
+func Dedupe(inputs []string) []*CLLI {
+	var out []*CLLI
+	seen := make(map[Key]bool, len(inputs))
+	for _, input := range inputs {
+		s, err := Normalize(input, dedupeRules)
+		if err != nil {
+			continue
+		}
+		c, err := Parse(s)
+		if err != nil {
+			continue
+		}
+		if k := c.Key(); !seen[k] {
+			seen[k] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeForCompare tests comparison normalization
+func TestNormalizeForCompare(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"CHCGIL01DS0", "CHCGIL01DS0"},
+		{"chcgil01ds0", "CHCGIL01DS0"},
+		{" CHCGIL01DS0 ", "CHCGIL01DS0"},
+		{"\tchcgIL01ds0\n", "CHCGIL01DS0"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeForCompare(tt.input))
+		})
+	}
+}
+
+// TestEqualFold tests normalization-aware comparison
+func TestEqualFold(t *testing.T) {
+	assert.True(t, EqualFold("chcgil01ds0", "CHCGIL01DS0"))
+	assert.True(t, EqualFold(" CHCGIL01DS0 ", "chcgil01ds0"))
+	assert.True(t, EqualFold("", "  "))
+	assert.False(t, EqualFold("CHCGIL01DS0", "CHCGIL01DS1"))
+	assert.False(t, EqualFold("CHCG IL01DS0", "CHCGIL01DS0"))
+
+	// Consistent with NormalizeForCompare
+	for _, s := range []string{"chcgil01ds0", " CHCGIL01DS0 ", "CHCGIL01DS0"} {
+		assert.Equal(t, EqualFold(s, "CHCGIL01DS0"), NormalizeForCompare(s) == "CHCGIL01DS0")
+	}
+}
+
+// TestKey tests canonical keys of CLLI variants
+func TestKey(t *testing.T) {
+	assert.Equal(t, MustParse("CHCGIL01DS0").Key(), MustParse(" chcgil01ds0 ").Key())
+	assert.NotEqual(t, MustParse("CHCGIL01DS0").Key(), MustParse("CHCGIL01DS1").Key())
+	assert.Equal(t, Key{Place: "MPLS", Region: "MN", LocationCode: "B", LocationID: "1234"}, MustParse("MPLSMNB1234").Key())
+
+	padded := &CLLI{Place: "RYE ", Region: "NY", NetworkSite: "01", EntityCode: "DS0"}
+	assert.Equal(t, "RYE", padded.Key().Place)
+}
+
+// TestDedupe tests collapsing variants of the same code
+func TestDedupe(t *testing.T) {
+	codes := Dedupe([]string{
+		"chcg-il-01-ds0",
+		"CHCGIL01DS0",
+		" CHCG IL 01 DS0 ",
+		"CHCG.IL.01.DS1",
+		"not a clli",
+		"",
+		"MPLSMNB1234",
+		"mplsmn/b1234",
+	})
+	require.Len(t, codes, 3)
+	assert.Equal(t, "CHCGIL01DS0", codes[0].Format())
+	assert.Equal(t, "CHCGIL01DS1", codes[1].Format())
+	assert.Equal(t, "MPLSMNB1234", codes[2].Format())
+	assert.Empty(t, Dedupe(nil))
+}
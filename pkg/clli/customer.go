@@ -0,0 +1,64 @@
+package clli
+
+// CustomerUnit is the customer location tail of a customer CLLI, split into
+// its subfields. Customer CLLIs come in the layouts of CustomerLayout.
+type CustomerUnit struct {
+	Site   string // Network site of the serving building; 15-character layout only
+	Code   string // Customer code digit
+	Letter string // Letter opening the customer ID; empty in the 15-character layout
+	Number string // Digits of the customer ID
+
+	Layout CustomerLayout // Arrangement of the tail
+}
+
+// CustomerUnit returns the subfields of a customer CLLI's tail. Reports
+// false if the CLLI is not a customer CLLI or its customer ID does not
+// match one of the customer layouts.
+func (c *CLLI) CustomerUnit() (CustomerUnit, bool) {
+	if c.cliType != CLLITypeCustomer || !isDigit(c.CustomerCode) || !isCustomerID(c.CustomerID) {
+		return CustomerUnit{}, false
+	}
+	u := CustomerUnit{Site: c.NetworkSite, Code: c.CustomerCode, Number: c.CustomerID, Layout: customerLayoutOf(c.CustomerID)}
+	if isAlpha(c.CustomerID[:1]) {
+		u.Letter, u.Number = c.CustomerID[:1], c.CustomerID[1:]
+	}
+	return u, true
+}
+
+// CustomerLayout identifies the arrangement of a customer CLLI's tail.
+type CustomerLayout int
+
+const (
+	CustomerLayoutUnknown CustomerLayout = iota // Not a customer layout
+	CustomerLayoutLetter3                       // PPPPRR N A999: 11 characters
+	CustomerLayoutLetter4                       // PPPPRR N A9999: 12 characters
+	CustomerLayoutSite6                         // PPPPRR SS N 999999: 15 characters, with network site
+)
+
+// String returns the string representation of the customer layout
+func (l CustomerLayout) String() string {
+	switch l {
+	case CustomerLayoutLetter3:
+		return "letter_3"
+	case CustomerLayoutLetter4:
+		return "letter_4"
+	case CustomerLayoutSite6:
+		return "site_6"
+	default:
+		return "unknown"
+	}
+}
+
+// customerLayoutOf returns the layout of a customer ID.
+func customerLayoutOf(id string) CustomerLayout {
+	switch {
+	case len(id) == 4 && isAlpha(id[:1]) && isDigitsOnly(id[1:]):
+		return CustomerLayoutLetter3
+	case len(id) == 5 && isAlpha(id[:1]) && isDigitsOnly(id[1:]):
+		return CustomerLayoutLetter4
+	case len(id) == 6 && isDigitsOnly(id):
+		return CustomerLayoutSite6
+	default:
+		return CustomerLayoutUnknown
+	}
+}
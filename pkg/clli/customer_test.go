@@ -0,0 +1,36 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCustomerUnit tests splitting customer tails into subfields
+func TestCustomerUnit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CustomerUnit
+	}{
+		{"MPLSMN1A234", CustomerUnit{Code: "1", Letter: "A", Number: "234", Layout: CustomerLayoutLetter3}},
+		{"MPLSMN1A2345", CustomerUnit{Code: "1", Letter: "A", Number: "2345", Layout: CustomerLayoutLetter4}},
+		{"CHCGIL011234567", CustomerUnit{Site: "01", Code: "1", Number: "234567", Layout: CustomerLayoutSite6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c := MustParse(tt.input)
+			assert.Empty(t, c.EntityCode)
+			assert.NoError(t, Invariants(c))
+			unit, ok := c.CustomerUnit()
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, unit)
+		})
+	}
+
+	for _, input := range []string{"CHCGIL01DS0", "CHCGILB1234", "CHCGIL01"} {
+		_, ok := MustParse(input).CustomerUnit()
+		assert.False(t, ok, input)
+	}
+	assert.Equal(t, "site_6", CustomerLayoutSite6.String())
+}
@@ -0,0 +1,149 @@
+package clli
+
+import (
+	"cmp"
+	"slices"
+)
+
+// DiffStatus classifies a building or region in a DiffReport.
+type DiffStatus int
+
+const (
+	// DiffChanged marks a building or region in both inventories whose codes differ
+	DiffChanged DiffStatus = iota
+
+	// DiffAdded marks a building or region only in the new inventory
+	DiffAdded
+
+	// DiffRemoved marks a building or region only in the old inventory
+	DiffRemoved
+)
+
+// String returns "changed", "added" or "removed".
+func (s DiffStatus) String() string {
+	switch s {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// BuildingDiff lists the codes added and removed at one building.
+type BuildingDiff struct {
+	Building string     // Building key, as returned by ByBuilding
+	Region   string     // Region code of the building
+	Status   DiffStatus // Whether the building was added, removed or changed
+	Added    []*CLLI    // Codes added at the building, in Compare order
+	Removed  []*CLLI    // Codes removed from the building, in Compare order
+}
+
+// RegionDiff counts the codes added and removed in one region.
+type RegionDiff struct {
+	Region  string     // Region code
+	Status  DiffStatus // Whether the region was added, removed or changed
+	Added   int        // Number of codes added in the region
+	Removed int        // Number of codes removed from the region
+}
+
+// DiffReport describes the differences between two CLLI inventories.
+type DiffReport struct {
+	Added     []*CLLI        // Codes only in the new inventory, in Compare order
+	Removed   []*CLLI        // Codes only in the old inventory, in Compare order
+	Unchanged int            // Number of codes in both inventories
+	Buildings []BuildingDiff // Buildings with added or removed codes, by region then building
+	Regions   []RegionDiff   // Regions with added or removed codes, by region
+}
+
+// Empty reports whether the inventories hold the same codes.
+func (r DiffReport) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0
+}
+
+// DiffSets compares two CLLI inventories, such as nightly OSS exports, and
+// groups the added and removed codes by building and region. Codes are
+// matched by their Format form, so codes differing only in case or
+// surrounding whitespace in the input are the same. Nil and duplicate
+// entries are ignored.
+func DiffSets(old, updated []*CLLI) DiffReport {
+	oldSet, newSet := diffIndex(old), diffIndex(updated)
+
+	var report DiffReport
+	for code, c := range newSet {
+		if _, ok := oldSet[code]; ok {
+			report.Unchanged++
+		} else {
+			report.Added = append(report.Added, c)
+		}
+	}
+	for code, c := range oldSet {
+		if _, ok := newSet[code]; !ok {
+			report.Removed = append(report.Removed, c)
+		}
+	}
+	slices.SortFunc(report.Added, Compare)
+	slices.SortFunc(report.Removed, Compare)
+
+	report.Buildings = diffGroups(report, oldSet, newSet, ByBuilding, func(key, region string, status DiffStatus, added, removed []*CLLI) BuildingDiff {
+		return BuildingDiff{Building: key, Region: region, Status: status, Added: added, Removed: removed}
+	})
+	report.Regions = diffGroups(report, oldSet, newSet, ByState, func(key, region string, status DiffStatus, added, removed []*CLLI) RegionDiff {
+		return RegionDiff{Region: region, Status: status, Added: len(added), Removed: len(removed)}
+	})
+	return report
+}
+
+// diffIndex indexes the non-nil codes by their Format form.
+func diffIndex(codes []*CLLI) map[string]*CLLI {
+	set := make(map[string]*CLLI, len(codes))
+	for _, c := range codes {
+		if c != nil {
+			set[c.Format()] = c
+		}
+	}
+	return set
+}
+
+// diffGroups groups the added and removed codes of report by key, ordered
+// by region and then key, classifying each group by whether the old and new
+// inventories hold codes with its key.
+func diffGroups[T any](report DiffReport, oldSet, newSet map[string]*CLLI, key func(*CLLI) string, group func(key, region string, status DiffStatus, added, removed []*CLLI) T) []T {
+	added, removed := GroupBy(report.Added, key), GroupBy(report.Removed, key)
+	inOld, inNew := diffKeys(oldSet, key), diffKeys(newSet, key)
+
+	regions := make(map[string]string) // Region by key
+	for _, c := range slices.Concat(report.Added, report.Removed) {
+		regions[key(c)] = c.Region
+	}
+	ordered := make([]string, 0, len(regions))
+	for k := range regions {
+		ordered = append(ordered, k)
+	}
+	slices.SortFunc(ordered, func(a, b string) int {
+		return cmp.Or(cmp.Compare(regions[a], regions[b]), cmp.Compare(a, b))
+	})
+
+	groups := make([]T, 0, len(ordered))
+	for _, k := range ordered {
+		status := DiffChanged
+		switch {
+		case !inOld[k]:
+			status = DiffAdded
+		case !inNew[k]:
+			status = DiffRemoved
+		}
+		groups = append(groups, group(k, regions[k], status, added[k], removed[k]))
+	}
+	return groups
+}
+
+// diffKeys returns the set of keys of the codes in set.
+func diffKeys(set map[string]*CLLI, key func(*CLLI) string) map[string]bool {
+	keys := make(map[string]bool, len(set))
+	for _, c := range set {
+		keys[key(c)] = true
+	}
+	return keys
+}
@@ -0,0 +1,58 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiffSets tests grouping inventory differences by building and region
+func TestDiffSets(t *testing.T) {
+	parse := func(codes ...string) []*CLLI {
+		out := make([]*CLLI, len(codes))
+		for i, code := range codes {
+			out[i] = MustParse(code)
+		}
+		return out
+	}
+	old := parse("CHCGIL01DS0", "CHCGIL01MG1", "CHCGIL02DS0", "DLLSTX01DS0", "MPLSMNB1234")
+	updated := append(parse("chcgil01ds0", "CHCGIL01CG0", "CHCGIL03DS0", "MPLSMNB1234", "TOROON01DS0", "TOROON01DS0"), nil)
+
+	report := DiffSets(old, updated)
+	assert.False(t, report.Empty())
+	assert.Equal(t, 2, report.Unchanged)
+
+	codes := func(cs []*CLLI) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.Format())
+		}
+		return out
+	}
+	assert.Equal(t, []string{"CHCGIL01CG0", "CHCGIL03DS0", "TOROON01DS0"}, codes(report.Added))
+	assert.Equal(t, []string{"CHCGIL01MG1", "CHCGIL02DS0", "DLLSTX01DS0"}, codes(report.Removed))
+
+	require.Len(t, report.Buildings, 5)
+	b := report.Buildings[0]
+	assert.Equal(t, "CHCGIL01", b.Building)
+	assert.Equal(t, "IL", b.Region)
+	assert.Equal(t, DiffChanged, b.Status)
+	assert.Equal(t, []string{"CHCGIL01CG0"}, codes(b.Added))
+	assert.Equal(t, []string{"CHCGIL01MG1"}, codes(b.Removed))
+	assert.Equal(t, "CHCGIL02", report.Buildings[1].Building)
+	assert.Equal(t, DiffRemoved, report.Buildings[1].Status)
+	assert.Equal(t, DiffAdded, report.Buildings[2].Status)
+	assert.Equal(t, "TOROON01", report.Buildings[3].Building)
+	assert.Equal(t, "DLLSTX01", report.Buildings[4].Building)
+
+	assert.Equal(t, []RegionDiff{
+		{Region: "IL", Status: DiffChanged, Added: 2, Removed: 2},
+		{Region: "ON", Status: DiffAdded, Added: 1},
+		{Region: "TX", Status: DiffRemoved, Removed: 1},
+	}, report.Regions)
+	assert.Equal(t, "removed", DiffRemoved.String())
+
+	assert.True(t, DiffSets(old, old).Empty())
+	assert.Empty(t, DiffSets(nil, nil).Buildings)
+}
@@ -0,0 +1,186 @@
+package clli
+
+import "strconv"
+
+// EntityCategory groups entity codes by the Bell entity table they come from.
+type EntityCategory int
+
+const (
+	// EntityCategoryUnknown indicates a code that matches no entity table
+	EntityCategoryUnknown EntityCategory = iota
+
+	// EntityCategorySwitching indicates a switching entity (Table B)
+	EntityCategorySwitching
+
+	// EntityCategorySwitchboard indicates a switchboard or desk entity (Table C)
+	EntityCategorySwitchboard
+
+	// EntityCategoryMiscSwitching indicates a miscellaneous switching entity (Table D)
+	EntityCategoryMiscSwitching
+
+	// EntityCategoryNonSwitching indicates a non-switching entity (Table E)
+	EntityCategoryNonSwitching
+
+	// EntityCategoryReserved indicates a reserved, non-conforming code family,
+	// such as the Z.Z testing codes and X.X throwaway codes
+	EntityCategoryReserved
+)
+
+// String returns the string representation of the entity category
+func (c EntityCategory) String() string {
+	switch c {
+	case EntityCategorySwitching:
+		return "switching"
+	case EntityCategorySwitchboard:
+		return "switchboard"
+	case EntityCategoryMiscSwitching:
+		return "misc_switching"
+	case EntityCategoryNonSwitching:
+		return "non_switching"
+	case EntityCategoryReserved:
+		return "reserved"
+	default:
+		return "unknown"
+	}
+}
+
+// reservedEntityFamilies describes the reserved entity code families,
+// keyed by the entity table row that matches them.
+var reservedEntityFamilies = map[string]string{
+	"Table B: Z[A-Z]Z": "Reserved testing code (Z.Z)",
+	"Table B: X[A-Z]X": "Reserved throwaway code (X.X)",
+}
+
+// entityTableCategories maps each Bell entity table to its category.
+var entityTableCategories = map[string]struct {
+	category EntityCategory
+	name     string
+}{
+	"B": {EntityCategorySwitching, "Switching entity"},
+	"C": {EntityCategorySwitchboard, "Switchboard or desk entity"},
+	"D": {EntityCategoryMiscSwitching, "Miscellaneous switching entity"},
+	"E": {EntityCategoryNonSwitching, "Non-switching entity"},
+}
+
+// ClassifyEntityCode returns the category of an entity code and a short
+// description of it. Reserved code families are reported as
+// EntityCategoryReserved rather than with the switching codes of Table B.
+func ClassifyEntityCode(code string) (EntityCategory, string) {
+	info, ok := DescribeEntityCode(code)
+	if !ok {
+		return EntityCategoryUnknown, ""
+	}
+	if info.Category == EntityCategoryReserved {
+		return info.Category, info.Description
+	}
+	return info.Category, info.CategoryName
+}
+
+// EntityInfo is the structured classification of an entity code against
+// the Bell entity tables.
+type EntityInfo struct {
+	Code         string         // Entity code
+	Table        string         // Bell table letter, "B" to "E"
+	Pattern      string         // Table row matched, e.g. "[0-9]GT"
+	Category     EntityCategory // Category, distinguishing reserved families
+	CategoryName string         // Category of the table, e.g. "Non-switching entity"
+	Description  string         // Description of the equipment or reserved family
+}
+
+// String returns the table and category name, e.g. "Table E – Non-switching entity".
+func (i EntityInfo) String() string {
+	if i.Table == "" {
+		return ""
+	}
+	return "Table " + i.Table + " – " + i.CategoryName
+}
+
+// DescribeEntityCode classifies an entity code against the Bell entity
+// tables. It returns false if the code matches no table row.
+func DescribeEntityCode(code string) (EntityInfo, bool) {
+	e := lookupEntityTable(code)
+	if e == nil {
+		return EntityInfo{}, false
+	}
+
+	table := entityTableCategories[e.table]
+	info := EntityInfo{
+		Code:         code,
+		Table:        e.table,
+		Pattern:      e.pattern,
+		Category:     table.category,
+		CategoryName: table.name,
+		Description:  e.equipmentOf(code),
+	}
+	if desc, ok := reservedEntityFamilies[e.name()]; ok {
+		info.Category, info.Description = EntityCategoryReserved, desc
+	}
+	return info, true
+}
+
+// EntityCategory returns the structured classification of the CLLI's
+// entity code: its table, category name and description. It returns false
+// if this is not an entity CLLI or the code matches no table row.
+func (c *CLLI) EntityCategory() (EntityInfo, bool) {
+	if c.cliType != CLLITypeEntity {
+		return EntityInfo{}, false
+	}
+	return DescribeEntityCode(c.EntityCode)
+}
+
+// IsReservedEntity returns true if the CLLI's entity code belongs to a
+// reserved family, such as a Z.Z testing code.
+func (c *CLLI) IsReservedEntity() bool {
+	info, _ := c.EntityCategory()
+	return info.Category == EntityCategoryReserved
+}
+
+// EntityDetail is the meaning decoded from the structure of an entity code:
+// the equipment its table row denotes and the unit designator that
+// distinguishes like equipment in the same building.
+type EntityDetail struct {
+	EntityInfo
+
+	Equipment string // Equipment denoted by the row, e.g. "Digital switch"
+	Function  string // Switching function where the row encodes one: "toll", "tandem" or "remote"
+	Unit      string // Unit designator, e.g. "1" for DS1, or "" if the row has none
+}
+
+// UnitNumber returns the unit designator as a number, and false if the
+// code has no unit designator or it is not numeric.
+func (d EntityDetail) UnitNumber() (int, bool) {
+	if !isDigitsOnly(d.Unit) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(d.Unit)
+	return n, err == nil
+}
+
+// DescribeEntityDetail decodes an entity code into the equipment, function
+// and unit designator encoded by its table row, such as digital switch 1
+// for "DS1" or toll crossbar 0 for "0GT". It returns false if the code
+// matches no table row.
+func DescribeEntityDetail(code string) (EntityDetail, bool) {
+	info, ok := DescribeEntityCode(code)
+	if !ok {
+		return EntityDetail{}, false
+	}
+	d := EntityDetail{EntityInfo: info}
+	if info.Category == EntityCategoryReserved {
+		return d, true
+	}
+
+	e := lookupEntityTable(code)
+	d.Equipment, d.Function = info.Description, e.function
+	d.Unit = code[e.unit[0]:e.unit[1]]
+	return d, true
+}
+
+// EntityDetail returns the decoded meaning of the CLLI's entity code. It
+// returns false if this is not an entity CLLI or the code matches no table row.
+func (c *CLLI) EntityDetail() (EntityDetail, bool) {
+	if c.cliType != CLLITypeEntity {
+		return EntityDetail{}, false
+	}
+	return DescribeEntityDetail(c.EntityCode)
+}
@@ -0,0 +1,164 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClassifyEntityCode tests grouping of entity codes by table
+func TestClassifyEntityCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		category EntityCategory
+		desc     string
+	}{
+		{"DS0", EntityCategorySwitching, "Switching entity"},
+		{"01T", EntityCategorySwitching, "Switching entity"},
+		{"01B", EntityCategorySwitchboard, "Switchboard or desk entity"},
+		{"1MD", EntityCategoryMiscSwitching, "Miscellaneous switching entity"},
+		{"Q12", EntityCategoryNonSwitching, "Non-switching entity"},
+		{"ZAZ", EntityCategoryReserved, "Reserved testing code (Z.Z)"},
+		{"XQX", EntityCategoryReserved, "Reserved throwaway code (X.X)"},
+		{"XAX", EntityCategoryReserved, "Reserved throwaway code (X.X)"},
+		{"XCX", EntityCategoryReserved, "Reserved throwaway code (X.X)"}, // Not the XC prefix
+		{"XC1", EntityCategorySwitching, "Switching entity"},
+		{"QQQ", EntityCategoryUnknown, ""},
+		{"", EntityCategoryUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			category, desc := ClassifyEntityCode(tt.code)
+			assert.Equal(t, tt.category, category)
+			assert.Equal(t, tt.desc, desc)
+		})
+	}
+}
+
+// TestReservedEntities tests reserved entity detection on parsed CLLIs
+func TestReservedEntities(t *testing.T) {
+	c := MustParse("CHCGIL01ZAZ")
+	info, _ := c.EntityCategory()
+	assert.Equal(t, EntityCategoryReserved, info.Category)
+	assert.Equal(t, "reserved", info.Category.String())
+	assert.True(t, c.IsReservedEntity())
+	assert.Equal(t, "Reserved testing code (Z.Z)", c.EntityType())
+
+	c = MustParse("CHCGIL01DS0")
+	info, _ = c.EntityCategory()
+	assert.Equal(t, EntityCategorySwitching, info.Category)
+	assert.False(t, c.IsReservedEntity())
+	assert.Equal(t, "Digital switch", c.EntityType())
+
+	info, _ = MustParse("CHCGILB1234").EntityCategory()
+	assert.Equal(t, EntityCategoryUnknown, info.Category)
+	assert.False(t, MustParse("CHCGILB1234").IsReservedEntity())
+}
+
+// TestDescribeEntityCode tests the structured entity classification
+func TestDescribeEntityCode(t *testing.T) {
+	info, ok := DescribeEntityCode("DS0")
+	assert.True(t, ok)
+	assert.Equal(t, EntityInfo{
+		Code:         "DS0",
+		Table:        "B",
+		Pattern:      "(MG|SG|CG|DS|RL|PS|RP|CM|VS|OS|OL)[x1]",
+		Category:     EntityCategorySwitching,
+		CategoryName: "Switching entity",
+		Description:  "Digital switch",
+	}, info)
+	assert.Equal(t, "Table B – Switching entity", info.String())
+
+	info, ok = DescribeEntityCode("4QB")
+	assert.True(t, ok)
+	assert.Equal(t, "Table C – Switchboard or desk entity", info.String())
+
+	info, ok = DescribeEntityCode("ZAZ")
+	assert.True(t, ok)
+	assert.Equal(t, EntityCategoryReserved, info.Category)
+	assert.Equal(t, "Table B – Switching entity", info.String())
+	assert.Equal(t, "Reserved testing code (Z.Z)", info.Description)
+
+	info, ok = DescribeEntityCode("QQQ")
+	assert.False(t, ok)
+	assert.Equal(t, "", info.String())
+}
+
+// TestCLLIEntityCategory tests entity classification of parsed CLLIs
+func TestCLLIEntityCategory(t *testing.T) {
+	info, ok := MustParse("PHNXAZMMQ01").EntityCategory()
+	assert.True(t, ok)
+	assert.Equal(t, EntityCategoryNonSwitching, info.Category)
+	assert.Equal(t, "Table E – Non-switching entity", info.String())
+
+	_, ok = MustParse("CHCGILB1234").EntityCategory()
+	assert.False(t, ok)
+}
+
+// TestDescribeEntityDetail tests decoding of equipment and unit designators
+func TestDescribeEntityDetail(t *testing.T) {
+	tests := []struct {
+		code      string
+		equipment string
+		function  string
+		unit      string
+	}{
+		{"DS1", "Digital switch", "", "1"},
+		{"MGA", "Media gateway", "", "A"},
+		{"CG0", "Switching equipment", "", "0"},
+		{"RT1", "Router", "", "1"},
+		{"MS1", "Multiplexer", "", "1"},
+		{"0GT", "Toll crossbar", "toll", "0"},
+		{"01T", "Tandem switch", "tandem", "01"},
+		{"RS2", "Remote switch", "remote", "2"},
+		{"12A", "Switching system", "", "12"},
+		{"4QB", "Switchboard", "", "4"},
+		{"1MD", "Miscellaneous switching equipment", "", "1"},
+		{"Q12", "Non-switching equipment", "", "12"},
+		{"FAA", "Non-switching equipment", "", ""},
+		{"ZAZ", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			d, ok := DescribeEntityDetail(tt.code)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, d.Code)
+			assert.Equal(t, tt.equipment, d.Equipment)
+			assert.Equal(t, tt.function, d.Function)
+			assert.Equal(t, tt.unit, d.Unit)
+			if d.Category != EntityCategoryReserved {
+				assert.Equal(t, d.Description, d.Equipment, "detail agrees with DescribeEntityCode")
+			}
+		})
+	}
+
+	_, ok := DescribeEntityDetail("QQQ")
+	assert.False(t, ok)
+
+	// Every row that is not a reserved family decodes its equipment
+	for _, e := range entityTable {
+		if _, reserved := reservedEntityFamilies[e.name()]; !reserved {
+			assert.NotEmpty(t, e.equipment, e.name())
+		}
+	}
+}
+
+// TestCLLIEntityDetail tests entity decoding of parsed CLLIs
+func TestCLLIEntityDetail(t *testing.T) {
+	d, ok := MustParse("CHCGIL01DS1").EntityDetail()
+	require.True(t, ok)
+	assert.Equal(t, EntityCategorySwitching, d.Category)
+	n, ok := d.UnitNumber()
+	assert.True(t, ok)
+	assert.Equal(t, 1, n)
+
+	d, _ = MustParse("CHCGIL01MGA").EntityDetail()
+	_, ok = d.UnitNumber()
+	assert.False(t, ok)
+
+	_, ok = MustParse("CHCGILB1234").EntityDetail()
+	assert.False(t, ok)
+}
@@ -0,0 +1,161 @@
+package clli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Segment describes one component of a CLLI code within an Explanation.
+type Segment struct {
+	Name     string // Component name, e.g. "Place" or "Entity code"
+	Value    string // Characters of the component
+	Start    int    // 1-based position of the first character
+	End      int    // 1-based position of the last character
+	Meaning  string // Human-readable interpretation of the value
+	TableRow string // Bell table row matched by the value, if any
+}
+
+// Explanation is a structured, render-ready breakdown of a CLLI code.
+// It carries the same information the command-line tool prints, so user
+// interfaces can present it directly or through Text and Markdown.
+type Explanation struct {
+	Code     string    // CLLI code as rebuilt from its components
+	Type     CLLIType  // Classified CLLI type
+	Segments []Segment // Components in positional order
+	City     string    // Resolved city name, or "" if unknown
+	State    string    // Resolved state or province name, or "" if unknown
+	Country  string    // Resolved country name, or "" if unknown
+}
+
+// Explain returns a breakdown of c's components, as c.Explain does, or an
+// empty Explanation if c is nil.
+func Explain(c *CLLI) Explanation {
+	if c == nil {
+		return Explanation{}
+	}
+	return *c.Explain()
+}
+
+// Explain returns a breakdown of the CLLI's components, their meanings and
+// the geographic resolution of its place and region codes.
+func (c *CLLI) Explain() *Explanation {
+	e := &Explanation{
+		Code:    c.Format(),
+		Type:    c.cliType,
+		City:    c.CityName(),
+		State:   c.StateName(),
+		Country: c.CountryName(),
+	}
+
+	pos := 1
+	add := func(name, value, meaning, row string) {
+		if value == "" {
+			return
+		}
+		e.Segments = append(e.Segments, Segment{
+			Name:     name,
+			Value:    value,
+			Start:    pos,
+			End:      pos + len(value) - 1,
+			Meaning:  meaning,
+			TableRow: row,
+		})
+		pos += len(value)
+	}
+
+	add("Place", c.Place, orDefault(e.City, "Place abbreviation"), "")
+
+	regionMeaning := e.State
+	if regionMeaning != "" && e.Country != "" {
+		regionMeaning += ", " + e.Country
+	}
+	add("Region", c.Region, orDefault(regionMeaning, "Unrecognized region"), "")
+
+	add("Location code", c.LocationCode, orDefault(c.LocationCodeMeaning(), c.LocationType()), "")
+	add("Location ID", c.LocationID, "Location identifier", "")
+	add("Network site", c.NetworkSite, siteMeaning(c.NetworkSite), "")
+	add("Customer code", c.CustomerCode, c.LocationType(), "")
+	add("Customer ID", c.CustomerID, "Customer identifier", "")
+	add("Entity code", c.EntityCode, c.EntityType(), entityTableRow(c.EntityCode))
+
+	return e
+}
+
+// Text renders the explanation as aligned plain text.
+func (e *Explanation) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", e.Code, e.Type)
+
+	nameWidth, valueWidth := 0, 0
+	for _, s := range e.Segments {
+		nameWidth = max(nameWidth, len(s.Name))
+		valueWidth = max(valueWidth, len(s.Value))
+	}
+
+	for _, s := range e.Segments {
+		fmt.Fprintf(&b, "  %-5s  %-*s  %-*s  %s", s.positions(), nameWidth, s.Name, valueWidth, s.Value, s.Meaning)
+		if s.TableRow != "" {
+			fmt.Fprintf(&b, " [%s]", s.TableRow)
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Markdown renders the explanation as a Markdown heading and table.
+func (e *Explanation) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** (%s)\n\n", e.Code, e.Type)
+	b.WriteString("| Positions | Component | Value | Meaning | Table |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, s := range e.Segments {
+		fmt.Fprintf(&b, "| %s | %s | `%s` | %s | %s |\n",
+			s.positions(), s.Name, s.Value, markdownEscape(s.Meaning), markdownEscape(s.TableRow))
+	}
+	return b.String()
+}
+
+// Description renders the segment as one line, such as
+// "chars 7-8: network site '01' — Building 1".
+func (s Segment) Description() string {
+	chars := "chars"
+	if s.Start == s.End {
+		chars = "char"
+	}
+	d := fmt.Sprintf("%s %s: %s '%s'", chars, s.positions(), strings.ToLower(s.Name), s.Value)
+	if s.Meaning != "" {
+		d += " — " + s.Meaning
+	}
+	return d
+}
+
+// siteMeaning describes a network site code, such as "Building 1" for "01".
+func siteMeaning(site string) string {
+	if n, err := strconv.Atoi(site); err == nil {
+		return "Building " + strconv.Itoa(n)
+	}
+	return "Building " + site
+}
+
+// positions formats the segment's character range, e.g. "1-4" or "7".
+func (s Segment) positions() string {
+	if s.Start == s.End {
+		return fmt.Sprintf("%d", s.Start)
+	}
+	return fmt.Sprintf("%d-%d", s.Start, s.End)
+}
+
+// markdownEscape escapes characters with special meaning in Markdown tables.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "[", `\[`, "]", `\]`, "*", `\*`).Replace(s)
+}
+
+// orDefault returns s, or def if s is empty.
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExplain tests the structured breakdown of parsed CLLIs
+func TestExplain(t *testing.T) {
+	t.Run("Entity", func(t *testing.T) {
+		e := MustParse("CHCGIL01DS0").Explain()
+		assert.Equal(t, "CHCGIL01DS0", e.Code)
+		assert.Equal(t, CLLITypeEntity, e.Type)
+		assert.Equal(t, "Chicago", e.City)
+		assert.Equal(t, "Illinois", e.State)
+		assert.Equal(t, "United States", e.Country)
+
+		require.Len(t, e.Segments, 4)
+		assert.Equal(t, Segment{Name: "Place", Value: "CHCG", Start: 1, End: 4, Meaning: "Chicago"}, e.Segments[0])
+		assert.Equal(t, "Illinois, United States", e.Segments[1].Meaning)
+		assert.Equal(t, 7, e.Segments[2].Start)
+		assert.Equal(t, "Entity code", e.Segments[3].Name)
+		assert.Equal(t, "Digital switch", e.Segments[3].Meaning)
+		assert.Equal(t, "Table B: two-letter equipment prefix", e.Segments[3].TableRow)
+	})
+
+	t.Run("Segments cover the code", func(t *testing.T) {
+		for _, code := range []string{"CHCGIL01DS0", "CHCGILB1234", "DLLSTX1A234", "DLLSTX011234567", "LSANCA12"} {
+			e := MustParse(code).Explain()
+			var rebuilt string
+			for _, s := range e.Segments {
+				assert.Equal(t, len(rebuilt)+1, s.Start, code)
+				rebuilt += s.Value
+			}
+			assert.Equal(t, code, rebuilt)
+		}
+	})
+
+	t.Run("Descriptions", func(t *testing.T) {
+		e := Explain(MustParse("CHCGIL01DS0"))
+		assert.Equal(t, "chars 1-4: place 'CHCG' — Chicago", e.Segments[0].Description())
+		assert.Equal(t, "chars 7-8: network site '01' — Building 1", e.Segments[2].Description())
+		assert.Equal(t, "char 7: location code 'B' — Pole", Explain(MustParse("CHCGILB1234")).Segments[2].Description())
+		assert.Equal(t, "Building AB", Explain(MustParse("TOROONAB")).Segments[2].Meaning)
+		assert.Equal(t, Explanation{}, Explain(nil))
+	})
+
+	t.Run("Unknown place", func(t *testing.T) {
+		e := MustParse("ZZZZIL01DS0").Explain()
+		assert.Empty(t, e.City)
+		assert.Equal(t, "Place abbreviation", e.Segments[0].Meaning)
+	})
+}
+
+// TestExplanationRenderers tests the text and Markdown renderings
+func TestExplanationRenderers(t *testing.T) {
+	e := MustParse("CHCGILB1234").Explain()
+
+	assert.Equal(t, "CHCGILB1234 (NonBuilding)\n"+
+		"  1-4    Place          CHCG  Chicago\n"+
+		"  5-6    Region         IL    Illinois, United States\n"+
+		"  7      Location code  B     Pole\n"+
+		"  8-11   Location ID    1234  Location identifier\n", e.Text())
+
+	md := MustParse("CHCGIL01DS0").Explain().Markdown()
+	assert.Contains(t, md, "**CHCGIL01DS0** (Entity)")
+	assert.Contains(t, md, "| 9-11 | Entity code | `DS0` | Digital switch | Table B: two-letter equipment prefix |")
+
+	md = MustParse("CHCGIL0101B").Explain().Markdown()
+	assert.Contains(t, md, `Table C: \[0-9\]\[CDBINQWMVROLPEUTZ0-9\]B`)
+}
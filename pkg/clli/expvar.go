@@ -0,0 +1,72 @@
+package clli
+
+import (
+	"expvar"
+	"time"
+)
+
+// ExpvarMetrics is a MetricsHook that exposes parse counters through expvar,
+// for services that want quick runtime visibility without running Prometheus.
+// The counters appear under a single map in /debug/vars:
+//
+//	{"clli": {"parses": 120, "failures": 3, "failures_by_code": {"bad_region": 3}, ...}}
+type ExpvarMetrics struct {
+	vars           *expvar.Map
+	parses         *expvar.Int
+	failures       *expvar.Int
+	failuresByCode *expvar.Map
+	cacheHits      *expvar.Int
+	cacheMisses    *expvar.Int
+}
+
+var _ MetricsHook = (*ExpvarMetrics)(nil)
+
+// NewExpvarMetrics creates counters that are not yet published.
+// Use Map to embed them in an existing expvar tree.
+func NewExpvarMetrics() *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		vars:           new(expvar.Map),
+		parses:         new(expvar.Int),
+		failures:       new(expvar.Int),
+		failuresByCode: new(expvar.Map),
+		cacheHits:      new(expvar.Int),
+		cacheMisses:    new(expvar.Int),
+	}
+	m.vars.Set("parses", m.parses)
+	m.vars.Set("failures", m.failures)
+	m.vars.Set("failures_by_code", m.failuresByCode)
+	m.vars.Set("cache_hits", m.cacheHits)
+	m.vars.Set("cache_misses", m.cacheMisses)
+	return m
+}
+
+// PublishExpvar creates counters and publishes them under the given expvar name.
+// Like expvar.Publish, it panics if the name is already in use.
+func PublishExpvar(name string) *ExpvarMetrics {
+	m := NewExpvarMetrics()
+	expvar.Publish(name, m.vars)
+	return m
+}
+
+// Map returns the expvar map holding all counters.
+func (m *ExpvarMetrics) Map() *expvar.Map {
+	return m.vars
+}
+
+// OnParse implements MetricsHook.
+func (m *ExpvarMetrics) OnParse(_ *CLLI, code ErrorCode, _ time.Duration) {
+	m.parses.Add(1)
+	if code != ErrCodeNone {
+		m.failures.Add(1)
+		m.failuresByCode.Add(code.String(), 1)
+	}
+}
+
+// OnCacheLookup records a hit or miss of a parse cache.
+func (m *ExpvarMetrics) OnCacheLookup(hit bool) {
+	if hit {
+		m.cacheHits.Add(1)
+	} else {
+		m.cacheMisses.Add(1)
+	}
+}
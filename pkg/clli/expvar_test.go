@@ -0,0 +1,54 @@
+package clli
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExpvarMetrics tests counter updates and JSON rendering
+func TestExpvarMetrics(t *testing.T) {
+	m := NewExpvarMetrics()
+
+	p := MustNewParser(nil)
+	p.SetMetricsHook(m)
+	_, _ = p.Parse("CHCGIL01DS0")
+	_, _ = p.Parse("CHCGZZ01DS0")
+	_, _ = p.Parse("CH1GIL01DS0")
+	m.OnCacheLookup(true)
+	m.OnCacheLookup(false)
+	m.OnCacheLookup(true)
+
+	var vars struct {
+		Parses         int64            `json:"parses"`
+		Failures       int64            `json:"failures"`
+		FailuresByCode map[string]int64 `json:"failures_by_code"`
+		CacheHits      int64            `json:"cache_hits"`
+		CacheMisses    int64            `json:"cache_misses"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(m.Map().String()), &vars))
+
+	assert.Equal(t, int64(3), vars.Parses)
+	assert.Equal(t, int64(2), vars.Failures)
+	assert.Equal(t, map[string]int64{"bad_region": 1, "bad_place": 1}, vars.FailuresByCode)
+	assert.Equal(t, int64(2), vars.CacheHits)
+	assert.Equal(t, int64(1), vars.CacheMisses)
+}
+
+// expvarRuns numbers the runs of TestPublishExpvar, since expvar names
+// cannot be unpublished and -count runs the test again in the same process.
+var expvarRuns atomic.Int64
+
+// TestPublishExpvar tests publishing counters under a global name
+func TestPublishExpvar(t *testing.T) {
+	name := fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+	m := PublishExpvar(name)
+	assert.Same(t, m.Map(), expvar.Get(name))
+
+	assert.Panics(t, func() { PublishExpvar(name) })
+}
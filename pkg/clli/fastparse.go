@@ -0,0 +1,62 @@
+package clli
+
+import "strings"
+
+// Fast path
+// Most CLLIs in bulk feeds are already well formed: uppercase, no padding
+// and valid under strict parsing. parseFast accepts exactly those inputs
+// without allocating, by validating components in place and sharing the
+// input's memory for every field. Anything it does not accept takes the
+// full ParseWithOptions path, which produces the detailed errors.
+
+// ParseInto parses input like Parse, storing the result in dst rather than
+// allocating a new CLLI, so high-throughput collectors can reuse one CLLI
+// per worker. Well-formed uppercase input is parsed without allocating.
+// On error dst is left unchanged.
+func ParseInto(input string, dst *CLLI) error {
+	if metricsHook.Load() == nil && parseFast(input, dst) {
+		return nil
+	}
+	c, err := Parse(input)
+	if err != nil {
+		return err
+	}
+	*dst = *c
+	return nil
+}
+
+// parseFast parses input into dst under Parse's default options, reporting
+// whether it succeeded. It fails, leaving dst unchanged, for any input that
+// is not a valid uppercase CLLI, including lowercase input that Parse would
+// normalize; callers then fall back to the full parse.
+func parseFast(input string, dst *CLLI) bool {
+	s := strings.TrimSpace(input)
+	if len(s) < 8 || len(s) > 15 || !isAlphanumeric(s) || !isAlpha(s[:6]) {
+		return false
+	}
+	if _, ok := defaultRegionRegistry.Lookup(s[4:6]); !ok {
+		return false
+	}
+
+	classification, ok := DefaultClassifier.Classify(s[6:])
+	if !ok || !classification.matches(s[6:]) {
+		return false
+	}
+	if len(s) == 12 && isDigit(s[6:7]) && validateCustomerTail(s[6:7], s[7:]) != nil {
+		return false
+	}
+	if classification.Type == CLLITypeEntity && classification.EntityCode != "" {
+		if !isAlpha(classification.NetworkSite) && !isDigitsOnly(classification.NetworkSite) {
+			return false
+		}
+		if lookupEntityTable(classification.EntityCode) == nil {
+			return false
+		}
+	}
+
+	*dst = CLLI{Original: s, Place: s[:4], Region: s[4:6], valid: true}
+	classification.apply(dst)
+	rs, n := ambiguousReadings(s[6:])
+	dst.readings = readingTypes(rs[:n])
+	return true
+}
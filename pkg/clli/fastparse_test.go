@@ -0,0 +1,59 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseInto tests that ParseInto agrees with the full parse, on and
+// off the fast path
+func TestParseInto(t *testing.T) {
+	inputs := append([]string{
+		"CHCGIL01DS0", " chcgil01ds0 ", "MPLSMNB1234", "MPLSMN1A2345", "MPLSMN011234567",
+		"CHCGIL01", "CHCGILA1DS0", "CHCGIL01QQQ", "CHCGZZ01DS0", "",
+	}, fuzzSeeds...)
+
+	for _, input := range inputs {
+		want, wantErr := ParseWithOptions(input, nil)
+
+		var got CLLI
+		err := ParseInto(input, &got)
+		if wantErr != nil {
+			assert.Equal(t, wantErr.Error(), err.Error(), input)
+			assert.Equal(t, CLLI{}, got, input)
+			continue
+		}
+		require.NoError(t, err, input)
+		assert.Equal(t, *want, got, input)
+	}
+}
+
+// TestParseIntoAllocs tests that well-formed input is parsed without allocating
+func TestParseIntoAllocs(t *testing.T) {
+	var c CLLI
+	for _, input := range []string{"CHCGIL01DS0", "MPLSMNB1234", "MPLSMN1A2345", "MPLSMN011234567"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = ParseInto(input, &c)
+		})
+		assert.Zero(t, allocs, input)
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	cliCodes := []string{
+		"MPLSMNMSDS1",
+		"NYCMNYPSRS1",
+		"LSANCAMASG1",
+		"CHCGILMAMG1",
+		"MPLSMNB1234",
+		"NYCMNY1A567",
+	}
+
+	var c CLLI
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		_ = ParseInto(cliCodes[i%len(cliCodes)], &c)
+	}
+}
@@ -0,0 +1,134 @@
+package clli
+
+import "strings"
+
+// FormatOptions controls how a CLLI is reconstructed from its components.
+type FormatOptions struct {
+	// PadPlace pads place codes shorter than 4 characters with trailing
+	// spaces, as Bell practice does for short place names, so the region
+	// always occupies characters 5-6.
+	PadPlace bool
+
+	// Uppercase converts every component to uppercase.
+	Uppercase bool
+}
+
+// Format reconstructs the CLLI string from its parsed components.
+// Unlike String, which echoes the original input, Format only uses the
+// component fields, so edits made to those fields are reflected in the output.
+// Place codes shorter than 4 characters are space-padded, so the result
+// parses back to the same components.
+func (c *CLLI) Format() string {
+	return c.FormatWithOptions(&FormatOptions{PadPlace: true})
+}
+
+// Canonical returns the canonical form of the CLLI: uppercase components
+// with the place code space-padded to 4 characters. Messy input such as
+// " chcgil01ds0 " canonicalizes to "CHCGIL01DS0".
+func (c *CLLI) Canonical() string {
+	return c.FormatWithOptions(&FormatOptions{PadPlace: true, Uppercase: true})
+}
+
+// FormatWithOptions reconstructs the CLLI string from its parsed components
+// using custom formatting options. A nil opts selects no options. A region
+// filled in by lenient parsing of a short code is written only as far as
+// the input supplied it, so "CHCG" formats as "CHCG" rather than "CHCGXX".
+func (c *CLLI) FormatWithOptions(opts *FormatOptions) string {
+	if opts == nil {
+		opts = &FormatOptions{}
+	}
+
+	var b strings.Builder
+	b.Grow(15)
+	b.WriteString(c.Place)
+	if opts.PadPlace {
+		for i := len(c.Place); i < 4; i++ {
+			b.WriteByte(' ')
+		}
+	}
+	if c.inferred&(1<<ComponentRegion) != 0 {
+		b.WriteString(strings.TrimRight(c.Region, "X"))
+	} else {
+		b.WriteString(c.Region)
+	}
+
+	switch {
+	case c.LocationCode != "" || c.LocationID != "":
+		b.WriteString(c.LocationCode)
+		b.WriteString(c.LocationID)
+	case c.CustomerCode != "" || c.CustomerID != "":
+		b.WriteString(c.NetworkSite)
+		b.WriteString(c.CustomerCode)
+		b.WriteString(c.CustomerID)
+	default:
+		b.WriteString(c.NetworkSite)
+		b.WriteString(c.EntityCode)
+	}
+
+	if opts.Uppercase {
+		return strings.ToUpper(b.String())
+	}
+	return b.String()
+}
+
+// FormatWith formats the CLLI according to layout, replacing each verb with
+// a component or geographic name, so downstream systems can emit forms such
+// as "CHCG/IL/01/DS0" ("%P/%R/%S/%E") or "CHCG-IL (Chicago, Illinois)"
+// ("%P-%R (%C, %N)"). The verbs are:
+//
+//	%P  place code, without padding
+//	%R  region code
+//	%S  network site
+//	%E  entity code
+//	%L  location code and ID of a non-building CLLI
+//	%U  customer code and ID of a customer CLLI
+//	%B  building: place, region and network site
+//	%C  city name, as returned by CityName
+//	%N  state or province name, as returned by StateName
+//	%Y  country code, as returned by CountryCode
+//	%T  CLLI type
+//	%%  a literal percent sign
+//
+// Absent components format as empty strings. Other characters, including
+// unrecognized verbs, are copied unchanged.
+func (c *CLLI) FormatWith(layout string) string {
+	var b strings.Builder
+	b.Grow(len(layout) + 16)
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 == len(layout) {
+			b.WriteByte(layout[i])
+			continue
+		}
+		i++
+		switch layout[i] {
+		case 'P':
+			b.WriteString(strings.TrimRight(c.Place, " "))
+		case 'R':
+			b.WriteString(c.Region)
+		case 'S':
+			b.WriteString(c.NetworkSite)
+		case 'E':
+			b.WriteString(c.EntityCode)
+		case 'L':
+			b.WriteString(c.LocationCode + c.LocationID)
+		case 'U':
+			b.WriteString(c.CustomerCode + c.CustomerID)
+		case 'B':
+			b.WriteString(c.Place + c.Region + c.NetworkSite)
+		case 'C':
+			b.WriteString(c.CityName())
+		case 'N':
+			b.WriteString(c.StateName())
+		case 'Y':
+			b.WriteString(c.CountryCode())
+		case 'T':
+			b.WriteString(c.cliType.String())
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(layout[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormatWith tests layout-based formatting
+func TestFormatWith(t *testing.T) {
+	c := MustParse("CHCGIL01DS0")
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"%P/%R/%S/%E", "CHCG/IL/01/DS0"},
+		{"%P-%R (%C, %N)", "CHCG-IL (Chicago, Illinois)"},
+		{"%B %T %Y", "CHCGIL01 Entity US"},
+		{"100%% %L%U", "100% "},
+		{"%Q %", "%Q %"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			assert.Equal(t, tt.expected, c.FormatWith(tt.layout))
+		})
+	}
+
+	assert.Equal(t, "MPLS MN B1234", MustParse("MPLSMNB1234").FormatWith("%P %R %L"))
+	assert.Equal(t, "1A2345", MustParse("MPLSMN1A2345").FormatWith("%S%U"))
+}
@@ -0,0 +1,136 @@
+package clli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComponentKind identifies a single component of a CLLI code.
+type ComponentKind int
+
+const (
+	// ComponentPlace is the 4-letter place code
+	ComponentPlace ComponentKind = iota
+
+	// ComponentRegion is the 2-letter state or province code
+	ComponentRegion
+
+	// ComponentNetworkSite is the 2-character building code
+	ComponentNetworkSite
+
+	// ComponentEntityCode is the 3-character equipment code
+	ComponentEntityCode
+
+	// ComponentLocationCode is the single letter of a non-building location
+	ComponentLocationCode
+
+	// ComponentLocationID is the 4-digit identifier of a non-building location
+	ComponentLocationID
+
+	// ComponentCustomerCode is the single digit of a customer location
+	ComponentCustomerCode
+
+	// ComponentCustomerID is the identifier of a customer location
+	ComponentCustomerID
+)
+
+// String returns the component name, matching the field names used in ParseError.
+func (k ComponentKind) String() string {
+	switch k {
+	case ComponentPlace:
+		return "place"
+	case ComponentRegion:
+		return "region"
+	case ComponentNetworkSite:
+		return "network_site"
+	case ComponentEntityCode:
+		return "entity_code"
+	case ComponentLocationCode:
+		return "location_code"
+	case ComponentLocationID:
+		return "location_id"
+	case ComponentCustomerCode:
+		return "customer_code"
+	case ComponentCustomerID:
+		return "customer_id"
+	default:
+		return "unknown"
+	}
+}
+
+// Fragment is a validated, normalized CLLI component.
+type Fragment struct {
+	Kind  ComponentKind // Component the value was validated as
+	Value string        // Normalized value (trimmed and uppercased)
+}
+
+// String returns the fragment value.
+func (f Fragment) String() string {
+	return f.Value
+}
+
+// ParseFragment validates and normalizes a single CLLI component, for
+// interfaces that collect components in separate form fields. Surrounding
+// whitespace is removed and letters are uppercased before validation.
+//
+// Returns a ParseError naming the component. The error wraps the matching
+// sentinel (ErrInvalidPlace, ErrInvalidRegion, ...) together with a
+// message describing the problem, suitable for display next to the field.
+func ParseFragment(kind ComponentKind, s string) (Fragment, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+
+	var sentinel, detail error
+	switch kind {
+	case ComponentPlace:
+		sentinel, detail = ErrInvalidPlace, validatePlace(value)
+	case ComponentRegion:
+		sentinel, detail = ErrInvalidRegion, validateRegion(value)
+	case ComponentNetworkSite:
+		sentinel, detail = ErrInvalidSite, validateNetworkSite(value)
+	case ComponentEntityCode:
+		sentinel, detail = ErrInvalidEntity, validateEntityCode(value)
+	case ComponentLocationCode:
+		if len(value) != 1 || !isAlpha(value) {
+			sentinel, detail = ErrInvalidLocation, newMessageError(MsgLocationCode)
+		}
+	case ComponentLocationID:
+		if len(value) != 4 || !isDigitsOnly(value) {
+			sentinel, detail = ErrInvalidLocation, newMessageError(MsgLocationID)
+		}
+	case ComponentCustomerCode:
+		if len(value) != 1 || !isDigitsOnly(value) {
+			sentinel, detail = ErrInvalidCustomer, newMessageError(MsgCustomerCode)
+		}
+	case ComponentCustomerID:
+		if !isCustomerID(value) {
+			sentinel, detail = ErrInvalidCustomer, newMessageError(MsgCustomerID)
+		}
+	default:
+		return Fragment{}, newMessageError(MsgComponentKind, int(kind))
+	}
+
+	if detail != nil {
+		return Fragment{}, &ParseError{
+			Input:    s,
+			Position: 0,
+			Field:    kind.String(),
+			Err:      fmt.Errorf("%w: %w", sentinel, detail),
+		}
+	}
+
+	return Fragment{Kind: kind, Value: value}, nil
+}
+
+// isCustomerID reports whether s is a customer ID: a letter followed by
+// 3 digits (11-character CLLIs), a letter followed by 4 digits (12-character
+// CLLIs) or 6 digits (15-character CLLIs).
+func isCustomerID(s string) bool {
+	switch len(s) {
+	case 4, 5:
+		return isAlpha(s[:1]) && isDigitsOnly(s[1:])
+	case 6:
+		return isDigitsOnly(s)
+	default:
+		return false
+	}
+}
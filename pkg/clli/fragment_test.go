@@ -0,0 +1,82 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseFragment tests validation and normalization of single components
+func TestParseFragment(t *testing.T) {
+	tests := []struct {
+		kind     ComponentKind
+		input    string
+		expected string
+	}{
+		{ComponentPlace, " chcg ", "CHCG"},
+		{ComponentRegion, "il", "IL"},
+		{ComponentNetworkSite, "01", "01"},
+		{ComponentNetworkSite, "ab", "AB"},
+		{ComponentEntityCode, "ds0", "DS0"},
+		{ComponentLocationCode, "b", "B"},
+		{ComponentLocationID, "1234", "1234"},
+		{ComponentCustomerCode, "1", "1"},
+		{ComponentCustomerID, "a234", "A234"},
+		{ComponentCustomerID, "a2345", "A2345"},
+		{ComponentCustomerID, "234567", "234567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String()+"/"+tt.input, func(t *testing.T) {
+			f, err := ParseFragment(tt.kind, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, Fragment{Kind: tt.kind, Value: tt.expected}, f)
+			assert.Equal(t, tt.expected, f.String())
+		})
+	}
+}
+
+// TestParseFragmentErrors tests error reporting for invalid components
+func TestParseFragmentErrors(t *testing.T) {
+	tests := []struct {
+		kind     ComponentKind
+		input    string
+		sentinel error
+		id       MessageID
+	}{
+		{ComponentPlace, "CHC", ErrInvalidPlace, MsgPlaceLength},
+		{ComponentRegion, "ZZ", ErrInvalidRegion, MsgRegionUnknown},
+		{ComponentNetworkSite, "A1", ErrInvalidSite, MsgSiteMixed},
+		{ComponentEntityCode, "QQQ", ErrInvalidEntity, MsgEntityPattern},
+		{ComponentLocationCode, "1", ErrInvalidLocation, MsgLocationCode},
+		{ComponentLocationID, "12A4", ErrInvalidLocation, MsgLocationID},
+		{ComponentCustomerCode, "A", ErrInvalidCustomer, MsgCustomerCode},
+		{ComponentCustomerID, "12345", ErrInvalidCustomer, MsgCustomerID},
+		{ComponentPlace, "", ErrInvalidPlace, MsgPlaceEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String()+"/"+tt.input, func(t *testing.T) {
+			_, err := ParseFragment(tt.kind, tt.input)
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.Equal(t, tt.id, MessageIDOf(err))
+
+			var pe *ParseError
+			require.ErrorAs(t, err, &pe)
+			assert.Equal(t, tt.kind.String(), pe.Field)
+			assert.Equal(t, tt.input, pe.Input)
+		})
+	}
+
+	t.Run("Localized detail", func(t *testing.T) {
+		_, err := ParseFragment(ComponentLocationID, "12")
+		assert.Contains(t, Localize(err, "fr"), "exactement 4 chiffres")
+	})
+
+	t.Run("Unknown kind", func(t *testing.T) {
+		_, err := ParseFragment(ComponentKind(99), "X")
+		assert.Error(t, err)
+		assert.Equal(t, "unknown", ComponentKind(99).String())
+	})
+}
@@ -0,0 +1,75 @@
+package clli
+
+import "testing"
+
+// fuzzSeeds are valid and malformed inputs seeding the fuzz targets.
+var fuzzSeeds = []string{
+	"CHCGIL01DS0", "NYCMNYBXMCA", "CHCGIL01", "DLLSTXB1234", "MPLSMN1A2345",
+	"MPLSMN1A234", "CHCGIL011234567", "chcgil01ds0", " CHCGIL01DS0 ",
+	"RYE NY01DS0", "CHCGILAB12", "MPLS", "CHCGZZ01DS0", "", "CHCG-IL-01",
+
+	// Inputs on the edges of the classification rules
+	"AAAAON0A", "MPLSMNC62345", "AAAAAB00000000A", "AAA AA00",
+	"CHI IL01DS0", "CHCG",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	lenient := &ParseOptions{NormalizeCase: true, TrimWhitespace: true}
+	f.Fuzz(func(t *testing.T, input string) {
+		if c, err := Parse(input); err == nil {
+			if err := Invariants(c); err != nil {
+				t.Fatalf("Parse(%q): %v", input, err)
+			}
+			if err := CheckRoundTrip(input, nil); err != nil {
+				t.Fatal(err)
+			}
+			if got := ClassifyType(input); got != c.Type() {
+				t.Fatalf("ClassifyType(%q) = %s, Parse type %s", input, got, c.Type())
+			}
+		}
+		var fast CLLI
+		if parseFast(input, &fast) {
+			c, err := ParseWithOptions(input, nil)
+			if err != nil || fast != *c {
+				t.Fatalf("parseFast(%q) = %+v, ParseWithOptions: %+v, %v", input, fast, c, err)
+			}
+		}
+		if c, err := ParseWithOptions(input, lenient); err == nil {
+			if err := Invariants(c); err != nil {
+				t.Fatalf("lenient ParseWithOptions(%q): %v", input, err)
+			}
+			if err := CheckRoundTrip(input, lenient); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+func FuzzUnpack(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		if c, err := Parse(s); err == nil {
+			if v, err := c.Pack(); err == nil {
+				f.Add(v)
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, v uint64) {
+		c, err := Unpack(v)
+		if err != nil {
+			return
+		}
+		if err := Invariants(c); err != nil {
+			t.Fatalf("Unpack(%#x): %v", v, err)
+		}
+		packed, err := c.Pack()
+		if err != nil {
+			t.Fatalf("Unpack(%#x) = %s does not pack: %v", v, c.Format(), err)
+		}
+		if packed != v {
+			t.Fatalf("Unpack(%#x) = %s packs to %#x", v, c.Format(), packed)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package clli
+
+import "iter"
+
+// EntityTable selects the Bell entity tables enumerated by
+// GenerateEntityCLLIs.
+type EntityTable string
+
+const (
+	// EntityTableAll selects every entity table
+	EntityTableAll EntityTable = ""
+
+	// EntityTableB selects switching entities
+	EntityTableB EntityTable = "B"
+
+	// EntityTableC selects switchboard and desk entities
+	EntityTableC EntityTable = "C"
+
+	// EntityTableD selects miscellaneous switching entities
+	EntityTableD EntityTable = "D"
+
+	// EntityTableE selects non-switching entities
+	EntityTableE EntityTable = "E"
+)
+
+// entityCodeChars lists the characters of entity codes in ascending order.
+const entityCodeChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// GenerateEntityCLLIs enumerates the syntactically valid entity CLLIs at the
+// building of base, in ascending order, so planners can pick the next code
+// not yet in use. Codes are limited to the given table, and reserved code
+// families such as the Z.Z testing codes are skipped since they are not
+// assigned to equipment. An entity CLLI base is rolled up to its building.
+// The sequence is empty if base has no network site.
+func GenerateEntityCLLIs(base *CLLI, table EntityTable) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if base == nil || base.NetworkSite == "" {
+			return
+		}
+		prefix := base.Place + base.Region + base.NetworkSite
+
+		code := make([]byte, 3)
+		for _, a := range []byte(entityCodeChars) {
+			for _, b := range []byte(entityCodeChars) {
+				for _, c := range []byte(entityCodeChars) {
+					code[0], code[1], code[2] = a, b, c
+					if !entityInTable(string(code), table) {
+						continue
+					}
+					if !yield(prefix + string(code)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// entityInTable reports whether code is an assignable entity code of table.
+func entityInTable(code string, table EntityTable) bool {
+	e := lookupEntityTable(code)
+	if e == nil || (table != EntityTableAll && e.table != string(table)) {
+		return false
+	}
+	_, reserved := reservedEntityFamilies[e.name()]
+	return !reserved
+}
@@ -0,0 +1,43 @@
+package clli
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateEntityCLLIs tests enumerating entity codes at a building
+func TestGenerateEntityCLLIs(t *testing.T) {
+	codes := slices.Collect(GenerateEntityCLLIs(MustParse("CHCGIL01"), EntityTableC))
+	assert.NotEmpty(t, codes)
+	assert.True(t, slices.IsSorted(codes))
+	for _, code := range codes {
+		c, err := Parse(code)
+		if assert.NoError(t, err, code) {
+			info, _ := c.EntityCategory()
+			assert.Equal(t, "C", info.Table, code)
+		}
+	}
+	assert.Equal(t, "CHCGIL0100B", codes[0])
+
+	// Entity CLLIs are rolled up to their building, and reserved codes skipped
+	all := slices.Collect(GenerateEntityCLLIs(MustParse("CHCGIL01DS0"), EntityTableAll))
+	assert.Contains(t, all, "CHCGIL01DS0")
+	assert.Contains(t, all, "CHCGIL01Q12")
+	assert.NotContains(t, all, "CHCGIL01ZAZ")
+	assert.Greater(t, len(all), len(codes))
+
+	// Iteration stops early
+	var first []string
+	for code := range GenerateEntityCLLIs(MustParse("CHCGIL01"), EntityTableB) {
+		first = append(first, code)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Len(t, first, 3)
+
+	assert.Empty(t, slices.Collect(GenerateEntityCLLIs(MustParse("CHCGILB1234"), EntityTableAll)))
+	assert.Empty(t, slices.Collect(GenerateEntityCLLIs(nil, EntityTableAll)))
+}
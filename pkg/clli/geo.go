@@ -0,0 +1,223 @@
+package clli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// GeoResolver resolves place codes to city names. Implementations may be
+// backed by loaded datasets, a database or a remote service, and must be
+// safe for concurrent use. A place that is not known resolves to "" with a
+// nil error.
+type GeoResolver interface {
+	City(ctx context.Context, place, region string) (string, error)
+}
+
+// GeoResolverFunc adapts an ordinary function to the GeoResolver interface.
+type GeoResolverFunc func(ctx context.Context, place, region string) (string, error)
+
+// City calls f(ctx, place, region).
+func (f GeoResolverFunc) City(ctx context.Context, place, region string) (string, error) {
+	return f(ctx, place, region)
+}
+
+var (
+	_ GeoResolver = (*Resolver)(nil)
+	_ GeoResolver = GeoResolverFunc(nil)
+)
+
+// geoResolverHolder wraps the active GeoResolver so it can be stored atomically.
+type geoResolverHolder struct {
+	GeoResolver
+}
+
+// geoResolver is the GeoResolver used by CityName and ResolveCity.
+var geoResolver atomic.Pointer[geoResolverHolder]
+
+// SetGeoResolver replaces the GeoResolver used by CityName and ResolveCity,
+// so callers can plug in their own place datasets. A nil g restores
+// DefaultResolver.
+func SetGeoResolver(g GeoResolver) {
+	if g == nil {
+		geoResolver.Store(nil)
+		return
+	}
+	geoResolver.Store(&geoResolverHolder{g})
+}
+
+// DefaultGeoResolver returns the GeoResolver used by CityName and
+// ResolveCity: the one set with SetGeoResolver, or DefaultResolver.
+func DefaultGeoResolver() GeoResolver {
+	if h := geoResolver.Load(); h != nil {
+		return h.GeoResolver
+	}
+	return DefaultResolver()
+}
+
+// CoordinateResolver locates place codes, for plotting CLLIs on maps.
+// Implementations must be safe for concurrent use.
+type CoordinateResolver interface {
+	// Coordinates returns the approximate latitude and longitude of a place
+	// in decimal degrees, and false if the place is not known.
+	Coordinates(place, region string) (lat, lon float64, ok bool)
+}
+
+var _ CoordinateResolver = (*Resolver)(nil)
+
+// coordinateResolverHolder wraps the active CoordinateResolver so it can be
+// stored atomically.
+type coordinateResolverHolder struct {
+	CoordinateResolver
+}
+
+// coordinateResolver is the CoordinateResolver used by Coordinates.
+var coordinateResolver atomic.Pointer[coordinateResolverHolder]
+
+// SetCoordinateResolver replaces the CoordinateResolver used by
+// CLLI.Coordinates. A nil r restores DefaultResolver.
+func SetCoordinateResolver(r CoordinateResolver) {
+	if r == nil {
+		coordinateResolver.Store(nil)
+		return
+	}
+	coordinateResolver.Store(&coordinateResolverHolder{r})
+}
+
+// DefaultCoordinateResolver returns the CoordinateResolver used by
+// CLLI.Coordinates: the one set with SetCoordinateResolver, or DefaultResolver.
+func DefaultCoordinateResolver() CoordinateResolver {
+	if h := coordinateResolver.Load(); h != nil {
+		return h.CoordinateResolver
+	}
+	return DefaultResolver()
+}
+
+// Coordinates returns the approximate latitude and longitude of the CLLI's
+// place in decimal degrees, as recorded for its central office area.
+// Returns false if the place is not known.
+func (c *CLLI) Coordinates() (lat, lon float64, ok bool) {
+	return DefaultCoordinateResolver().Coordinates(c.Place, c.Region)
+}
+
+// ResolveCityWith is the variant of ResolveCity that uses the given
+// GeoResolver instead of DefaultGeoResolver.
+func (c *CLLI) ResolveCityWith(ctx context.Context, g GeoResolver) (string, error) {
+	return g.City(ctx, c.Place, c.Region)
+}
+
+// LoadDatasetCSV reads a dataset from CSV whose header row names the place,
+// region and city columns (case-insensitively, in any order), and
+// optionally latitude and longitude columns in decimal degrees. The city
+// column may also be named "locality", as in place-code reference
+// extracts. Other columns are ignored. Data embedded with go:embed can be loaded through a
+// bytes.Reader.
+func LoadDatasetCSV(name string, r io.Reader) (*Dataset, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, newMessageError(MsgDataset, name, err)
+	}
+	columns := map[string]int{"PLACE": -1, "REGION": -1, "CITY": -1}
+	for i, h := range header {
+		h = strings.ToUpper(strings.TrimSpace(h))
+		if h == "LOCALITY" {
+			h = "CITY"
+		}
+		if _, ok := columns[h]; ok {
+			columns[h] = i
+		}
+	}
+	for col, i := range columns {
+		if i < 0 {
+			return nil, newMessageError(MsgDatasetColumn, name, strings.ToLower(col))
+		}
+	}
+	lat, lon := -1, -1
+	for i, h := range header {
+		switch strings.ToUpper(strings.TrimSpace(h)) {
+		case "LATITUDE":
+			lat = i
+		case "LONGITUDE":
+			lon = i
+		}
+	}
+
+	var records []PlaceRecord
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, newMessageError(MsgDataset, name, err)
+		}
+		field := func(i int) string {
+			if i >= 0 && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		rec := PlaceRecord{Place: field(columns["PLACE"]), Region: field(columns["REGION"]), City: field(columns["CITY"])}
+		if field(lat) != "" || field(lon) != "" {
+			line, _ := cr.FieldPos(0)
+			if rec.Latitude, rec.Longitude, err = parseCoordinates(field(lat), field(lon)); err != nil {
+				return nil, newMessageError(MsgDatasetLine, name, line, err)
+			}
+			rec.HasCoordinates = true
+		}
+		records = append(records, rec)
+	}
+	return NewDataset(name, records), nil
+}
+
+// LoadDatasetJSON reads a dataset from a JSON array of objects with place,
+// region and city members, and optionally latitude and longitude members in
+// decimal degrees.
+func LoadDatasetJSON(name string, r io.Reader) (*Dataset, error) {
+	var entries []struct {
+		Place     string   `json:"place"`
+		Region    string   `json:"region"`
+		City      string   `json:"city"`
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+	}
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, newMessageError(MsgDataset, name, err)
+	}
+
+	records := make([]PlaceRecord, len(entries))
+	for i, e := range entries {
+		records[i] = PlaceRecord{Place: e.Place, Region: e.Region, City: e.City}
+		if e.Latitude == nil && e.Longitude == nil {
+			continue
+		}
+		if e.Latitude == nil || e.Longitude == nil || !validCoordinates(*e.Latitude, *e.Longitude) {
+			return nil, newMessageError(MsgDatasetEntry, name, i)
+		}
+		records[i].Latitude, records[i].Longitude, records[i].HasCoordinates = *e.Latitude, *e.Longitude, true
+	}
+	return NewDataset(name, records), nil
+}
+
+// parseCoordinates parses a latitude and longitude in decimal degrees.
+func parseCoordinates(lat, lon string) (float64, float64, error) {
+	la, err1 := strconv.ParseFloat(lat, 64)
+	lo, err2 := strconv.ParseFloat(lon, 64)
+	if err1 != nil || err2 != nil || !validCoordinates(la, lo) {
+		return 0, 0, newMessageError(MsgCoordinates, lat, lon)
+	}
+	return la, lo, nil
+}
+
+// validCoordinates reports whether lat and lon are within range.
+func validCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
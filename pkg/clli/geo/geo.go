@@ -0,0 +1,228 @@
+// Package geo loads place/city mapping files into the clli package and
+// reloads them when they change, so operators can maintain their own
+// authoritative site lists without recompiling:
+//
+//	w, err := geo.Watch("/etc/clli/places.csv", nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+//	clli.SetGeoResolver(w)
+//	clli.SetCoordinateResolver(w)
+//
+// A mapping file is CSV or JSON, chosen by its ".csv" or ".json"
+// extension. A CSV file has a header row naming the place, region and city
+// columns, in any order and case-insensitively, and optionally latitude and
+// longitude columns in decimal degrees; other columns are ignored:
+//
+//	place,region,city,latitude,longitude
+//	CHCG,IL,Chicago,41.8781,-87.6298
+//	LABS,ZX,Lab East,,
+//
+// A JSON file is an array of objects with the same members:
+//
+//	[
+//	  {"place": "CHCG", "region": "IL", "city": "Chicago", "latitude": 41.8781, "longitude": -87.6298},
+//	  {"place": "LABS", "region": "ZX", "city": "Lab East"}
+//	]
+//
+// Place and region codes are stored in uppercase, and later rows replace
+// earlier ones for the same place and region. See
+// clli.LoadDatasetCSV and clli.LoadDatasetJSON for the details.
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// ErrUnknownFormat is returned for files whose extension is not ".csv" or ".json".
+var ErrUnknownFormat = errors.New("geo: unknown file format")
+
+// DefaultInterval is how often Watch checks the file when
+// WatchOptions.Interval is zero.
+const DefaultInterval = 30 * time.Second
+
+// LoadFile reads a place/city mapping file in the format selected by its
+// extension. The dataset is named after the file, without its extension.
+func LoadFile(path string) (*clli.Dataset, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	var load func(string, io.Reader) (*clli.Dataset, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		load = clli.LoadDatasetCSV
+	case ".json":
+		load = clli.LoadDatasetJSON
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return load(name, f)
+}
+
+// WatchOptions controls how Watch reloads a file.
+type WatchOptions struct {
+	// Interval is how often the file's modification time and size are
+	// checked. Zero selects DefaultInterval.
+	Interval time.Duration
+
+	// OnReload, if set, is called with each dataset loaded after the first.
+	// It runs after the dataset is swapped in and may call Reload.
+	OnReload func(d *clli.Dataset)
+
+	// OnError, if set, is called when a changed file cannot be loaded. The
+	// previous dataset stays in use until the file loads successfully.
+	OnError func(err error)
+}
+
+// Watcher serves the places of a mapping file, reloading it when it
+// changes. It implements clli.GeoResolver and clli.CoordinateResolver and
+// is safe for concurrent use.
+type Watcher struct {
+	path    string
+	opts    WatchOptions
+	dataset atomic.Pointer[clli.Dataset]
+
+	mu      sync.Mutex // Serializes reloads
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+var (
+	_ clli.GeoResolver        = (*Watcher)(nil)
+	_ clli.CoordinateResolver = (*Watcher)(nil)
+)
+
+// Watch loads a mapping file with LoadFile and starts checking it for
+// changes in the background. Returns an error if the file cannot be loaded
+// initially. A nil opts selects the defaults. Call Close to stop watching.
+func Watch(path string, opts *WatchOptions) (*Watcher, error) {
+	w := &Watcher{path: path, stop: make(chan struct{}), done: make(chan struct{})}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = DefaultInterval
+	}
+
+	if err := w.reload(false); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// run checks the file every interval until Close is called.
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.reload(false); err != nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// Reload loads the file immediately, whether or not it has changed.
+// On error the previous dataset stays in use.
+func (w *Watcher) Reload() error {
+	return w.reload(true)
+}
+
+// reload loads the file if force is set or it has changed since the last
+// load, and calls OnReload once the lock is released.
+func (w *Watcher) reload(force bool) error {
+	d, err := w.load(force)
+	if err != nil || d == nil {
+		return err
+	}
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(d)
+	}
+	return nil
+}
+
+// load loads the file if force is set or it has changed since the last load.
+// Returns the new dataset if one replaced an earlier one, or nil.
+func (w *Watcher) load(force bool) (*clli.Dataset, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		return nil, err
+	}
+	if !force && w.dataset.Load() != nil && fi.ModTime().Equal(w.modTime) && fi.Size() == w.size {
+		return nil, nil
+	}
+
+	d, err := LoadFile(w.path)
+	if err != nil {
+		return nil, err
+	}
+	first := w.dataset.Swap(d) == nil
+	w.modTime, w.size = fi.ModTime(), fi.Size()
+	if first {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// Dataset returns the dataset currently being served.
+func (w *Watcher) Dataset() *clli.Dataset {
+	return w.dataset.Load()
+}
+
+// City returns the city for a place and region, or "" if the file does not
+// list it.
+func (w *Watcher) City(ctx context.Context, place, region string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	rec, _ := w.Dataset().Lookup(place, region)
+	return rec.City, nil
+}
+
+// Coordinates returns the latitude and longitude of a place, and false if
+// the file does not list it or gives no coordinates for it.
+func (w *Watcher) Coordinates(place, region string) (lat, lon float64, ok bool) {
+	rec, found := w.Dataset().Lookup(place, region)
+	if !found || !rec.HasCoordinates {
+		return 0, 0, false
+	}
+	return rec.Latitude, rec.Longitude, true
+}
+
+// Close stops watching the file. The last loaded dataset remains available.
+func (w *Watcher) Close() error {
+	w.once.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// TestLoadFile tests loading CSV and JSON mapping files
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "sites.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("region,place,city,latitude,longitude\nZX,labs,Lab East,40.5,-74.25\n"), 0o644))
+	jsonPath := filepath.Join(dir, "sites.JSON")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[{"place": "LABS", "region": "ZX", "city": "Lab East"}]`), 0o644))
+
+	for _, path := range []string{csvPath, jsonPath} {
+		d, err := LoadFile(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, "sites", d.Name())
+		rec, ok := d.Lookup("LABS", "ZX")
+		require.True(t, ok, path)
+		assert.Equal(t, "Lab East", rec.City)
+	}
+
+	_, err := LoadFile(filepath.Join(dir, "sites.txt"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = LoadFile(filepath.Join(dir, "missing.csv"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestWatch tests reloading a changed mapping file
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.csv")
+	require.NoError(t, os.WriteFile(path, []byte("place,region,city\nLABS,ZX,Lab East\n"), 0o644))
+
+	reloaded := make(chan *clli.Dataset, 1)
+	w, err := Watch(path, &WatchOptions{Interval: 10 * time.Millisecond, OnReload: func(d *clli.Dataset) { reloaded <- d }})
+	require.NoError(t, err)
+	defer w.Close()
+
+	city, err := w.City(context.Background(), "LABS", "ZX")
+	require.NoError(t, err)
+	assert.Equal(t, "Lab East", city)
+	_, _, ok := w.Coordinates("LABS", "ZX")
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte("place,region,city,latitude,longitude\nLABS,ZX,Lab West,40.5,-74.25\n"), 0o644))
+	select {
+	case d := <-reloaded:
+		assert.Equal(t, 1, d.Len())
+	case <-time.After(5 * time.Second):
+		t.Fatal("file was not reloaded")
+	}
+	city, _ = w.City(context.Background(), "LABS", "ZX")
+	assert.Equal(t, "Lab West", city)
+	lat, lon, ok := w.Coordinates("LABS", "ZX")
+	require.True(t, ok)
+	assert.Equal(t, []float64{40.5, -74.25}, []float64{lat, lon})
+
+	// A bad file leaves the previous dataset in use
+	require.NoError(t, os.WriteFile(path, []byte("place,city\nLABS,Broken\n"), 0o644))
+	assert.Error(t, w.Reload())
+	city, _ = w.City(context.Background(), "LABS", "ZX")
+	assert.Equal(t, "Lab West", city)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	_, err = Watch(filepath.Join(t.TempDir(), "missing.csv"), nil)
+	assert.Error(t, err)
+}
+
+// TestWatchReloadFromCallback tests calling Reload from OnReload
+func TestWatchReloadFromCallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.csv")
+	require.NoError(t, os.WriteFile(path, []byte("place,region,city\nLABS,ZX,Lab East\n"), 0o644))
+
+	var w *Watcher
+	var calls int
+	errs := make(chan error, 1)
+	w, err := Watch(path, &WatchOptions{Interval: time.Hour, OnReload: func(*clli.Dataset) {
+		if calls++; calls == 1 {
+			errs <- w.Reload()
+		}
+	}})
+	require.NoError(t, err)
+	defer w.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- w.Reload() }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reload from OnReload deadlocked")
+	}
+	assert.NoError(t, <-errs)
+	assert.Equal(t, 2, calls)
+}
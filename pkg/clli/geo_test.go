@@ -0,0 +1,105 @@
+package clli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetGeoResolver tests replacing the resolver behind CityName and ResolveCity
+func TestSetGeoResolver(t *testing.T) {
+	c := MustParse("RYEBNY01DS0")
+	assert.Equal(t, "", c.CityName())
+
+	d, err := LoadDatasetCSV("custom", strings.NewReader("city,PLACE,region\nRye Brook,ryeb,ny\n"))
+	require.NoError(t, err)
+	SetGeoResolver(NewResolver(d, builtinDataset))
+	defer SetGeoResolver(nil)
+
+	assert.Equal(t, "Rye Brook", c.CityName())
+	assert.Equal(t, "Chicago", MustParse("CHCGIL01DS0").CityName())
+	city, err := c.ResolveCity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Rye Brook", city)
+
+	SetGeoResolver(nil)
+	assert.Equal(t, DefaultResolver(), DefaultGeoResolver())
+	assert.Equal(t, "", c.CityName())
+}
+
+// TestResolveCityWith tests per-call resolver injection
+func TestResolveCityWith(t *testing.T) {
+	c := MustParse("CHCGIL01DS0")
+	g := GeoResolverFunc(func(_ context.Context, place, region string) (string, error) {
+		return place + "/" + region, nil
+	})
+	city, err := c.ResolveCityWith(context.Background(), g)
+	require.NoError(t, err)
+	assert.Equal(t, "CHCG/IL", city)
+
+	// Lookup failures are reported by ResolveCity but hidden by CityName
+	failing := GeoResolverFunc(func(context.Context, string, string) (string, error) {
+		return "", errors.New("unavailable")
+	})
+	SetGeoResolver(failing)
+	defer SetGeoResolver(nil)
+	assert.Equal(t, "", c.CityName())
+	_, err = c.ResolveCity(context.Background())
+	assert.EqualError(t, err, "unavailable")
+}
+
+// TestLoadDataset tests loading datasets from CSV and JSON
+func TestLoadDataset(t *testing.T) {
+	d, err := LoadDatasetJSON("json", strings.NewReader(`[{"place":"ryeb","region":"NY","city":"Rye Brook"}]`))
+	require.NoError(t, err)
+	rec, ok := d.Lookup("RYEB", "NY")
+	assert.True(t, ok)
+	assert.Equal(t, "Rye Brook", rec.City)
+	assert.Equal(t, "json", rec.Dataset)
+
+	_, err = LoadDatasetJSON("json", strings.NewReader(`{`))
+	assert.Error(t, err)
+
+	_, err = LoadDatasetCSV("csv", strings.NewReader("place,city\nRYEB,Rye Brook\n"))
+	assert.EqualError(t, err, "dataset csv: missing region column")
+
+	d, err = LoadDatasetCSV("csv", strings.NewReader("place,region,city,population\nRYEB,NY,Rye Brook,9000\nSHRT,NY\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Len())
+}
+
+// TestCoordinates tests loading coordinates and resolving them for a CLLI
+func TestCoordinates(t *testing.T) {
+	d, err := LoadDatasetCSV("csv", strings.NewReader("place,region,city,latitude,longitude\nRYEB,NY,Rye Brook,41.0193,-73.6835\nSHRT,NY,Short,,\n"))
+	require.NoError(t, err)
+	rec, ok := d.Lookup("RYEB", "NY")
+	require.True(t, ok)
+	assert.True(t, rec.HasCoordinates)
+	assert.InDelta(t, 41.0193, rec.Latitude, 1e-9)
+	assert.InDelta(t, -73.6835, rec.Longitude, 1e-9)
+	rec, _ = d.Lookup("SHRT", "NY")
+	assert.False(t, rec.HasCoordinates)
+
+	_, err = LoadDatasetCSV("csv", strings.NewReader("place,region,city,latitude,longitude\nRYEB,NY,Rye Brook,91,0\n"))
+	assert.EqualError(t, err, `dataset csv: line 2: invalid coordinates "91", "0"`)
+
+	d, err = LoadDatasetJSON("json", strings.NewReader(`[{"place":"RYEB","region":"NY","city":"Rye Brook","latitude":41.0193,"longitude":-73.6835}]`))
+	require.NoError(t, err)
+	rec, _ = d.Lookup("RYEB", "NY")
+	assert.True(t, rec.HasCoordinates)
+	_, err = LoadDatasetJSON("json", strings.NewReader(`[{"place":"RYEB","region":"NY","latitude":41.0193}]`))
+	assert.EqualError(t, err, "dataset json: entry 0: invalid coordinates")
+
+	SetCoordinateResolver(NewResolver(d))
+	defer SetCoordinateResolver(nil)
+	lat, lon, ok := MustParse("RYEBNY01DS0").Coordinates()
+	assert.True(t, ok)
+	assert.InDelta(t, 41.0193, lat, 1e-9)
+	assert.InDelta(t, -73.6835, lon, 1e-9)
+	_, _, ok = MustParse("ATLNGA01DS0").Coordinates()
+	assert.False(t, ok)
+}
@@ -0,0 +1,28 @@
+//go:build !clli_nogeodata
+
+package clli
+
+import (
+	"bytes"
+	_ "embed"
+	_ "time/tzdata"
+)
+
+// Embedded place data
+// The place dataset in data/places.csv is compiled into the binary and
+// consulted by DefaultResolver after the built-in mappings, together with the
+// time zone database used by TimeZone. Build with the clli_nogeodata tag to
+// leave both out for a smaller binary. The dataset is a seed of major US and
+// Canadian places; data/README.md records its sources.
+
+//go:embed data/places.csv
+var embeddedPlaces []byte
+
+// embeddedDataset returns the embedded place dataset.
+func embeddedDataset() *Dataset {
+	d, err := LoadDatasetCSV("embedded", bytes.NewReader(embeddedPlaces))
+	if err != nil {
+		panic("clli: embedded place data: " + err.Error())
+	}
+	return d
+}
@@ -0,0 +1,9 @@
+//go:build clli_nogeodata
+
+package clli
+
+// embeddedDataset returns nil when the embedded place data is excluded by
+// the clli_nogeodata build tag.
+func embeddedDataset() *Dataset {
+	return nil
+}
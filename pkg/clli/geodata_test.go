@@ -0,0 +1,45 @@
+//go:build !clli_nogeodata
+
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedDataset tests that the embedded place data backs DefaultResolver
+func TestEmbeddedDataset(t *testing.T) {
+	d := embeddedDataset()
+	require.NotNil(t, d)
+	assert.Greater(t, d.Len(), 50)
+
+	assert.Equal(t, "Atlanta", MustParse("ATLNGA01DS0").CityName())
+	assert.Equal(t, "Winnipeg", MustParse("WNPGMB01DS0").CityName())
+
+	// Built-in mappings take precedence over the embedded data
+	rec, ok := DefaultResolver().LookupPlace("CHCG", "IL")
+	assert.True(t, ok)
+	assert.Equal(t, "builtin", rec.Dataset)
+	rec, ok = DefaultResolver().LookupPlace("ATLN", "GA")
+	assert.True(t, ok)
+	assert.Equal(t, "embedded", rec.Dataset)
+}
+
+// TestEmbeddedCoordinates tests that the embedded place data locates places,
+// including those whose names come from the built-in mappings
+func TestEmbeddedCoordinates(t *testing.T) {
+	lat, lon, ok := MustParse("ATLNGA01DS0").Coordinates()
+	assert.True(t, ok)
+	assert.InDelta(t, 33.749, lat, 1e-9)
+	assert.InDelta(t, -84.388, lon, 1e-9)
+
+	lat, lon, ok = MustParse("CHCGIL01DS0").Coordinates()
+	assert.True(t, ok)
+	assert.InDelta(t, 41.8781, lat, 1e-9)
+	assert.InDelta(t, -87.6298, lon, 1e-9)
+
+	_, _, ok = MustParse("ZZZZNY01DS0").Coordinates()
+	assert.False(t, ok)
+}
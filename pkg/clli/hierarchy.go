@@ -0,0 +1,42 @@
+package clli
+
+import "fmt"
+
+// Location hierarchy
+// CLLIs form a hierarchy: a place in a region (CHCGIL) contains buildings
+// identified by a network site (CHCGIL01), which contain entities such as
+// switches (CHCGIL01DS0). These methods navigate from a code to its parents,
+// so entities can be rolled up to their building.
+
+// buildingComponents has the bits of the components of a building CLLI.
+const buildingComponents = 1<<ComponentPlace | 1<<ComponentRegion | 1<<ComponentNetworkSite
+
+// BuildingCLLI returns the 8-character building CLLI containing this code:
+// its place, region and network site, without the entity code or customer
+// tail. A building CLLI returns a copy of itself. Returns an error wrapping
+// ErrInvalidSite for codes without a network site, such as non-building
+// locations.
+func (c *CLLI) BuildingCLLI() (*CLLI, error) {
+	if c.NetworkSite == "" {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSite, newMessageError(MsgNoNetworkSite, c.Format()))
+	}
+
+	b := &CLLI{
+		Original:    c.Place + c.Region + c.NetworkSite,
+		Place:       c.Place,
+		Region:      c.Region,
+		NetworkSite: c.NetworkSite,
+		invalid:     c.invalid & buildingComponents,
+		relaxed:     c.relaxed & buildingComponents,
+		inferred:    c.inferred & buildingComponents,
+	}
+	b.valid = b.invalid == 0
+	b.cliType = determineCLLIType(b)
+	return b, nil
+}
+
+// ParentPlace returns the 6-character place and region code that is the top
+// of this code's hierarchy, e.g. "CHCGIL" for CHCGIL01DS0.
+func (c *CLLI) ParentPlace() string {
+	return c.Place + c.Region
+}
@@ -0,0 +1,44 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildingCLLI tests deriving the building of a code
+func TestBuildingCLLI(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"CHCGIL01DS0", "CHCGIL01"},
+		{"chcgil02mg1", "CHCGIL02"},
+		{"CHCGIL01", "CHCGIL01"},
+		{"MPLSMN01123456A", "MPLSMN01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			b, err := MustParse(tt.code).BuildingCLLI()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b.String())
+			assert.Equal(t, tt.expected, b.Format())
+			assert.Empty(t, b.EntityCode)
+			assert.True(t, b.IsValid())
+
+			reparsed := MustParse(tt.expected)
+			assert.Equal(t, reparsed.Type(), b.Type())
+		})
+	}
+
+	_, err := MustParse("CHCGILB1234").BuildingCLLI()
+	assert.ErrorIs(t, err, ErrInvalidSite)
+	assert.EqualError(t, err, "invalid network site code: CHCGILB1234 has no network site")
+}
+
+// TestParentPlace tests the place at the top of a code's hierarchy
+func TestParentPlace(t *testing.T) {
+	assert.Equal(t, "CHCGIL", MustParse("CHCGIL01DS0").ParentPlace())
+	assert.Equal(t, "CHCGIL", MustParse("CHCGILB1234").ParentPlace())
+}
@@ -165,7 +165,7 @@ func TestIntegrationParsing(t *testing.T) {
 				place:       "DLLS",
 				region:      "TX",
 				networkSite: "01",
-				entityCode:  "1234567",
+				entityCode:  "",
 			},
 			geographic: struct {
 				city        string
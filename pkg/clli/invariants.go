@@ -0,0 +1,144 @@
+package clli
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CheckRoundTrip verifies that parse(format(parse(x))) == parse(x) for the given input.
+// Inputs rejected by the parser are not round-trip candidates and yield nil.
+// A non-nil error describes how the formatter and parser disagree.
+//
+// Parameters:
+//   - input: The CLLI string to check
+//   - opts: Parsing options, or nil for default behavior
+func CheckRoundTrip(input string, opts *ParseOptions) error {
+	first, err := ParseWithOptions(input, opts)
+	if err != nil {
+		return nil
+	}
+
+	formatted := first.Format()
+	second, err := ParseWithOptions(formatted, opts)
+	if err != nil {
+		return fmt.Errorf("round trip of %q: formatted value %q no longer parses: %w", input, formatted, err)
+	}
+
+	if diff := diffComponents(first, second); diff != "" {
+		return fmt.Errorf("round trip of %q via %q changed %s", input, formatted, diff)
+	}
+
+	return nil
+}
+
+// diffComponents returns a description of the first component that differs
+// between two parsed CLLIs, or an empty string if they are equivalent.
+func diffComponents(a, b *CLLI) string {
+	fields := []struct {
+		name string
+		a, b string
+	}{
+		{"place", a.Place, b.Place},
+		{"region", a.Region, b.Region},
+		{"network_site", a.NetworkSite, b.NetworkSite},
+		{"entity_code", a.EntityCode, b.EntityCode},
+		{"location_code", a.LocationCode, b.LocationCode},
+		{"location_id", a.LocationID, b.LocationID},
+		{"customer_code", a.CustomerCode, b.CustomerCode},
+		{"customer_id", a.CustomerID, b.CustomerID},
+	}
+	for _, f := range fields {
+		if f.a != f.b {
+			return fmt.Sprintf("%s from %q to %q", f.name, f.a, f.b)
+		}
+	}
+
+	if a.cliType != b.cliType {
+		return fmt.Sprintf("type from %s to %s", a.cliType, b.cliType)
+	}
+	if a.valid != b.valid {
+		return fmt.Sprintf("validity from %t to %t", a.valid, b.valid)
+	}
+
+	return ""
+}
+
+// Invariants checks the internal consistency of c: each component has the
+// length and character class of its kind, the populated components form
+// exactly one CLLI layout, and, for CLLIs produced by the parser, the type
+// and validity agree with the components. Every CLLI returned by Parse or
+// ParseWithOptions satisfies them, so fuzz tests of code that round-trips
+// CLLIs can check what comes back. CLLIs built as struct literals have no
+// type, and only their components are checked.
+// Returns the violations joined with errors.Join, or nil if there are none.
+func Invariants(c *CLLI) error {
+	if c == nil {
+		return errors.New("nil CLLI")
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// Components
+	switch {
+	case !isAlpha(c.Place) || len(c.Place) > 4:
+		fail("place %q is not 1 to 4 letters", c.Place)
+	case len(c.Place) < 4 && c.FieldValid(ComponentPlace):
+		fail("short place %q is not flagged invalid", c.Place)
+	}
+	if len(c.Region) != 2 || !isAlpha(c.Region) {
+		fail("region %q is not 2 letters", c.Region)
+	}
+	if c.NetworkSite != "" && (len(c.NetworkSite) != 2 || !isAlphanumeric(c.NetworkSite)) {
+		fail("network site %q is not 2 letters or digits", c.NetworkSite)
+	}
+	if c.EntityCode != "" && !isValidEntityCode(c.EntityCode) {
+		fail("entity code %q is not 2 or 3 letters or digits", c.EntityCode)
+	}
+	if c.LocationCode != "" && (len(c.LocationCode) != 1 || !isAlpha(c.LocationCode)) {
+		fail("location code %q is not a letter", c.LocationCode)
+	}
+	if c.LocationID != "" && (len(c.LocationID) < 4 || !isDigitsOnly(c.LocationID)) {
+		fail("location ID %q is not 4 or more digits", c.LocationID)
+	}
+	if c.CustomerCode != "" && (len(c.CustomerCode) != 1 || !isAlphanumeric(c.CustomerCode)) {
+		fail("customer code %q is not a letter or digit", c.CustomerCode)
+	}
+	if c.CustomerID != "" && (len(c.CustomerID) < 4 || !isAlphanumeric(c.CustomerID)) {
+		fail("customer ID %q is not 4 or more letters or digits", c.CustomerID)
+	}
+
+	// Layout
+	entity := c.EntityCode != ""
+	location := c.LocationCode != "" || c.LocationID != ""
+	customer := c.CustomerCode != "" || c.CustomerID != ""
+	switch {
+	case entity && location, entity && customer, location && customer:
+		fail("components of more than one CLLI type are populated")
+	case location && (c.LocationCode == "" || c.LocationID == ""):
+		fail("location code and ID must be populated together")
+	case customer && (c.CustomerCode == "" || c.CustomerID == ""):
+		fail("customer code and ID must be populated together")
+	case entity && c.NetworkSite == "":
+		fail("entity code %q has no network site", c.EntityCode)
+	case location && c.NetworkSite != "":
+		fail("non-building location has network site %q", c.NetworkSite)
+	}
+	if n := len(c.Format()); n > 15 {
+		fail("formatted code has %d characters", n)
+	}
+
+	// Parser state
+	if c.cliType != CLLITypeUnknown {
+		if t := determineCLLIType(c); c.cliType != t {
+			fail("type %s does not match components of type %s", c.cliType, t)
+		}
+		if c.valid != (c.invalid == 0) {
+			fail("validity %t does not match invalid components %v", c.valid, c.InvalidFields())
+		}
+	}
+
+	return errors.Join(errs...)
+}
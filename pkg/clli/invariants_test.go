@@ -0,0 +1,157 @@
+package clli
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// candidateCLLI is a quick.Generator producing CLLI-shaped strings.
+// Most values share a valid place/region prefix so that a useful share
+// of them is accepted by the parser and exercises the round trip.
+type candidateCLLI string
+
+func (candidateCLLI) Generate(r *rand.Rand, _ int) reflect.Value {
+	const alnum = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	prefixes := []string{"CHCGIL", "NYCMNY", "LSANCA", "MPLSMN", "TOROON", "MTRLQC"}
+
+	b := []byte(prefixes[r.Intn(len(prefixes))])
+	n := 2 + r.Intn(10)
+	for i := 0; i < n; i++ {
+		b = append(b, alnum[r.Intn(len(alnum))])
+	}
+	return reflect.ValueOf(candidateCLLI(b))
+}
+
+// TestFormat tests reconstruction of CLLI strings from components
+func TestFormat(t *testing.T) {
+	tests := []string{
+		"CHCGIL01DS0",
+		"MPLSMNMSDS1",
+		"MPLSMNB1234",
+		"MPLSMN1A234",
+		"CHCGIL01",
+		"CHCGIL011234567",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			c := MustParse(input)
+			assert.Equal(t, input, c.Format())
+		})
+	}
+
+	t.Run("Reflects edited components", func(t *testing.T) {
+		c := MustParse("CHCGIL01DS0")
+		c.EntityCode = "MG1"
+		assert.Equal(t, "CHCGIL01MG1", c.Format())
+		assert.Equal(t, "CHCGIL01DS0", c.String())
+	})
+
+	t.Run("Lenient parses", func(t *testing.T) {
+		lenient := &ParseOptions{NormalizeCase: true, TrimWhitespace: true}
+		for input, expected := range map[string]string{
+			"CHI IL01DS0": "CHI IL01DS0",
+			"CHCG":        "CHCG",
+		} {
+			c, err := ParseWithOptions(input, lenient)
+			require.NoError(t, err, input)
+			assert.Equal(t, expected, c.Format(), input)
+		}
+	})
+}
+
+// TestCanonical tests canonical formatting and formatting options
+func TestCanonical(t *testing.T) {
+	c := MustParse("  chcgil01ds0 ")
+	assert.Equal(t, "CHCGIL01DS0", c.Canonical())
+
+	c = &CLLI{Place: "rye", Region: "ny", NetworkSite: "01", EntityCode: "ds0"}
+	assert.Equal(t, "rye ny01ds0", c.Format(), "Format pads short places")
+	assert.Equal(t, "ryeny01ds0", c.FormatWithOptions(nil))
+	assert.Equal(t, "rye ny01ds0", c.FormatWithOptions(&FormatOptions{PadPlace: true}))
+	assert.Equal(t, "RYENY01DS0", c.FormatWithOptions(&FormatOptions{Uppercase: true}))
+	assert.Equal(t, "RYE NY01DS0", c.Canonical())
+}
+
+// TestCheckRoundTrip tests the round-trip invariant on known inputs
+func TestCheckRoundTrip(t *testing.T) {
+	inputs := []string{
+		"CHCGIL01DS0",
+		"  chcgil01ds0  ",
+		"MPLSMNMSDS1",
+		"NYCMNYJ5678",
+		"NYCMNY2B567",
+		"LSANCA12",
+		"CHCGIL011234567",
+		"INVALID", // rejected inputs are not candidates
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			assert.NoError(t, CheckRoundTrip(input, nil))
+		})
+	}
+}
+
+// TestRoundTripProperty checks the round-trip invariant on generated inputs
+func TestRoundTripProperty(t *testing.T) {
+	property := func(s candidateCLLI) bool {
+		err := CheckRoundTrip(string(s), nil)
+		if err != nil {
+			t.Log(err)
+		}
+		return err == nil
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestInvariants tests the consistency checks on parsed and hand-built CLLIs
+func TestInvariants(t *testing.T) {
+	for _, s := range fuzzSeeds {
+		if c, err := Parse(s); err == nil {
+			assert.NoError(t, Invariants(c), s)
+		}
+		if c, err := ParseWithOptions(s, &ParseOptions{}); err == nil {
+			assert.NoError(t, Invariants(c), s)
+		}
+	}
+
+	assert.NoError(t, Invariants(&CLLI{Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "DS0"}))
+
+	tests := []struct {
+		name string
+		c    *CLLI
+		want string
+	}{
+		{"nil", nil, "nil CLLI"},
+		{"place", &CLLI{Place: "CH1G", Region: "IL"}, `place "CH1G" is not 1 to 4 letters`},
+		{"region", &CLLI{Place: "CHCG", Region: "I"}, `region "I" is not 2 letters`},
+		{"mixed layouts", &CLLI{Place: "CHCG", Region: "IL", NetworkSite: "01", EntityCode: "DS0", LocationCode: "B", LocationID: "1234"},
+			"components of more than one CLLI type are populated"},
+		{"unpaired location", &CLLI{Place: "CHCG", Region: "IL", LocationCode: "B"}, "location code and ID must be populated together"},
+		{"entity without site", &CLLI{Place: "CHCG", Region: "IL", EntityCode: "DS0"}, `entity code "DS0" has no network site`},
+		{"customer ID", &CLLI{Place: "CHCG", Region: "IL", CustomerCode: "1", CustomerID: "A23"},
+			`customer ID "A23" is not 4 or more letters or digits`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, Invariants(tt.c), tt.want)
+		})
+	}
+
+	t.Run("Edited type", func(t *testing.T) {
+		c := MustParse("CHCGIL01DS0")
+		c.EntityCode = ""
+		c.LocationCode, c.LocationID = "B", "1234"
+		c.NetworkSite = ""
+		assert.EqualError(t, Invariants(c), "type Entity does not match components of type NonBuilding")
+	})
+}
@@ -0,0 +1,152 @@
+package clli
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+)
+
+// JSONForm selects how a CLLI is encoded as JSON.
+type JSONForm int32
+
+const (
+	// JSONCompact encodes a CLLI as its formatted string, e.g. "CHCGIL01DS0"
+	JSONCompact JSONForm = iota
+
+	// JSONExpanded encodes a CLLI as an object of its components and type
+	JSONExpanded
+)
+
+// jsonForm is the form used by MarshalJSON.
+var jsonForm atomic.Int32
+
+// SetJSONForm sets the form used when encoding CLLIs as JSON. The default is
+// JSONCompact. Decoding accepts both forms regardless of this setting.
+func SetJSONForm(form JSONForm) {
+	jsonForm.Store(int32(form))
+}
+
+// DefaultJSONForm returns the form used when encoding CLLIs as JSON.
+func DefaultJSONForm() JSONForm {
+	return JSONForm(jsonForm.Load())
+}
+
+// expandedCLLI is the JSONExpanded encoding of a CLLI.
+type expandedCLLI struct {
+	Code         string `json:"code"`
+	Place        string `json:"place"`
+	Region       string `json:"region"`
+	NetworkSite  string `json:"site,omitempty"`
+	EntityCode   string `json:"entity,omitempty"`
+	LocationCode string `json:"location_code,omitempty"`
+	LocationID   string `json:"location_id,omitempty"`
+	CustomerCode string `json:"customer_code,omitempty"`
+	CustomerID   string `json:"customer_id,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Valid        bool   `json:"valid"`
+}
+
+var (
+	_ json.Marshaler   = CLLI{}
+	_ json.Unmarshaler = (*CLLI)(nil)
+)
+
+// MarshalJSON implements json.Marshaler, encoding the CLLI in the form
+// selected by SetJSONForm. The code is reconstructed with Format, so edits
+// to the component fields are reflected in the output. It has a value
+// receiver, like MarshalText, so CLLI values and struct fields are encoded
+// in the selected form rather than through MarshalText; encoding/json
+// writes a nil *CLLI as null.
+func (c CLLI) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONForm(DefaultJSONForm())
+}
+
+// MarshalJSONForm encodes the CLLI in the given form, independent of the
+// package-wide setting.
+func (c *CLLI) MarshalJSONForm(form JSONForm) ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	if form != JSONExpanded {
+		return json.Marshal(c.Format())
+	}
+	return json.Marshal(expandedCLLI{
+		Code:         c.Format(),
+		Place:        c.Place,
+		Region:       c.Region,
+		NetworkSite:  c.NetworkSite,
+		EntityCode:   c.EntityCode,
+		LocationCode: c.LocationCode,
+		LocationID:   c.LocationID,
+		CustomerCode: c.CustomerCode,
+		CustomerID:   c.CustomerID,
+		Type:         c.cliType.String(),
+		Valid:        c.valid,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either form. A
+// string is parsed as UnmarshalBinary parses it, so codes accepted with
+// AllowInternational or a padded place decode as well. An object is parsed from its "code" member,
+// or built from its components when the code is absent; "type" and "valid"
+// are derived rather than trusted. A JSON null leaves the CLLI unchanged.
+func (c *CLLI) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var parsed *CLLI
+	if len(data) > 0 && data[0] == '"' {
+		var code string
+		if err := json.Unmarshal(data, &code); err != nil {
+			return err
+		}
+		p, err := parseEncoded(code)
+		if err != nil {
+			return err
+		}
+		parsed = p
+	} else {
+		var e expandedCLLI
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		var err error
+		if e.Code != "" {
+			parsed, err = parseEncoded(e.Code)
+		} else {
+			parsed, err = e.builder().Build()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	*c = *parsed
+	return nil
+}
+
+// builder returns a Builder holding the non-empty components of e.
+func (e expandedCLLI) builder() *Builder {
+	b := NewBuilder()
+	components := []struct {
+		kind  ComponentKind
+		value string
+	}{
+		{ComponentPlace, e.Place},
+		{ComponentRegion, e.Region},
+		{ComponentNetworkSite, e.NetworkSite},
+		{ComponentEntityCode, e.EntityCode},
+		{ComponentLocationCode, e.LocationCode},
+		{ComponentLocationID, e.LocationID},
+		{ComponentCustomerCode, e.CustomerCode},
+		{ComponentCustomerID, e.CustomerID},
+	}
+	for _, comp := range components {
+		if comp.value != "" {
+			b.with(comp.kind, comp.value)
+		}
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package clli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMarshalJSON tests encoding in both JSON forms
+func TestMarshalJSON(t *testing.T) {
+	c := MustParse(" chcgil01ds0 ")
+
+	data, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"CHCGIL01DS0"`, string(data))
+
+	data, err = c.MarshalJSONForm(JSONExpanded)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code":"CHCGIL01DS0","place":"CHCG","region":"IL","site":"01","entity":"DS0","type":"Entity","valid":true}`, string(data))
+
+	payload := struct {
+		Switch *CLLI `json:"switch"`
+		Peer   *CLLI `json:"peer"`
+	}{Switch: MustParse("DLLSTXB1234")}
+
+	SetJSONForm(JSONExpanded)
+	defer SetJSONForm(JSONCompact)
+	assert.Equal(t, JSONExpanded, DefaultJSONForm())
+
+	data, err = json.Marshal(payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"switch":{"code":"DLLSTXB1234","place":"DLLS","region":"TX","location_code":"B","location_id":"1234","type":"NonBuilding","valid":true},"peer":null}`, string(data))
+
+	// Values and value fields use the selected form too
+	data, err = json.Marshal(*c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code":"CHCGIL01DS0","place":"CHCG","region":"IL","site":"01","entity":"DS0","type":"Entity","valid":true}`, string(data))
+
+	data, err = json.Marshal(struct{ C CLLI }{C: *c})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"C":{"code":"CHCGIL01DS0","place":"CHCG","region":"IL","site":"01","entity":"DS0","type":"Entity","valid":true}}`, string(data))
+}
+
+// TestUnmarshalJSON tests decoding from both JSON forms
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"compact", `"chcgil01ds0"`},
+		{"expanded code", `{"code":"CHCGIL01DS0","type":"Customer","valid":false}`},
+		{"expanded components", `{"place":"CHCG","region":"IL","site":"01","entity":"DS0"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c CLLI
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &c))
+			assert.Equal(t, "CHCGIL01DS0", c.Format())
+			assert.Equal(t, CLLITypeEntity, c.Type())
+			assert.True(t, c.IsValid())
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, form := range []JSONForm{JSONCompact, JSONExpanded} {
+			data, err := MustParse("MPLSMN1A2345").MarshalJSONForm(form)
+			require.NoError(t, err)
+			var c CLLI
+			require.NoError(t, json.Unmarshal(data, &c))
+			assert.Equal(t, *MustParse("MPLSMN1A2345"), c)
+		}
+	})
+
+	t.Run("parse options", func(t *testing.T) {
+		for _, p := range optionParsed(t) {
+			for _, form := range []JSONForm{JSONCompact, JSONExpanded} {
+				data, err := p.MarshalJSONForm(form)
+				require.NoError(t, err)
+				var c CLLI
+				require.NoError(t, json.Unmarshal(data, &c))
+				assert.Equal(t, p.Format(), c.Format())
+				assert.Equal(t, p.Type(), c.Type())
+			}
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var payload struct {
+			Switch *CLLI `json:"switch"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"switch":null}`), &payload))
+		assert.Nil(t, payload.Switch)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var c CLLI
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"CHCGZZ01DS0"`), &c), ErrInvalidRegion)
+		assert.ErrorIs(t, json.Unmarshal([]byte(`{"place":"CHCG","region":"IL","entity":"QQQ"}`), &c), ErrInvalidEntity)
+		assert.Error(t, json.Unmarshal([]byte(`42`), &c))
+		assert.False(t, c.IsValid())
+	})
+}
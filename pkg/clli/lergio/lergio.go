@@ -0,0 +1,274 @@
+// Package lergio loads LERG switch and NPA-NXX records into the clli
+// package, so carriers with a LERG subscription get switch, registry and
+// city resolution from one call:
+//
+//	data, err := lergio.LoadAndInstall(lerg7, lerg6, &lergio.Options{Snapshot: "2024-06"})
+//
+// The LERG 7 file lists switches, one per record, with the switch CLLI,
+// owning OCN, equipment type, host switch of remotes, and the city and
+// state of the switch. The LERG 6 file lists NPA-NXX assignments and the
+// switch serving each. Both are read as delimited files whose header row
+// names the columns; see SwitchColumns and NPANXXColumns for the names
+// recognized. Other columns are ignored.
+package lergio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Column names recognized in LERG 7 switch records, matched case-insensitively.
+// The switch column is required; the others are optional.
+var SwitchColumns = map[string][]string{
+	"switch":    {"SWITCH", "SWITCH_CLLI", "CLLI"},
+	"ocn":       {"OCN"},
+	"equipment": {"EQPT_TYPE", "EQUIP_TYPE", "EQUIPMENT"},
+	"host":      {"HOST", "HOST_CLLI"},
+	"city":      {"CITY", "SWITCH_CITY", "LOC_CITY"},
+	"state":     {"STATE", "SWITCH_STATE", "LOC_STATE"},
+}
+
+// Column names recognized in LERG 6 NPA-NXX records, matched case-insensitively.
+// All three columns are required.
+var NPANXXColumns = map[string][]string{
+	"npa":    {"NPA"},
+	"nxx":    {"NXX"},
+	"switch": {"SWITCH", "SWITCH_CLLI", "CLLI"},
+}
+
+// ErrMissingColumn is returned when a required column is not in the header row.
+var ErrMissingColumn = errors.New("lergio: missing column")
+
+// Options controls how LERG files are read.
+type Options struct {
+	// Comma is the field separator. Zero selects ','; use '|' for
+	// pipe-delimited extracts.
+	Comma rune
+
+	// ParseOptions controls how switch CLLIs are parsed. Nil selects the
+	// same defaults as clli.Parse.
+	ParseOptions *clli.ParseOptions
+
+	// OnError, if set, is called for each record that cannot be loaded and
+	// the record is skipped. Otherwise loading stops at the first such record.
+	OnError func(file string, line int, err error)
+
+	// DatasetName names the place dataset built from switch cities. Empty
+	// selects "lerg".
+	DatasetName string
+
+	// Snapshot is the LERG issue the files come from, such as "2024-06".
+	// Install adds the place dataset as a snapshot when it is set.
+	Snapshot string
+}
+
+// Data is the content of a set of LERG files.
+type Data struct {
+	Registry *clli.Registry    // Every switch CLLI
+	Switches *clli.SwitchTable // Switch attributes and NPA-NXX assignments
+	Places   *clli.Dataset     // Cities of the switches' places
+}
+
+// Load reads LERG 7 switch records from switches and, if npanxx is not
+// nil, LERG 6 NPA-NXX records from npanxx. A nil opts selects the defaults.
+func Load(switches, npanxx io.Reader, opts *Options) (*Data, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.DatasetName == "" {
+		o.DatasetName = "lerg"
+	}
+
+	l := &loader{opts: o, byCLLI: make(map[string]int)}
+	if err := l.readSwitches(switches); err != nil {
+		return nil, err
+	}
+	if npanxx != nil {
+		if err := l.readNPANXX(npanxx); err != nil {
+			return nil, err
+		}
+	}
+	return l.data(), nil
+}
+
+// Install adds the places of d to r, or to clli.DefaultResolver if r is
+// nil, and makes d the source of its switch lookups, so the geographic
+// lookups resolve LERG switch cities and r.Switch resolves its switches.
+func (d *Data) Install(r *clli.Resolver) {
+	if r == nil {
+		r = clli.DefaultResolver()
+	}
+	if d.Places.Snapshot() != "" {
+		r.AddSnapshot(d.Places)
+	} else {
+		r.AddDataset(d.Places)
+	}
+	r.SetSwitchResolver(d.Switches)
+}
+
+// LoadAndInstall loads LERG files with Load and installs them into the
+// DefaultResolver with Install.
+func LoadAndInstall(switches, npanxx io.Reader, opts *Options) (*Data, error) {
+	d, err := Load(switches, npanxx, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.Install(nil)
+	return d, nil
+}
+
+// loader accumulates records across files.
+type loader struct {
+	opts     Options
+	codes    []*clli.CLLI
+	switches []clli.SwitchInfo
+	places   []clli.PlaceRecord
+	byCLLI   map[string]int // Index into switches
+}
+
+// readSwitches reads LERG 7 switch records.
+func (l *loader) readSwitches(r io.Reader) error {
+	return l.read("switch", r, SwitchColumns, []string{"switch"}, func(get func(string) string) error {
+		c, err := clli.ParseWithOptions(get("switch"), l.opts.ParseOptions)
+		if err != nil {
+			return err
+		}
+		if !c.IsEntityCLLI() {
+			return fmt.Errorf("%s: %w: not an entity CLLI", c.Format(), clli.ErrInvalidCLLI)
+		}
+
+		if state := strings.ToUpper(get("state")); state != "" && state != c.Region {
+			return fmt.Errorf("%s: state %s does not match region %s", c.Format(), state, c.Region)
+		}
+
+		info := clli.SwitchInfo{
+			CLLI:      c.Format(),
+			OCN:       get("ocn"),
+			Equipment: get("equipment"),
+			HostCLLI:  clli.NormalizeForCompare(get("host")),
+		}
+		if info.HostCLLI == info.CLLI {
+			info.HostCLLI = ""
+		}
+		if i, ok := l.byCLLI[info.CLLI]; ok {
+			l.switches[i] = info
+		} else {
+			l.byCLLI[info.CLLI] = len(l.switches)
+			l.switches = append(l.switches, info)
+			l.codes = append(l.codes, c)
+		}
+
+		if city := get("city"); city != "" {
+			l.places = append(l.places, clli.PlaceRecord{Place: c.Place, Region: c.Region, City: city})
+		}
+		return nil
+	})
+}
+
+// readNPANXX reads LERG 6 NPA-NXX records, attaching each NPA-NXX to its
+// serving switch.
+func (l *loader) readNPANXX(r io.Reader) error {
+	return l.read("npanxx", r, NPANXXColumns, []string{"npa", "nxx", "switch"}, func(get func(string) string) error {
+		npa, nxx := get("npa"), get("nxx")
+		code := npa + nxx
+		if len(npa) != 3 || len(nxx) != 3 || strings.Trim(code, "0123456789") != "" {
+			return fmt.Errorf("invalid NPA-NXX %s-%s", npa, nxx)
+		}
+		switchCLLI := clli.NormalizeForCompare(get("switch"))
+		i, ok := l.byCLLI[switchCLLI]
+		if !ok {
+			return fmt.Errorf("NPA-NXX %s: unknown switch %s", code, switchCLLI)
+		}
+		l.switches[i].NPANXX = append(l.switches[i].NPANXX, code)
+		return nil
+	})
+}
+
+// read calls record for each record of a delimited file, passing a
+// function returning the trimmed value of a named column.
+func (l *loader) read(file string, r io.Reader, columns map[string][]string, required []string, record func(get func(string) string) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	if l.opts.Comma != 0 {
+		cr.Comma = l.opts.Comma
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("lergio: %s header: %w", file, err)
+	}
+	index := make(map[string]int)
+	for name, aliases := range columns {
+		for i, h := range header {
+			h = strings.TrimSpace(h)
+			for _, alias := range aliases {
+				if _, seen := index[name]; !seen && strings.EqualFold(h, alias) {
+					index[name] = i
+				}
+			}
+		}
+	}
+	for _, name := range required {
+		if _, ok := index[name]; !ok {
+			return fmt.Errorf("%w: %s %s", ErrMissingColumn, file, name)
+		}
+	}
+
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("lergio: %s: %w", file, err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		get := func(name string) string {
+			if i, ok := index[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		if err := record(get); err != nil {
+			if l.opts.OnError == nil {
+				return fmt.Errorf("lergio: %s line %d: %w", file, line, err)
+			}
+			l.opts.OnError(file, line, err)
+		}
+	}
+}
+
+// data builds the loaded Data, assigning host and remote roles.
+func (l *loader) data() *Data {
+	hosts := make(map[string]bool)
+	for _, s := range l.switches {
+		if s.HostCLLI != "" {
+			hosts[s.HostCLLI] = true
+		}
+	}
+	for i := range l.switches {
+		s := &l.switches[i]
+		switch {
+		case s.HostCLLI != "":
+			s.Role = clli.SwitchRoleRemote
+		case hosts[s.CLLI]:
+			s.Role = clli.SwitchRoleHost
+		default:
+			s.Role = clli.SwitchRoleStandalone
+		}
+	}
+
+	return &Data{
+		Registry: clli.NewRegistry(l.codes...),
+		Switches: clli.NewSwitchTable(l.switches),
+		Places:   clli.NewDatasetSnapshot(l.opts.DatasetName, l.opts.Snapshot, l.places),
+	}
+}
@@ -0,0 +1,94 @@
+package lergio
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+const (
+	lerg7 = "LATA,SWITCH,OCN,EQPT_TYPE,HOST,CITY,STATE\n" +
+		"358,CHCGIL01DS0,9533,5ESS,,CHICAGO,IL\n" +
+		"358,RYEBNY01RS0,9533,5ESS,chcgil01ds0,RYE BROOK,NY\n" +
+		"552,DLLSTX01DS0,9206,DMS100,,DALLAS,\n"
+	lerg6 = "NPA|NXX|SWITCH\n312|555|CHCGIL01DS0\n312|556|CHCGIL01DS0\n914|939|RYEBNY01RS0\n"
+)
+
+// TestLoad tests loading switch and NPA-NXX records
+func TestLoad(t *testing.T) {
+	d, err := Load(strings.NewReader(lerg7), strings.NewReader(strings.ReplaceAll(lerg6, "|", ",")), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, d.Registry.Len())
+	assert.Equal(t, 3, d.Switches.Len())
+	assert.Equal(t, 3, d.Places.Len())
+	assert.Equal(t, "lerg", d.Places.Name())
+
+	info, ok, err := d.Switches.Switch(context.Background(), "CHCGIL01DS0")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, clli.SwitchInfo{
+		CLLI: "CHCGIL01DS0", Role: clli.SwitchRoleHost, OCN: "9533", Equipment: "5ESS", NPANXX: []string{"312555", "312556"},
+	}, info)
+
+	info, ok = d.Switches.ServingSwitch("914939")
+	require.True(t, ok)
+	assert.Equal(t, clli.SwitchRoleRemote, info.Role)
+	assert.Equal(t, "CHCGIL01DS0", info.HostCLLI)
+
+	info, _, _ = d.Switches.Switch(context.Background(), "DLLSTX01DS0")
+	assert.Equal(t, clli.SwitchRoleStandalone, info.Role)
+
+	place, ok := d.Places.Lookup("RYEB", "NY")
+	require.True(t, ok)
+	assert.Equal(t, "RYE BROOK", place.City)
+}
+
+// TestLoadErrors tests handling of bad records and headers
+func TestLoadErrors(t *testing.T) {
+	_, err := Load(strings.NewReader("OCN,CITY\n9533,CHICAGO\n"), nil, nil)
+	assert.ErrorIs(t, err, ErrMissingColumn)
+
+	bad := "SWITCH,STATE,CITY\nCHCGIL01DS0,IL,CHICAGO\nCHCGZZ01DS0,,\nCHCGILB1234,,\nDLLSTX01DS0,OK,DALLAS\n"
+	_, err = Load(strings.NewReader(bad), nil, nil)
+	assert.ErrorIs(t, err, clli.ErrInvalidRegion)
+	assert.ErrorContains(t, err, "lergio: switch line 3")
+
+	var lines []int
+	opts := &Options{Comma: '|', OnError: func(file string, line int, err error) {
+		lines = append(lines, line)
+	}}
+	d, err := Load(strings.NewReader(strings.ReplaceAll(bad, ",", "|")),
+		strings.NewReader("NPA|NXX|SWITCH\n312|555|CHCGIL01DS0\n31|2555|CHCGIL01DS0\n214|555|DLLSTX01DS0\n"), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5, 3, 4}, lines)
+	assert.Equal(t, 1, d.Registry.Len())
+	assert.Equal(t, 1, d.Places.Len())
+}
+
+// TestInstall tests installing loaded data into the resolvers
+func TestInstall(t *testing.T) {
+	d, err := Load(strings.NewReader(lerg7), nil, &Options{DatasetName: "lerg7", Snapshot: "2024-06"})
+	require.NoError(t, err)
+
+	r := clli.NewResolver()
+	d.Install(r)
+
+	city, err := r.City(context.Background(), "RYEB", "NY")
+	require.NoError(t, err)
+	assert.Equal(t, "RYE BROOK", city)
+	assert.Equal(t, map[string]string{"lerg7": "2024-06"}, r.Snapshots())
+
+	info, ok, err := clli.MustParse("RYEBNY01RS0").ResolveSwitchWith(context.Background(), r)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, clli.SwitchRoleRemote, info.Role)
+
+	// Installing into a Resolver of its own leaves the default untouched
+	assert.Nil(t, clli.DefaultSwitchResolver())
+}
@@ -0,0 +1,99 @@
+package clli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Location ID assignment
+// Non-building location IDs are four digits assigned within each location
+// code letter. The public specification does not divide the IDs of a letter
+// into ranges or reserve blocks of them, so the default rules only check
+// that the letter is assigned and allow 0001-9999 under it, rejecting 0000.
+// Carriers that enforce their own assignment plans, with narrower ranges or
+// reserved blocks, register them with SetLocationIDRange.
+
+// LocationIDRange describes the location IDs assignable under a
+// non-building location code letter.
+type LocationIDRange struct {
+	Min, Max int      // Assignable IDs, inclusive
+	Reserved [][2]int // Reserved sub-ranges within Min-Max, inclusive
+}
+
+// reserved reports whether id falls in a reserved sub-range.
+func (r LocationIDRange) reserved(id int) bool {
+	for _, span := range r.Reserved {
+		if id >= span[0] && id <= span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	locationIDRangesMu sync.RWMutex
+
+	// locationIDRanges holds the rules for each location code letter. The
+	// defaults are the same for every assigned letter and reserve nothing.
+	locationIDRanges = map[string]LocationIDRange{
+		"B": {Min: 1, Max: 9999},
+		"E": {Min: 1, Max: 9999},
+		"J": {Min: 1, Max: 9999},
+		"M": {Min: 1, Max: 9999},
+		"P": {Min: 1, Max: 9999},
+	}
+)
+
+// SetLocationIDRange replaces the assignment rules for a location code
+// letter, registering the letter if it had none.
+func SetLocationIDRange(code string, r LocationIDRange) {
+	locationIDRangesMu.Lock()
+	defer locationIDRangesMu.Unlock()
+	locationIDRanges[strings.ToUpper(code)] = r
+}
+
+// LookupLocationIDRange returns the assignment rules for a location code
+// letter, and false if the letter has none.
+func LookupLocationIDRange(code string) (LocationIDRange, bool) {
+	locationIDRangesMu.RLock()
+	defer locationIDRangesMu.RUnlock()
+	r, ok := locationIDRanges[strings.ToUpper(code)]
+	return r, ok
+}
+
+// validateLocationID checks a location ID against the rules of its
+// location code letter.
+func validateLocationID(code, id string) error {
+	r, ok := LookupLocationIDRange(code)
+	if !ok {
+		return newMessageError(MsgLocationUnassigned, code)
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil || len(id) != 4 {
+		return newMessageError(MsgLocationID)
+	}
+	if n < r.Min || n > r.Max {
+		return newMessageError(MsgLocationRange, id, code, r.Min, r.Max)
+	}
+	if r.reserved(n) {
+		return newMessageError(MsgLocationReserved, id, code)
+	}
+	return nil
+}
+
+// ValidateLocationID checks the location ID of a non-building CLLI against
+// the assignment rules of its location code letter, reporting unassigned
+// letters, IDs outside the assignable range and reserved IDs. Returns an
+// error wrapping ErrInvalidLocation, or nil for valid IDs and for CLLIs
+// that are not non-building CLLIs with a location code.
+func (c *CLLI) ValidateLocationID() error {
+	if c.cliType != CLLITypeNonBuilding || c.LocationCode == "" {
+		return nil
+	}
+	if err := validateLocationID(c.LocationCode, c.LocationID); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLocation, err)
+	}
+	return nil
+}
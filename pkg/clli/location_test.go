@@ -0,0 +1,49 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateLocationID tests location ID assignment rules
+func TestValidateLocationID(t *testing.T) {
+	assert.NoError(t, MustParse("MPLSMNB1234").ValidateLocationID())
+	assert.NoError(t, MustParse("CHCGIL01DS0").ValidateLocationID())
+
+	err := MustParse("MPLSMNB0000").ValidateLocationID()
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+	assert.EqualError(t, err, "invalid location code: location ID 0000 is outside the range 0001-9999 of location code B")
+
+	err = MustParse("MPLSMNA1234").ValidateLocationID()
+	assert.ErrorContains(t, err, "location code A is not assigned")
+
+	defer SetLocationIDRange("M", LocationIDRange{Min: 1, Max: 9999})
+	SetLocationIDRange("m", LocationIDRange{Min: 1000, Max: 8999, Reserved: [][2]int{{5000, 5099}}})
+	r, ok := LookupLocationIDRange("M")
+	require.True(t, ok)
+	assert.Equal(t, 8999, r.Max)
+
+	assert.NoError(t, MustParse("MPLSMNM1234").ValidateLocationID())
+	assert.ErrorContains(t, MustParse("MPLSMNM9000").ValidateLocationID(), "outside the range 1000-8999")
+	assert.EqualError(t, MustParse("MPLSMNM5050").ValidateLocationID(), "invalid location code: location ID 5050 is reserved under location code M")
+}
+
+// TestValidateLocationIDsOption tests location ID validation during parsing
+func TestValidateLocationIDsOption(t *testing.T) {
+	opts := &ParseOptions{Strict: true, NormalizeCase: true, ValidateLocationIDs: true}
+
+	_, err := ParseWithOptions("mplsmnb1234", opts)
+	assert.NoError(t, err)
+
+	_, err = ParseWithOptions("MPLSMNB0000", opts)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+	assert.Equal(t, ErrCodeBadLocation, ErrorCodeOf(err))
+	assert.Equal(t, "bad_location", ErrCodeBadLocation.String())
+
+	opts.Strict = false
+	c, err := ParseWithOptions("MPLSMNB0000", opts)
+	require.NoError(t, err)
+	assert.Equal(t, []ComponentKind{ComponentLocationID}, c.InvalidFields())
+}
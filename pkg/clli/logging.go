@@ -0,0 +1,61 @@
+package clli
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer so that a CLLI logged as an attribute
+// value is rendered as a group of its components rather than a raw string.
+// Components are masked according to the redaction policy, as in String.
+func (c *CLLI) LogValue() slog.Value {
+	if c == nil {
+		return slog.Value{}
+	}
+	return slog.GroupValue(c.logAttrs()...)
+}
+
+// LogAttrs returns a "clli" attribute group carrying the code, its components
+// and its type, for consistent structured logging:
+//
+//	logger.Info("provisioned", clli.LogAttrs(c))
+//
+// Empty components are omitted, and components are masked according to the
+// redaction policy, as in String. A nil CLLI yields an empty group, which slog
+// handlers drop from the output.
+func LogAttrs(c *CLLI) slog.Attr {
+	if c == nil {
+		return slog.Attr{Key: "clli", Value: slog.GroupValue()}
+	}
+	return slog.Attr{Key: "clli", Value: slog.GroupValue(c.logAttrs()...)}
+}
+
+// logAttrs returns the non-empty components of c as log attributes, masked
+// according to the redaction policy.
+func (c *CLLI) logAttrs() []slog.Attr {
+	code := c.Original
+	if p := c.redactionPolicy(); len(p.Components) > 0 {
+		c = p.apply(c)
+		code = c.Format()
+	}
+
+	attrs := make([]slog.Attr, 0, 8)
+	attrs = append(attrs, slog.String("code", code))
+
+	fields := []struct {
+		key, value string
+	}{
+		{"place", c.Place},
+		{"region", c.Region},
+		{"site", c.NetworkSite},
+		{"entity", c.EntityCode},
+		{"location_code", c.LocationCode},
+		{"location_id", c.LocationID},
+		{"customer_code", c.CustomerCode},
+		{"customer_id", c.CustomerID},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			attrs = append(attrs, slog.String(f.key, f.value))
+		}
+	}
+
+	return append(attrs, slog.String("type", c.cliType.String()))
+}
@@ -0,0 +1,109 @@
+package clli
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogValue tests structured logging of CLLI values
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	c := MustParse("CHCGIL01DS0")
+	logger.Info("parsed", "site", c)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, map[string]any{
+		"code":   "CHCGIL01DS0",
+		"place":  "CHCG",
+		"region": "IL",
+		"site":   "01",
+		"entity": "DS0",
+		"type":   "Entity",
+	}, record["site"])
+}
+
+// TestLogAttrs tests the grouped attribute helper
+func TestLogAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Info("parsed", LogAttrs(MustParse("MPLSMNB1234")))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, map[string]any{
+		"code":          "MPLSMNB1234",
+		"place":         "MPLS",
+		"region":        "MN",
+		"location_code": "B",
+		"location_id":   "1234",
+		"type":          "NonBuilding",
+	}, record["clli"])
+
+	t.Run("Nil CLLI", func(t *testing.T) {
+		buf.Reset()
+		logger.Info("missing", LogAttrs(nil))
+		record = nil
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.NotContains(t, record, "clli")
+	})
+}
+
+// TestRedactionPolicy tests masking components in String and log output
+func TestRedactionPolicy(t *testing.T) {
+	c := MustParse("chcgil01ds0")
+	assert.Equal(t, "CHCGIL*****", RedactFacility.Redact(c))
+	assert.Equal(t, "MPLSMNB####", RedactionPolicy{Components: []ComponentKind{ComponentLocationID}, Mask: '#'}.Redact(MustParse("MPLSMNB1234")))
+	assert.Equal(t, "CHCGIL01DS0", RedactionPolicy{}.Redact(c))
+	assert.Empty(t, RedactFacility.Redact(nil))
+	assert.Equal(t, c.Original, c.String(), "String echoes the input without a policy")
+
+	SetRedactionPolicy(RedactFacility)
+	defer SetRedactionPolicy(RedactionPolicy{})
+	assert.Equal(t, RedactFacility, CurrentRedactionPolicy())
+
+	assert.Equal(t, "CHCGIL*****", c.String())
+	assert.Equal(t, "CHCGIL01DS0", c.Format(), "Format is not redacted")
+	assert.Equal(t, "01", c.NetworkSite)
+	assert.Equal(t, "CHCGIL*****-NYCMNY**", Span{A: c, Z: MustParse("NYCMNY02")}.String())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("parsed", "site", c)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, map[string]any{
+		"code":   "CHCGIL*****",
+		"place":  "CHCG",
+		"region": "IL",
+		"site":   "**",
+		"entity": "***",
+		"type":   "Entity",
+	}, record["site"])
+
+	SetRedactionPolicy(RedactionPolicy{})
+	assert.Equal(t, c.Original, c.String())
+}
+
+// TestParserRedactionPolicy tests masking CLLIs parsed by a Parser with a policy of its own
+func TestParserRedactionPolicy(t *testing.T) {
+	p := MustNewParser(nil)
+	p.SetRedactionPolicy(RedactFacility)
+
+	c, err := p.Parse("CHCGIL01DS0")
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL*****", c.String())
+	assert.Equal(t, "CHCGIL*****", LogAttrs(c).Value.Group()[0].Value.String())
+
+	// The package-level policy still applies to other CLLIs
+	assert.Equal(t, "CHCGIL01DS0", MustParse("CHCGIL01DS0").String())
+}
@@ -0,0 +1,162 @@
+package clli
+
+import "fmt"
+
+// Wildcard matching
+// Patterns use "?" to match any one character and "*" to match any run of
+// characters, including none. Letters match case-insensitively and
+// surrounding whitespace is ignored, as in Parse.
+
+// ComponentPattern matches CLLIs component by component. Each field is a
+// wildcard pattern for the component of the same name; an empty field
+// matches any value, including an absent component.
+type ComponentPattern struct {
+	Place        string
+	Region       string
+	NetworkSite  string
+	EntityCode   string
+	LocationCode string
+	LocationID   string
+	CustomerCode string
+	CustomerID   string
+}
+
+// Matcher is a compiled wildcard pattern over whole codes or over
+// components. A Matcher is safe for concurrent use.
+type Matcher struct {
+	code       string                          // Whole-code pattern, if compiled by CompileMatcher
+	components [ComponentCustomerID + 1]string // Component patterns, indexed by ComponentKind
+}
+
+// Match reports whether code matches the wildcard pattern, for example
+// "CHCGIL??*" for every code at a Chicago building. The code is not
+// validated. Returns false if the pattern is malformed.
+func Match(pattern, code string) bool {
+	m, err := CompileMatcher(pattern)
+	if err != nil {
+		return false
+	}
+	return m.MatchString(code)
+}
+
+// CompileMatcher compiles a whole-code wildcard pattern for reuse.
+// Returns an error if the pattern contains characters other than letters,
+// digits, "?" and "*".
+func CompileMatcher(pattern string) (*Matcher, error) {
+	p, err := compileGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{code: p}, nil
+}
+
+// CompileComponentMatcher compiles a component pattern for reuse, for
+// example ComponentPattern{Place: "CHCG", EntityCode: "DS*"}.
+// Returns an error if any field is malformed.
+func CompileComponentMatcher(p ComponentPattern) (*Matcher, error) {
+	fields := [...]string{
+		ComponentPlace:        p.Place,
+		ComponentRegion:       p.Region,
+		ComponentNetworkSite:  p.NetworkSite,
+		ComponentEntityCode:   p.EntityCode,
+		ComponentLocationCode: p.LocationCode,
+		ComponentLocationID:   p.LocationID,
+		ComponentCustomerCode: p.CustomerCode,
+		ComponentCustomerID:   p.CustomerID,
+	}
+
+	m := &Matcher{}
+	for kind, field := range fields {
+		if field == "" {
+			continue
+		}
+		g, err := compileGlob(field)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ComponentKind(kind), err)
+		}
+		m.components[kind] = g
+	}
+	return m, nil
+}
+
+// Match reports whether c matches the pattern.
+func (m *Matcher) Match(c *CLLI) bool {
+	if c == nil {
+		return false
+	}
+	if m.code != "" {
+		return matchGlob(m.code, c.Format())
+	}
+
+	values := [...]string{
+		ComponentPlace:        c.Place,
+		ComponentRegion:       c.Region,
+		ComponentNetworkSite:  c.NetworkSite,
+		ComponentEntityCode:   c.EntityCode,
+		ComponentLocationCode: c.LocationCode,
+		ComponentLocationID:   c.LocationID,
+		ComponentCustomerCode: c.CustomerCode,
+		ComponentCustomerID:   c.CustomerID,
+	}
+	for kind, pattern := range m.components {
+		if pattern != "" && !matchGlob(pattern, values[kind]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchString reports whether code matches the pattern. Whole-code
+// patterns match code as given; component patterns parse it first and
+// never match a code that fails to parse.
+func (m *Matcher) MatchString(code string) bool {
+	if m.code != "" {
+		return matchGlob(m.code, NormalizeForCompare(code))
+	}
+	c, err := Parse(code)
+	if err != nil {
+		return false
+	}
+	return m.Match(c)
+}
+
+// compileGlob normalizes a wildcard pattern and checks its characters.
+func compileGlob(pattern string) (string, error) {
+	p := NormalizeForCompare(pattern)
+	if p == "" {
+		return "", newMessageError(MsgPatternEmpty, pattern)
+	}
+	for _, r := range p {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '?' || r == '*') {
+			return "", newMessageError(MsgPatternCharacter, pattern, r)
+		}
+	}
+	return p, nil
+}
+
+// matchGlob reports whether s matches a compiled wildcard pattern. It
+// backtracks only to the most recent "*" rather than recursing.
+func matchGlob(pattern, s string) bool {
+	p, i := 0, 0
+	star, mark := -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, i
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			i = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
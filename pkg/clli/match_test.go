@@ -0,0 +1,68 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMatch tests whole-code wildcard patterns
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		code     string
+		expected bool
+	}{
+		{"CHCGIL??*", "CHCGIL01DS0", true},
+		{"CHCGIL??*", "CHCGIL01", true},
+		{"CHCGIL??*", "CHCGIL0", false},
+		{"chcgil*", " CHCGIL01DS0 ", true},
+		{"CHCGIL01DS?", "CHCGIL01DS0", true},
+		{"CHCGIL01DS?", "CHCGIL01DS01", false},
+		{"*DS0", "CHCGIL01DS0", true},
+		{"*DS*", "DLLSTX02DS1", true},
+		{"*", "CHCGIL01DS0", true},
+		{"NYCM*", "CHCGIL01DS0", false},
+		{"CHCG-*", "CHCGIL01DS0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Match(tt.pattern, tt.code))
+		})
+	}
+}
+
+// TestCompileMatcher tests compiled matchers and pattern errors
+func TestCompileMatcher(t *testing.T) {
+	m, err := CompileMatcher("CHCGIL01*")
+	require.NoError(t, err)
+	assert.True(t, m.Match(MustParse("CHCGIL01DS0")))
+	assert.False(t, m.Match(MustParse("CHCGIL02DS0")))
+	assert.False(t, m.Match(nil))
+
+	_, err = CompileMatcher("CHCG-*")
+	assert.EqualError(t, err, `invalid pattern "CHCG-*": unexpected character '-'`)
+	_, err = CompileMatcher(" ")
+	assert.EqualError(t, err, `invalid pattern " ": empty`)
+}
+
+// TestComponentMatcher tests component-level patterns
+func TestComponentMatcher(t *testing.T) {
+	m, err := CompileComponentMatcher(ComponentPattern{Place: "CHCG", EntityCode: "DS*"})
+	require.NoError(t, err)
+	assert.True(t, m.MatchString("CHCGIL01DS0"))
+	assert.True(t, m.MatchString("chcgil02ds1"))
+	assert.False(t, m.MatchString("CHCGIL01MG0"))
+	assert.False(t, m.MatchString("DLLSTX01DS0"))
+	assert.False(t, m.MatchString("CHCGIL01"))
+	assert.False(t, m.MatchString("not a clli"))
+
+	m, err = CompileComponentMatcher(ComponentPattern{Region: "TX"})
+	require.NoError(t, err)
+	codes := Filter(parseAll(t, "DLLSTX01DS0", "CHCGIL01DS0", "HSTXTX12"), m.Match)
+	assert.Equal(t, []string{"DLLSTX01DS0", "HSTXTX12"}, formatAll(codes))
+
+	_, err = CompileComponentMatcher(ComponentPattern{EntityCode: "D%"})
+	assert.EqualError(t, err, `entity_code: invalid pattern "D%": unexpected character '%'`)
+}
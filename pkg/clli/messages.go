@@ -0,0 +1,412 @@
+package clli
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MessageID is a stable identifier for a translatable error message.
+// IDs never change between releases, so they can be used as keys in
+// operator tooling and translation files.
+type MessageID string
+
+// Message identifiers for every error the package reports.
+const (
+	MsgParseError MessageID = "parse_error"
+
+	// Sentinel errors
+	MsgInvalidCLLI     MessageID = "invalid_clli"
+	MsgInvalidPlace    MessageID = "invalid_place"
+	MsgInvalidRegion   MessageID = "invalid_region"
+	MsgInvalidSite     MessageID = "invalid_site"
+	MsgInvalidEntity   MessageID = "invalid_entity"
+	MsgInvalidLocation MessageID = "invalid_location"
+	MsgInvalidCustomer MessageID = "invalid_customer"
+	MsgEmptyInput      MessageID = "empty_input"
+	MsgInvalidOptions  MessageID = "invalid_options"
+
+	// Component validation details
+	MsgPlaceEmpty       MessageID = "place_empty"
+	MsgPlaceLength      MessageID = "place_length"
+	MsgPlaceCharacter   MessageID = "place_character"
+	MsgPlaceUnknown     MessageID = "place_unknown"
+	MsgRegionEmpty      MessageID = "region_empty"
+	MsgRegionLength     MessageID = "region_length"
+	MsgRegionCharacter  MessageID = "region_character"
+	MsgRegionUnknown    MessageID = "region_unknown"
+	MsgSiteEmpty        MessageID = "site_empty"
+	MsgSiteLength       MessageID = "site_length"
+	MsgSiteMixed        MessageID = "site_mixed"
+	MsgSiteCharacter    MessageID = "site_character"
+	MsgSiteBuilding     MessageID = "site_building"
+	MsgEntityEmpty      MessageID = "entity_empty"
+	MsgEntityLength     MessageID = "entity_length"
+	MsgEntityPattern    MessageID = "entity_pattern"
+	MsgLocationCode     MessageID = "location_code"
+	MsgLocationID       MessageID = "location_id"
+	MsgCustomerCode     MessageID = "customer_code"
+	MsgCustomerID       MessageID = "customer_id"
+	MsgCustomerTail     MessageID = "customer_tail"
+	MsgMustParseFailure MessageID = "must_parse_failure"
+	MsgBuilderConflict  MessageID = "builder_conflict"
+	MsgNoNetworkSite    MessageID = "no_network_site"
+	MsgNotPackable      MessageID = "not_packable"
+	MsgBinaryEncoding   MessageID = "binary_encoding"
+	MsgStructureLength  MessageID = "structure_length"
+
+	// Location ID assignment details
+	MsgLocationRange      MessageID = "location_range"
+	MsgLocationReserved   MessageID = "location_reserved"
+	MsgLocationUnassigned MessageID = "location_unassigned"
+
+	// Option validation details
+	MsgOptionsConflict    MessageID = "options_conflict"
+	MsgOptionsStrictAlias MessageID = "options_strict_alias"
+	MsgUnknownPreset      MessageID = "unknown_preset"
+	MsgOptionsType        MessageID = "options_type"
+	MsgOptionsValidator   MessageID = "options_validator"
+	MsgOptionsRequires    MessageID = "options_requires"
+
+	// Decoding and loading details
+	MsgScanNull         MessageID = "scan_null"
+	MsgScanType         MessageID = "scan_type"
+	MsgComponentKind    MessageID = "component_kind"
+	MsgSpanFormat       MessageID = "span_format"
+	MsgSpanEnds         MessageID = "span_ends"
+	MsgRecordColumn     MessageID = "record_column"
+	MsgPatternEmpty     MessageID = "pattern_empty"
+	MsgPatternCharacter MessageID = "pattern_character"
+	MsgDataset          MessageID = "dataset"
+	MsgDatasetColumn    MessageID = "dataset_column"
+	MsgDatasetLine      MessageID = "dataset_line"
+	MsgDatasetEntry     MessageID = "dataset_entry"
+	MsgDatasetSnapshot  MessageID = "dataset_snapshot"
+	MsgCoordinates      MessageID = "coordinates"
+	MsgCatalogLanguage  MessageID = "catalog_language"
+	MsgCatalogDefault   MessageID = "catalog_default"
+
+	// Entity table pattern details
+	MsgTableClass        MessageID = "table_class"
+	MsgTableLength       MessageID = "table_length"
+	MsgTableUnterminated MessageID = "table_unterminated"
+	MsgTableCharacter    MessageID = "table_character"
+	MsgTableRepetition   MessageID = "table_repetition"
+)
+
+// Catalog maps message IDs to fmt format strings for a single language.
+type Catalog map[MessageID]string
+
+// DefaultLanguage is the language used when a message has no translation.
+const DefaultLanguage = "en"
+
+// englishCatalog holds the canonical messages returned by Error methods.
+var englishCatalog = Catalog{
+	MsgParseError:       "parse error at position %d in field %s: %v",
+	MsgInvalidCLLI:      "invalid CLLI format",
+	MsgInvalidPlace:     "invalid place code",
+	MsgInvalidRegion:    "invalid region code",
+	MsgInvalidSite:      "invalid network site code",
+	MsgInvalidEntity:    "invalid entity code",
+	MsgInvalidLocation:  "invalid location code",
+	MsgInvalidCustomer:  "invalid customer code",
+	MsgEmptyInput:       "empty CLLI input",
+	MsgInvalidOptions:   "invalid parse options",
+	MsgPlaceEmpty:       "place code cannot be empty",
+	MsgPlaceLength:      "place code must be exactly 4 characters",
+	MsgPlaceCharacter:   "place code contains invalid character: %c",
+	MsgPlaceUnknown:     "place code %s is not known in region %s",
+	MsgRegionEmpty:      "region code cannot be empty",
+	MsgRegionLength:     "region code must be exactly 2 characters",
+	MsgRegionCharacter:  "region code contains invalid character: %c",
+	MsgRegionUnknown:    "invalid region code: %s",
+	MsgSiteEmpty:        "network site code cannot be empty",
+	MsgSiteLength:       "network site code must be exactly 2 characters",
+	MsgSiteMixed:        "network site code must be either all digits or all letters",
+	MsgSiteCharacter:    "network site code contains invalid character: %c",
+	MsgSiteBuilding:     "network site code %s must be 2 digits when no entity code follows",
+	MsgEntityEmpty:      "entity code cannot be empty",
+	MsgEntityLength:     "entity code must be exactly 3 characters",
+	MsgEntityPattern:    "invalid entity code pattern: %s",
+	MsgLocationCode:     "location code must be a single letter",
+	MsgLocationID:       "location ID must be exactly 4 digits",
+	MsgCustomerCode:     "customer code must be a single digit",
+	MsgCustomerID:       "customer ID must be a letter followed by 3 or 4 digits, or 6 digits",
+	MsgCustomerTail:     "customer ID %s must be a letter followed by 4 digits",
+	MsgBuilderConflict:  "%s cannot be combined with %s",
+	MsgNoNetworkSite:    "%s has no network site",
+	MsgNotPackable:      "%s cannot be packed into 8 bytes",
+	MsgBinaryEncoding:   "unknown binary encoding %d",
+	MsgStructureLength:  "no CLLI type has %d characters; codes have 8, 11, 12 or 15",
+	MsgMustParseFailure: "MustParse failed for input %q: %v",
+
+	MsgLocationRange:      "location ID %s is outside the range %04[3]d-%04[4]d of location code %[2]s",
+	MsgLocationReserved:   "location ID %s is reserved under location code %s",
+	MsgLocationUnassigned: "location code %s is not assigned",
+
+	MsgOptionsConflict:    "%s cannot be combined with %s",
+	MsgOptionsStrictAlias: "StrictValidation is set but Strict is not; set Strict instead",
+	MsgUnknownPreset:      "unknown option preset %q",
+	MsgOptionsType:        "TypePreference[%d] is %d, not a CLLI type",
+	MsgOptionsValidator:   "ExtraValidators[%d] is nil",
+	MsgOptionsRequires:    "%s requires %s",
+
+	MsgScanNull:         "cannot scan NULL into CLLI: %v",
+	MsgScanType:         "cannot scan %T into CLLI",
+	MsgComponentKind:    "unknown component kind %d",
+	MsgSpanFormat:       "span must be two CLLIs separated by '-'",
+	MsgSpanEnds:         "span requires both a and z ends",
+	MsgRecordColumn:     "missing required column",
+	MsgPatternEmpty:     "invalid pattern %q: empty",
+	MsgPatternCharacter: "invalid pattern %q: unexpected character %q",
+	MsgDataset:          "dataset %s: %v",
+	MsgDatasetColumn:    "dataset %s: missing %s column",
+	MsgDatasetLine:      "dataset %s: line %d: %v",
+	MsgDatasetEntry:     "dataset %s: entry %d: invalid coordinates",
+	MsgDatasetSnapshot:  "dataset %q has no snapshot %q",
+	MsgCoordinates:      "invalid coordinates %q, %q",
+	MsgCatalogLanguage:  "catalog language cannot be empty",
+	MsgCatalogDefault:   "the default catalog cannot be replaced",
+
+	MsgTableClass:        "bad character class [%s]",
+	MsgTableLength:       "pattern %q does not match three characters",
+	MsgTableUnterminated: "unterminated %c in %q",
+	MsgTableCharacter:    "unexpected %q in %q",
+	MsgTableRepetition:   "bad repetition in %q",
+}
+
+// frenchCatalog provides Canadian French translations for bilingual operator tools.
+var frenchCatalog = Catalog{
+	MsgParseError:       "erreur d'analyse à la position %d dans le champ %s : %v",
+	MsgInvalidCLLI:      "format CLLI invalide",
+	MsgInvalidPlace:     "code de lieu invalide",
+	MsgInvalidRegion:    "code de région invalide",
+	MsgInvalidSite:      "code de site réseau invalide",
+	MsgInvalidEntity:    "code d'entité invalide",
+	MsgInvalidLocation:  "code d'emplacement invalide",
+	MsgInvalidCustomer:  "code client invalide",
+	MsgEmptyInput:       "entrée CLLI vide",
+	MsgInvalidOptions:   "options d'analyse invalides",
+	MsgPlaceEmpty:       "le code de lieu ne peut pas être vide",
+	MsgPlaceLength:      "le code de lieu doit comporter exactement 4 caractères",
+	MsgPlaceCharacter:   "le code de lieu contient un caractère invalide : %c",
+	MsgPlaceUnknown:     "le code de lieu %s n'est pas connu dans la région %s",
+	MsgRegionEmpty:      "le code de région ne peut pas être vide",
+	MsgRegionLength:     "le code de région doit comporter exactement 2 caractères",
+	MsgRegionCharacter:  "le code de région contient un caractère invalide : %c",
+	MsgRegionUnknown:    "code de région invalide : %s",
+	MsgSiteEmpty:        "le code de site réseau ne peut pas être vide",
+	MsgSiteLength:       "le code de site réseau doit comporter exactement 2 caractères",
+	MsgSiteMixed:        "le code de site réseau doit être entièrement numérique ou entièrement alphabétique",
+	MsgSiteCharacter:    "le code de site réseau contient un caractère invalide : %c",
+	MsgSiteBuilding:     "le code de site réseau %s doit comporter 2 chiffres en l'absence de code d'entité",
+	MsgEntityEmpty:      "le code d'entité ne peut pas être vide",
+	MsgEntityLength:     "le code d'entité doit comporter exactement 3 caractères",
+	MsgEntityPattern:    "motif de code d'entité invalide : %s",
+	MsgLocationCode:     "le code d'emplacement doit être une seule lettre",
+	MsgLocationID:       "l'identifiant d'emplacement doit comporter exactement 4 chiffres",
+	MsgCustomerCode:     "le code client doit être un seul chiffre",
+	MsgCustomerID:       "l'identifiant client doit être une lettre suivie de 3 ou 4 chiffres, ou 6 chiffres",
+	MsgCustomerTail:     "l'identifiant client %s doit être une lettre suivie de 4 chiffres",
+	MsgBuilderConflict:  "%s ne peut pas être combiné avec %s",
+	MsgNoNetworkSite:    "%s n'a pas de site réseau",
+	MsgNotPackable:      "%s ne peut pas être compacté sur 8 octets",
+	MsgBinaryEncoding:   "encodage binaire inconnu %d",
+	MsgStructureLength:  "aucun type de CLLI ne comporte %d caractères ; les codes en comportent 8, 11, 12 ou 15",
+	MsgMustParseFailure: "échec de MustParse pour l'entrée %q : %v",
+
+	MsgLocationRange:      "l'identifiant d'emplacement %s est hors de la plage %04[3]d-%04[4]d du code d'emplacement %[2]s",
+	MsgLocationReserved:   "l'identifiant d'emplacement %s est réservé pour le code d'emplacement %s",
+	MsgLocationUnassigned: "le code d'emplacement %s n'est pas attribué",
+
+	MsgOptionsConflict:    "%s ne peut pas être combiné avec %s",
+	MsgOptionsStrictAlias: "StrictValidation est défini mais Strict ne l'est pas ; définissez Strict à la place",
+	MsgUnknownPreset:      "préréglage d'options inconnu %q",
+	MsgOptionsType:        "TypePreference[%d] vaut %d, qui n'est pas un type de CLLI",
+	MsgOptionsValidator:   "ExtraValidators[%d] est nil",
+	MsgOptionsRequires:    "%s exige %s",
+
+	MsgScanNull:         "impossible de lire NULL dans un CLLI : %v",
+	MsgScanType:         "impossible de lire %T dans un CLLI",
+	MsgComponentKind:    "type de composant inconnu %d",
+	MsgSpanFormat:       "un segment doit comporter deux CLLI séparés par « - »",
+	MsgSpanEnds:         "un segment exige les extrémités a et z",
+	MsgRecordColumn:     "colonne obligatoire manquante",
+	MsgPatternEmpty:     "motif %q invalide : vide",
+	MsgPatternCharacter: "motif %q invalide : caractère inattendu %q",
+	MsgDataset:          "jeu de données %s : %v",
+	MsgDatasetColumn:    "jeu de données %s : colonne %s manquante",
+	MsgDatasetLine:      "jeu de données %s : ligne %d : %v",
+	MsgDatasetEntry:     "jeu de données %s : entrée %d : coordonnées invalides",
+	MsgDatasetSnapshot:  "le jeu de données %q n'a pas d'instantané %q",
+	MsgCoordinates:      "coordonnées invalides %q, %q",
+	MsgCatalogLanguage:  "la langue du catalogue ne peut pas être vide",
+	MsgCatalogDefault:   "le catalogue par défaut ne peut pas être remplacé",
+
+	MsgTableClass:        "classe de caractères [%s] invalide",
+	MsgTableLength:       "le motif %q ne correspond pas à trois caractères",
+	MsgTableUnterminated: "%c non fermé dans %q",
+	MsgTableCharacter:    "%q inattendu dans %q",
+	MsgTableRepetition:   "répétition invalide dans %q",
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{
+		"en": englishCatalog,
+		"fr": frenchCatalog,
+	}
+)
+
+// RegisterCatalog adds or replaces the translations for a language.
+// Messages missing from the catalog fall back to DefaultLanguage.
+// The English catalog cannot be replaced, as it defines the Error strings.
+func RegisterCatalog(lang string, c Catalog) error {
+	if lang == "" {
+		return newMessageError(MsgCatalogLanguage)
+	}
+	if lang == DefaultLanguage {
+		return newMessageError(MsgCatalogDefault)
+	}
+
+	copied := make(Catalog, len(c))
+	for id, msg := range c {
+		copied[id] = msg
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[lang] = copied
+	return nil
+}
+
+// Languages returns the languages with a registered catalog.
+func Languages() []string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	return langs
+}
+
+// Message renders the message for id in the given language.
+// Unknown languages and untranslated messages fall back to DefaultLanguage.
+func Message(lang string, id MessageID, args ...any) string {
+	catalogsMu.RLock()
+	format, ok := catalogs[lang][id]
+	catalogsMu.RUnlock()
+
+	if !ok {
+		format, ok = englishCatalog[id]
+		if !ok {
+			return string(id)
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// MessageError is an error whose text comes from the message catalog.
+// Its ID is stable across releases and languages.
+type MessageError struct {
+	ID   MessageID // Catalog message identifier
+	Args []any     // Arguments substituted into the message
+}
+
+// newMessageError creates a MessageError for id with the given arguments.
+func newMessageError(id MessageID, args ...any) *MessageError {
+	return &MessageError{ID: id, Args: args}
+}
+
+// Error returns the message in DefaultLanguage.
+func (e *MessageError) Error() string {
+	return Message(DefaultLanguage, e.ID, e.Args...)
+}
+
+// Localize returns the message in the given language.
+func (e *MessageError) Localize(lang string) string {
+	return Message(lang, e.ID, e.Args...)
+}
+
+// Unwrap returns the arguments that are errors, so errors.Is and
+// errors.As see the causes a message reports.
+func (e *MessageError) Unwrap() []error {
+	var errs []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// sentinelIDs maps the package's sentinel errors to their message IDs.
+var sentinelIDs = map[error]MessageID{
+	ErrInvalidCLLI:     MsgInvalidCLLI,
+	ErrInvalidPlace:    MsgInvalidPlace,
+	ErrInvalidRegion:   MsgInvalidRegion,
+	ErrInvalidSite:     MsgInvalidSite,
+	ErrInvalidEntity:   MsgInvalidEntity,
+	ErrInvalidLocation: MsgInvalidLocation,
+	ErrInvalidCustomer: MsgInvalidCustomer,
+	ErrEmptyInput:      MsgEmptyInput,
+	ErrInvalidOptions:  MsgInvalidOptions,
+}
+
+// MessageIDOf returns the catalog ID describing err, or "" if err did not
+// originate from this package. For wrapped errors the outermost known ID is returned.
+func MessageIDOf(err error) MessageID {
+	if me, ok := err.(*MessageError); ok {
+		return me.ID
+	}
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return MessageIDOf(pe.Err)
+	}
+	var me *MessageError
+	if errors.As(err, &me) {
+		return me.ID
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if id, ok := sentinelIDs[e]; ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// Localize renders err in the given language, translating parse errors,
+// validation details and sentinel errors. Errors that did not originate
+// from this package are returned unchanged.
+func Localize(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+	if me, ok := err.(*MessageError); ok {
+		return me.Localize(lang)
+	}
+
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		if err == error(pe) {
+			return pe.Localize(lang)
+		}
+		return pe.Input + ": " + pe.Localize(lang)
+	}
+
+	var me *MessageError
+	if errors.As(err, &me) {
+		return me.Localize(lang)
+	}
+
+	if id := MessageIDOf(err); id != "" {
+		return Message(lang, id)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,75 @@
+package clli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessageCatalogs tests that every message is translated in the built-in catalogs
+func TestMessageCatalogs(t *testing.T) {
+	for id := range englishCatalog {
+		assert.Contains(t, frenchCatalog, id, "missing French translation for %s", id)
+	}
+	assert.Subset(t, Languages(), []string{"en", "fr"})
+}
+
+// TestLocalize tests rendering of package errors in different languages
+func TestLocalize(t *testing.T) {
+	_, err := Parse("CHCGZZ01DS0")
+	require.Error(t, err)
+
+	assert.Equal(t, err.Error(), Localize(err, "en"))
+	assert.Equal(t, "CHCGZZ01DS0: erreur d'analyse à la position 4 dans le champ region : code de région invalide",
+		Localize(err, "fr"))
+	assert.Equal(t, err.Error(), Localize(err, "xx"), "unknown languages fall back to English")
+	assert.Equal(t, MsgInvalidRegion, MessageIDOf(err))
+
+	t.Run("Validation details", func(t *testing.T) {
+		err := ValidateRegion("ZZ", true)
+		assert.Equal(t, "invalid region code: ZZ", err.Error())
+		assert.Equal(t, "code de région invalide : ZZ", Localize(err, "fr"))
+		assert.Equal(t, MsgRegionUnknown, MessageIDOf(err))
+	})
+
+	t.Run("Sentinel errors", func(t *testing.T) {
+		assert.Equal(t, "code d'entité invalide", Localize(ErrInvalidEntity, "fr"))
+		assert.Equal(t, MsgInvalidEntity, MessageIDOf(ErrInvalidEntity))
+	})
+
+	t.Run("Decoding and loading details", func(t *testing.T) {
+		var c CLLI
+		err := c.Scan(nil)
+		assert.ErrorIs(t, err, ErrEmptyInput)
+		assert.Equal(t, MsgScanNull, MessageIDOf(err))
+		assert.Equal(t, "impossible de lire NULL dans un CLLI : empty CLLI input", Localize(err, "fr"))
+
+		_, err = LoadDatasetCSV("csv", strings.NewReader("place,city\n"))
+		assert.Equal(t, MsgDatasetColumn, MessageIDOf(err))
+		assert.Equal(t, "jeu de données csv : colonne region manquante", Localize(err, "fr"))
+
+		_, err = CompileMatcher(" ")
+		assert.Equal(t, MsgPatternEmpty, MessageIDOf(err))
+	})
+
+	t.Run("Foreign errors", func(t *testing.T) {
+		other := errors.New("other")
+		assert.Equal(t, "other", Localize(other, "fr"))
+		assert.Equal(t, MessageID(""), MessageIDOf(other))
+		assert.Equal(t, "", Localize(nil, "fr"))
+	})
+}
+
+// TestRegisterCatalog tests adding a custom translation
+func TestRegisterCatalog(t *testing.T) {
+	require.NoError(t, RegisterCatalog("es", Catalog{MsgInvalidPlace: "código de lugar inválido"}))
+
+	assert.Equal(t, "código de lugar inválido", Localize(ErrInvalidPlace, "es"))
+	assert.Equal(t, "invalid region code", Localize(ErrInvalidRegion, "es"), "missing entries fall back to English")
+
+	assert.Error(t, RegisterCatalog("", Catalog{}))
+	assert.Error(t, RegisterCatalog(DefaultLanguage, Catalog{}))
+}
@@ -0,0 +1,184 @@
+package clli
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrorCode is a stable, machine-readable classification of parse failures.
+type ErrorCode int
+
+const (
+	// ErrCodeNone indicates a successful parse
+	ErrCodeNone ErrorCode = iota
+
+	// ErrCodeUnknown indicates an error that did not originate from the parser
+	ErrCodeUnknown
+
+	// ErrCodeEmpty indicates empty or whitespace-only input
+	ErrCodeEmpty
+
+	// ErrCodeLength indicates input outside the permitted length range
+	ErrCodeLength
+
+	// ErrCodeCharacters indicates characters outside A-Z and 0-9
+	ErrCodeCharacters
+
+	// ErrCodeBadPlace indicates an invalid place code
+	ErrCodeBadPlace
+
+	// ErrCodeBadRegion indicates an invalid or unknown region code
+	ErrCodeBadRegion
+
+	// ErrCodeBadSite indicates an invalid network site code
+	ErrCodeBadSite
+
+	// ErrCodeEntityPattern indicates an entity code that matches no Bell table pattern
+	ErrCodeEntityPattern
+
+	// ErrCodeRejected indicates a CLLI rejected by a post-parse check
+	ErrCodeRejected
+
+	// ErrCodeBadCustomer indicates an invalid customer code or identifier
+	ErrCodeBadCustomer
+
+	// ErrCodeBadLocation indicates a non-building location ID outside its assignment rules
+	ErrCodeBadLocation
+
+	// ErrCodeClassification indicates a remainder after the region that
+	// matches no CLLI type, or that a custom Classifier rejected
+	ErrCodeClassification
+)
+
+// String returns the string representation of the error code
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrCodeNone:
+		return "none"
+	case ErrCodeEmpty:
+		return "empty"
+	case ErrCodeLength:
+		return "length"
+	case ErrCodeCharacters:
+		return "characters"
+	case ErrCodeBadPlace:
+		return "bad_place"
+	case ErrCodeBadRegion:
+		return "bad_region"
+	case ErrCodeBadSite:
+		return "bad_site"
+	case ErrCodeEntityPattern:
+		return "entity_pattern"
+	case ErrCodeRejected:
+		return "rejected"
+	case ErrCodeBadCustomer:
+		return "bad_customer"
+	case ErrCodeBadLocation:
+		return "bad_location"
+	case ErrCodeClassification:
+		return "classification"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorCodeOf returns the ErrorCode describing err.
+// A nil error yields ErrCodeNone; errors not produced by the parser yield ErrCodeUnknown.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrCodeNone
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return ErrCodeUnknown
+	}
+	return pe.Code()
+}
+
+// Code returns the machine-readable classification of the failure, for API
+// layers mapping errors to stable responses without matching error text.
+func (e *ParseError) Code() ErrorCode {
+	switch e.Field {
+	case "input":
+		return ErrCodeEmpty
+	case "length":
+		return ErrCodeLength
+	case "characters":
+		return ErrCodeCharacters
+	case "place":
+		return ErrCodeBadPlace
+	case "region":
+		return ErrCodeBadRegion
+	case "network_site":
+		return ErrCodeBadSite
+	case "entity_code":
+		return ErrCodeEntityPattern
+	case "post_parse", "validator":
+		return ErrCodeRejected
+	case "customer_code", "customer_id":
+		return ErrCodeBadCustomer
+	case "location_id":
+		return ErrCodeBadLocation
+	case "classification":
+		return ErrCodeClassification
+	default:
+		return ErrCodeUnknown
+	}
+}
+
+// MetricsHook receives the outcome of every parse performed by a Parser.
+// Implementations must be safe for concurrent use and should return quickly,
+// as they run inline on the parsing goroutine.
+type MetricsHook interface {
+	// OnParse is called after each parse. result is nil when code is not ErrCodeNone.
+	OnParse(result *CLLI, code ErrorCode, duration time.Duration)
+}
+
+// MetricsHookFunc adapts an ordinary function to the MetricsHook interface.
+type MetricsHookFunc func(result *CLLI, code ErrorCode, duration time.Duration)
+
+// OnParse calls f(result, code, duration).
+func (f MetricsHookFunc) OnParse(result *CLLI, code ErrorCode, duration time.Duration) {
+	f(result, code, duration)
+}
+
+// metricsHookHolder wraps the package-level MetricsHook so it can be stored atomically.
+type metricsHookHolder struct {
+	MetricsHook
+}
+
+// metricsHook is the hook notified by the package-level parse functions.
+var metricsHook atomic.Pointer[metricsHookHolder]
+
+// SetMetricsHook installs the default hook, notified of every parse
+// performed by Parse, ParseWithOptions, ParseInto and the functions built
+// on them, and by Parsers without a hook of their own; prefer
+// Parser.SetMetricsHook where a process-wide hook is not wanted. If h also
+// implements CacheMetrics, as ExpvarMetrics does, it is notified of the
+// lookups of every ParseCache whose cache and Parser have no metrics of
+// their own. Passing nil removes it.
+func SetMetricsHook(h MetricsHook) {
+	if h == nil {
+		metricsHook.Store(nil)
+		return
+	}
+	metricsHook.Store(&metricsHookHolder{h})
+}
+
+// CurrentMetricsHook returns the hook installed with SetMetricsHook, or nil.
+func CurrentMetricsHook() MetricsHook {
+	if h := metricsHook.Load(); h != nil {
+		return h.MetricsHook
+	}
+	return nil
+}
+
+// observeParse runs parse and reports its outcome to h.
+func observeParse(h MetricsHook, parse func() (*CLLI, error)) (*CLLI, error) {
+	start := time.Now()
+	result, err := parse()
+	h.OnParse(result, ErrorCodeOf(err), time.Since(start))
+	return result, err
+}
@@ -0,0 +1,76 @@
+package clli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizeRules selects the cleanup applied by Normalize.
+type NormalizeRules struct {
+	// Uppercase converts letters to uppercase.
+	Uppercase bool
+
+	// TrimWhitespace removes leading and trailing whitespace.
+	TrimWhitespace bool
+
+	// StripSeparators removes the characters in Separators, so that
+	// "CHCG-IL-01-DS0" becomes "CHCGIL01DS0".
+	StripSeparators bool
+
+	// Separators lists the separator characters. Empty selects
+	// DefaultSeparators.
+	Separators string
+
+	// PadPlace pads a place code shorter than 4 characters with trailing
+	// spaces. The place is only known when the input separates it from the
+	// region, as in "RYE-NY-01-DS0"; unseparated input is left unpadded.
+	PadPlace bool
+}
+
+// DefaultSeparators are the separators removed by StripSeparators when
+// NormalizeRules.Separators is empty.
+const DefaultSeparators = "-._/:"
+
+// DefaultNormalizeRules returns rules enabling every cleanup step.
+func DefaultNormalizeRules() NormalizeRules {
+	return NormalizeRules{Uppercase: true, TrimWhitespace: true, StripSeparators: true, PadPlace: true}
+}
+
+// Normalize cleans up a CLLI exported with punctuation or inconsistent case
+// so it can be parsed, applying the given rules. The result is not
+// validated as a CLLI.
+//
+// Returns an error wrapping ErrEmptyInput if nothing remains, or
+// ErrInvalidCLLI if the result contains characters other than letters,
+// digits and place padding.
+func Normalize(input string, rules NormalizeRules) (string, error) {
+	s := input
+	if rules.TrimWhitespace {
+		s = strings.TrimSpace(s)
+	}
+	if rules.Uppercase {
+		s = strings.ToUpper(s)
+	}
+
+	if rules.StripSeparators {
+		seps := rules.Separators
+		if seps == "" {
+			seps = DefaultSeparators
+		}
+		fields := strings.FieldsFunc(s, func(r rune) bool { return strings.ContainsRune(seps, r) })
+		if rules.PadPlace && len(fields) > 1 && len(fields[0]) < 4 {
+			fields[0] += strings.Repeat(" ", 4-len(fields[0]))
+		}
+		s = strings.Join(fields, "")
+	}
+
+	if strings.TrimSpace(s) == "" {
+		return "", fmt.Errorf("%s: %w", input, &ParseError{Input: input, Field: "input", Err: ErrEmptyInput})
+	}
+	for i, r := range s {
+		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == ' ' && i < 4) {
+			return "", fmt.Errorf("%s: %w", input, &ParseError{Input: input, Position: i, Field: "characters", Err: ErrInvalidCLLI})
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,46 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalize tests cleanup rules for exported CLLIs
+func TestNormalize(t *testing.T) {
+	all := DefaultNormalizeRules()
+	tests := []struct {
+		name     string
+		input    string
+		rules    NormalizeRules
+		expected string
+		err      error
+	}{
+		{"hyphens", "CHCG-IL-01-DS0", all, "CHCGIL01DS0", nil},
+		{"dots and case", " chcg.il.01.ds0 ", all, "CHCGIL01DS0", nil},
+		{"mixed separators", "CHCG/IL:01_DS0", all, "CHCGIL01DS0", nil},
+		{"short place padded", "RYE-NY-01-DS0", all, "RYE NY01DS0", nil},
+		{"short place unpadded", "RYE-NY-01-DS0", NormalizeRules{StripSeparators: true}, "RYENY01DS0", nil},
+		{"custom separators", "CHCG|IL|01|DS0", NormalizeRules{StripSeparators: true, Separators: "|"}, "CHCGIL01DS0", nil},
+		{"case only", "chcgil01ds0", NormalizeRules{Uppercase: true}, "CHCGIL01DS0", nil},
+		{"separators kept", "CHCG-IL01DS0", NormalizeRules{Uppercase: true}, "", ErrInvalidCLLI},
+		{"empty", " -.- ", all, "", ErrEmptyInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input, tt.rules)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	normalized, err := Normalize("chcg-il-01-ds0", all)
+	assert.NoError(t, err)
+	c, err := Parse(normalized)
+	assert.NoError(t, err)
+	assert.Equal(t, "DS0", c.EntityCode)
+}
@@ -0,0 +1,143 @@
+package clli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validate checks the options for contradictory or out-of-range settings.
+// It reports every conflict found, wrapped in ErrInvalidOptions, so that
+// misconfigurations surface at Parser construction rather than as
+// surprising parse results at runtime. A nil receiver is valid and
+// selects the defaults.
+func (o *ParseOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if o.StrictValidation && !o.Strict {
+		errs = append(errs, newMessageError(MsgOptionsStrictAlias))
+	}
+
+	if o.Strict && o.AllowUnknownRegion {
+		errs = append(errs, newMessageError(MsgOptionsConflict, "Strict", "AllowUnknownRegion"))
+	}
+
+	if o.StrictStructure && !o.Strict {
+		errs = append(errs, newMessageError(MsgOptionsRequires, "StrictStructure", "Strict"))
+	}
+
+	if o.Regions != nil && o.AllowInternational {
+		errs = append(errs, newMessageError(MsgOptionsConflict, "Regions", "AllowInternational"))
+	}
+
+	if len(o.TypePreference) > 0 && o.Classifier != nil {
+		errs = append(errs, newMessageError(MsgOptionsConflict, "TypePreference", "Classifier"))
+	}
+
+	for i, t := range o.TypePreference {
+		if t < CLLITypeEntity || t > CLLITypeCustomer {
+			errs = append(errs, newMessageError(MsgOptionsType, i, int(t)))
+		}
+	}
+
+	for i, v := range o.ExtraValidators {
+		if v == nil {
+			errs = append(errs, newMessageError(MsgOptionsValidator, i))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidOptions, errors.Join(errs...))
+}
+
+// Preset names a predefined ParseOptions bundle, so teams can standardize on
+// a validation profile and reference it by name in configuration files and
+// on the command line.
+type Preset string
+
+const (
+	// PresetStrictTelcordia enforces the full specification: known regions only,
+	// strict structure, with case normalization and whitespace trimming.
+	PresetStrictTelcordia Preset = "StrictTelcordia"
+
+	// PresetLenient accepts short and legacy codes with unknown regions,
+	// for ingesting dirty data that is reviewed afterwards.
+	PresetLenient Preset = "Lenient"
+
+	// PresetLegacyBell validates strictly but, like the original Bell System
+	// records, requires codes to already be uppercase.
+	PresetLegacyBell Preset = "LegacyBell"
+)
+
+// presets holds the options for each named preset.
+var presets = map[Preset]ParseOptions{
+	PresetStrictTelcordia: {
+		Strict:           true,
+		StrictValidation: true,
+		StrictStructure:  true,
+		NormalizeCase:    true,
+		TrimWhitespace:   true,
+	},
+	PresetLenient: {
+		NormalizeCase:      true,
+		TrimWhitespace:     true,
+		AllowUnknownRegion: true,
+	},
+	PresetLegacyBell: {
+		Strict:         true,
+		TrimWhitespace: true,
+	},
+}
+
+// Presets returns the names of all predefined presets.
+func Presets() []Preset {
+	return []Preset{PresetStrictTelcordia, PresetLenient, PresetLegacyBell}
+}
+
+// LookupPreset returns the preset with the given name, ignoring case.
+// Returns an error wrapping ErrInvalidOptions if no preset has that name.
+func LookupPreset(name string) (Preset, error) {
+	for _, p := range Presets() {
+		if strings.EqualFold(string(p), name) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %w", ErrInvalidOptions, newMessageError(MsgUnknownPreset, name))
+}
+
+// Options returns a fresh copy of the preset's options, or nil for an unknown preset.
+// The copy may be modified without affecting the preset.
+func (p Preset) Options() *ParseOptions {
+	opts, ok := presets[p]
+	if !ok {
+		return nil
+	}
+	return &opts
+}
+
+// String returns the preset name.
+func (p Preset) String() string {
+	return string(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting preset names
+// case-insensitively and rejecting unknown names.
+func (p *Preset) UnmarshalText(text []byte) error {
+	found, err := LookupPreset(string(text))
+	if err != nil {
+		return err
+	}
+	*p = found
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Preset) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
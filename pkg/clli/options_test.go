@@ -0,0 +1,75 @@
+package clli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPresets tests that every preset is valid and usable
+func TestPresets(t *testing.T) {
+	for _, preset := range Presets() {
+		t.Run(preset.String(), func(t *testing.T) {
+			opts := preset.Options()
+			require.NotNil(t, opts)
+			assert.NoError(t, opts.Validate())
+
+			p, err := NewParser(opts)
+			require.NoError(t, err)
+			c, err := p.Parse("CHCGIL01DS0")
+			require.NoError(t, err)
+			assert.Equal(t, CLLITypeEntity, c.Type())
+		})
+	}
+}
+
+// TestPresetBehavior tests the distinguishing behavior of each preset
+func TestPresetBehavior(t *testing.T) {
+	_, err := ParseWithOptions("CHCGZZ01DS0", PresetStrictTelcordia.Options())
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+
+	_, err = ParseWithOptions("CHCGZZ01DS0", PresetLenient.Options())
+	assert.NoError(t, err)
+
+	_, err = ParseWithOptions("chcgil01ds0", PresetLegacyBell.Options())
+	assert.Error(t, err)
+	_, err = ParseWithOptions("chcgil01ds0", PresetStrictTelcordia.Options())
+	assert.NoError(t, err)
+}
+
+// TestPresetOptionsAreCopies tests that modifying returned options does not alter the preset
+func TestPresetOptionsAreCopies(t *testing.T) {
+	opts := PresetLenient.Options()
+	opts.Strict = true
+	assert.False(t, PresetLenient.Options().Strict)
+	assert.Nil(t, Preset("Nope").Options())
+}
+
+// TestLookupPreset tests preset lookup by name
+func TestLookupPreset(t *testing.T) {
+	p, err := LookupPreset("stricttelcordia")
+	require.NoError(t, err)
+	assert.Equal(t, PresetStrictTelcordia, p)
+
+	_, err = LookupPreset("Paranoid")
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+	assert.Contains(t, err.Error(), `unknown option preset "Paranoid"`)
+}
+
+// TestPresetText tests presets embedded in configuration
+func TestPresetText(t *testing.T) {
+	var cfg struct {
+		Profile Preset `json:"profile"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"profile":"lenient"}`), &cfg))
+	assert.Equal(t, PresetLenient, cfg.Profile)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"profile":"bogus"}`), &cfg))
+
+	out, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profile":"Lenient"}`, string(out))
+}
@@ -0,0 +1,130 @@
+package clli
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// CacheMetrics receives the outcome of every ParseCache lookup.
+// ExpvarMetrics implements it.
+type CacheMetrics interface {
+	OnCacheLookup(hit bool)
+}
+
+var _ CacheMetrics = (*ExpvarMetrics)(nil)
+
+// ParseCache memoizes the results of a Parser for repeated inputs, for
+// telemetry streams that carry the same few thousand distinct CLLIs
+// millions of times. It holds a bounded number of inputs and evicts the
+// least recently used. Failures are cached as well as successes.
+// A ParseCache is safe for concurrent use.
+type ParseCache struct {
+	parser  *Parser
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Front is most recently used
+	metrics CacheMetrics
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+// parseEntry is the cached result of parsing one input.
+type parseEntry struct {
+	input string
+	c     *CLLI
+	err   error
+}
+
+// NewParseCache creates a cache of at most size inputs in front of p.
+// A nil p parses with the same defaults as Parse; a size of zero or less
+// selects 4096. The Parser's metrics hook only sees cache misses.
+func NewParseCache(size int, p *Parser) *ParseCache {
+	if p == nil {
+		p = MustNewParser(nil)
+	}
+	if size <= 0 {
+		size = 4096
+	}
+	return &ParseCache{
+		parser:  p,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// SetMetrics installs a hook notified of every lookup, such as an
+// ExpvarMetrics, in place of the metrics hook of the cache's Parser or
+// the package-level metrics hook. Passing nil removes it. It must be
+// called before the cache is shared between goroutines.
+func (pc *ParseCache) SetMetrics(m CacheMetrics) {
+	pc.metrics = m
+}
+
+// Parse returns the result of parsing input with the cache's Parser,
+// parsing it only on the first request or after it has been evicted. Each
+// call returns a fresh copy of the CLLI, so callers may modify it.
+func (pc *ParseCache) Parse(input string) (*CLLI, error) {
+	pc.mu.Lock()
+	el, hit := pc.entries[input]
+	if hit {
+		pc.order.MoveToFront(el)
+	}
+	pc.mu.Unlock()
+
+	var e *parseEntry
+	if hit {
+		e = el.Value.(*parseEntry)
+		pc.hits.Add(1)
+	} else {
+		c, err := pc.parser.Parse(input)
+		e = &parseEntry{input: input, c: c, err: err}
+		pc.store(e)
+		pc.misses.Add(1)
+	}
+	metrics := pc.metrics
+	if metrics == nil {
+		metrics, _ = pc.parser.metricsHook().(CacheMetrics)
+	}
+	if metrics != nil {
+		metrics.OnCacheLookup(hit)
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+	c := *e.c
+	return &c, nil
+}
+
+// store adds e, evicting the least recently used entry if the cache is
+// full. An entry stored concurrently for the same input is kept.
+func (pc *ParseCache) store(e *parseEntry) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if _, ok := pc.entries[e.input]; ok {
+		return
+	}
+	pc.entries[e.input] = pc.order.PushFront(e)
+	if pc.order.Len() > pc.size {
+		oldest := pc.order.Back()
+		pc.order.Remove(oldest)
+		delete(pc.entries, oldest.Value.(*parseEntry).input)
+	}
+}
+
+// Len returns the number of cached inputs.
+func (pc *ParseCache) Len() int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.order.Len()
+}
+
+// Stats returns the number of lookups answered from the cache and the
+// number that required a parse.
+func (pc *ParseCache) Stats() (hits, misses uint64) {
+	return pc.hits.Load(), pc.misses.Load()
+}
@@ -0,0 +1,71 @@
+package clli
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseCache tests memoization, eviction and counters
+func TestParseCache(t *testing.T) {
+	var parses int
+	p := MustNewParser(nil)
+	p.SetMetricsHook(MetricsHookFunc(func(*CLLI, ErrorCode, time.Duration) { parses++ }))
+	pc := NewParseCache(2, p)
+	m := NewExpvarMetrics()
+	pc.SetMetrics(m)
+
+	c, err := pc.Parse("CHCGIL01DS0")
+	require.NoError(t, err)
+	c.EntityCode = "MG1" // Callers get their own copy
+
+	c, err = pc.Parse("CHCGIL01DS0")
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL01DS0", c.Format())
+	assert.Equal(t, 1, parses)
+
+	_, err = pc.Parse("CHCGZZ01DS0")
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+	_, err = pc.Parse("CHCGZZ01DS0")
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+	assert.Equal(t, 2, parses)
+
+	// CHCGIL01DS0 is the least recently used and is evicted
+	_, err = pc.Parse("TOROON01DS0")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pc.Len())
+	_, _ = pc.Parse("CHCGIL01DS0")
+	assert.Equal(t, 4, parses)
+
+	hits, misses := pc.Stats()
+	assert.Equal(t, uint64(2), hits)
+	assert.Equal(t, uint64(4), misses)
+	assert.JSONEq(t, `2`, m.Map().Get("cache_hits").String())
+	assert.JSONEq(t, `4`, m.Map().Get("cache_misses").String())
+}
+
+// TestParseCacheConcurrent tests concurrent lookups of shared inputs
+func TestParseCacheConcurrent(t *testing.T) {
+	pc := NewParseCache(8, nil)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				c, err := pc.Parse(fmt.Sprintf("CHCGIL%02dDS0", (i+j)%16))
+				assert.NoError(t, err)
+				assert.Equal(t, "CHCG", c.Place)
+			}
+		}()
+	}
+	wg.Wait()
+
+	hits, misses := pc.Stats()
+	assert.Equal(t, uint64(800), hits+misses)
+	assert.LessOrEqual(t, pc.Len(), 8)
+}
@@ -0,0 +1,129 @@
+package clli
+
+import "fmt"
+
+// Parser parses CLLI codes with a fixed set of options and optional hooks.
+// Configure a Parser before sharing it; once in use it is safe for concurrent use.
+type Parser struct {
+	opts      ParseOptions
+	metrics   MetricsHook
+	redaction *RedactionPolicy
+	pre       []func(string) string
+	post      []func(*CLLI) error
+}
+
+// NewParser creates a Parser that applies the given options to every parse.
+// A nil opts selects the same defaults as Parse.
+//
+// Returns an error wrapping ErrInvalidOptions if the options are contradictory.
+func NewParser(opts *ParseOptions) (*Parser, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Parser{}
+	if opts == nil {
+		p.opts = ParseOptions{
+			Strict:         true,
+			NormalizeCase:  true,
+			TrimWhitespace: true,
+		}
+	} else {
+		p.opts = *opts
+	}
+	return p, nil
+}
+
+// MustNewParser creates a Parser, panicking if the options are invalid.
+// Use this function only with options known to be valid, typically
+// package-level configuration or one of the named presets.
+func MustNewParser(opts *ParseOptions) *Parser {
+	p, err := NewParser(opts)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// SetMetricsHook installs a hook notified of every parse outcome, in place
+// of the package-level hook installed with the SetMetricsHook function.
+// If h also implements CacheMetrics, it is notified of the lookups of
+// every ParseCache in front of p without metrics of its own. Passing nil
+// removes any previously installed hook, after which the package-level
+// hook applies again.
+func (p *Parser) SetMetricsHook(h MetricsHook) {
+	p.metrics = h
+}
+
+// metricsHook returns the hook installed with SetMetricsHook, or else the
+// package-level hook.
+func (p *Parser) metricsHook() MetricsHook {
+	if p.metrics != nil {
+		return p.metrics
+	}
+	return CurrentMetricsHook()
+}
+
+// SetRedactionPolicy sets the policy applied by String, LogValue and
+// LogAttrs to the CLLIs this Parser parses, in place of the package-level
+// policy set with the SetRedactionPolicy function.
+func (p *Parser) SetRedactionPolicy(rp RedactionPolicy) {
+	rp.Components = append([]ComponentKind(nil), rp.Components...)
+	p.redaction = &rp
+}
+
+// Options returns a copy of the options used by this Parser.
+func (p *Parser) Options() ParseOptions {
+	return p.opts
+}
+
+// PreNormalize registers a function applied to the raw input before parsing,
+// for custom cleanup such as stripping known suffixes or mapping legacy aliases.
+// Functions run in registration order, before case and whitespace normalization.
+func (p *Parser) PreNormalize(fn func(string) string) {
+	p.pre = append(p.pre, fn)
+}
+
+// PostParse registers a check run on every successfully parsed CLLI.
+// A non-nil error rejects the CLLI; it is returned wrapped in a ParseError
+// with field "post_parse". Checks run in registration order.
+func (p *Parser) PostParse(fn func(*CLLI) error) {
+	p.post = append(p.post, fn)
+}
+
+// Parse parses a CLLI string using the Parser's options and hooks.
+// Returns a parsed CLLI struct or an error if the input is invalid.
+func (p *Parser) Parse(clli string) (*CLLI, error) {
+	h := p.metricsHook()
+	if h == nil {
+		return p.parse(clli)
+	}
+	return observeParse(h, func() (*CLLI, error) { return p.parse(clli) })
+}
+
+// parse applies the pre-normalization hooks, parses, and runs the post-parse checks.
+func (p *Parser) parse(clli string) (*CLLI, error) {
+	input := clli
+	for _, fn := range p.pre {
+		input = fn(input)
+	}
+
+	result, err := parseInput(input, &p.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range p.post {
+		if err := fn(result); err != nil {
+			return nil, fmt.Errorf("%s: %w", input, &ParseError{
+				Input:    input,
+				Position: 0,
+				Field:    "post_parse",
+				Err:      err,
+			})
+		}
+	}
+
+	result.redaction = p.redaction
+	return result, nil
+}
@@ -0,0 +1,413 @@
+package clli
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParser tests the reusable Parser type
+func TestParser(t *testing.T) {
+	t.Run("Default options", func(t *testing.T) {
+		p := MustNewParser(nil)
+		c, err := p.Parse("  chcgil01ds0 ")
+		require.NoError(t, err)
+		assert.Equal(t, "CHCGIL01DS0", c.Original)
+		assert.True(t, p.Options().Strict)
+	})
+
+	t.Run("Custom options are copied", func(t *testing.T) {
+		opts := &ParseOptions{Strict: false}
+		p := MustNewParser(opts)
+		opts.Strict = true
+		assert.False(t, p.Options().Strict)
+	})
+}
+
+// TestParserMetricsHook tests that the metrics hook observes every parse
+func TestParserMetricsHook(t *testing.T) {
+	var codes []ErrorCode
+	var types []CLLIType
+
+	p := MustNewParser(nil)
+	p.SetMetricsHook(MetricsHookFunc(func(result *CLLI, code ErrorCode, d time.Duration) {
+		codes = append(codes, code)
+		if result != nil {
+			types = append(types, result.Type())
+		}
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+	}))
+
+	_, _ = p.Parse("CHCGIL01DS0")
+	_, _ = p.Parse("CHCGZZ01DS0")
+	_, _ = p.Parse("")
+
+	assert.Equal(t, []ErrorCode{ErrCodeNone, ErrCodeBadRegion, ErrCodeEmpty}, codes)
+	assert.Equal(t, []CLLIType{CLLITypeEntity}, types)
+
+	p.SetMetricsHook(nil)
+	_, _ = p.Parse("CHCGIL01DS0")
+	assert.Len(t, codes, 3)
+}
+
+// TestSetMetricsHook tests the package-level metrics hook
+func TestSetMetricsHook(t *testing.T) {
+	m := NewExpvarMetrics()
+	SetMetricsHook(m)
+	defer SetMetricsHook(nil)
+	assert.Same(t, m, CurrentMetricsHook())
+
+	_, _ = Parse("CHCGIL01DS0")
+	_, _ = Parse("CHCGZZ01DS0")
+	_, _ = ParseWithOptions("chcgil01ds0", nil)
+	var c CLLI
+	_ = ParseInto("CHCGIL01DS0", &c)
+
+	// Parsers with their own hook do not report to the package hook
+	own := NewExpvarMetrics()
+	p := MustNewParser(nil)
+	p.SetMetricsHook(own)
+	_, _ = p.Parse("CHCGIL01DS0")
+	p.SetMetricsHook(nil)
+	_, _ = p.Parse("CH1GIL01DS0")
+
+	pc := NewParseCache(0, nil)
+	_, _ = pc.Parse("CHCGIL01DS0")
+	_, _ = pc.Parse("CHCGIL01DS0")
+
+	var vars struct {
+		Parses         int64            `json:"parses"`
+		FailuresByCode map[string]int64 `json:"failures_by_code"`
+		CacheHits      int64            `json:"cache_hits"`
+		CacheMisses    int64            `json:"cache_misses"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(m.Map().String()), &vars))
+	assert.Equal(t, int64(6), vars.Parses)
+	assert.Equal(t, map[string]int64{"bad_region": 1, "bad_place": 1}, vars.FailuresByCode)
+	assert.Equal(t, int64(1), vars.CacheHits)
+	assert.Equal(t, int64(1), vars.CacheMisses)
+	assert.Equal(t, "1", own.Map().Get("parses").String())
+
+	SetMetricsHook(nil)
+	assert.Nil(t, CurrentMetricsHook())
+	_, _ = Parse("CHCGIL01DS0")
+	assert.Equal(t, "6", m.Map().Get("parses").String())
+}
+
+// TestErrorCodeOf tests mapping of errors to error codes
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ErrorCode
+	}{
+		{"CHCGIL01DS0", ErrCodeNone},
+		{"   ", ErrCodeEmpty},
+		{"CHCG", ErrCodeLength},
+		{"CHCG-IL01DS0", ErrCodeCharacters},
+		{"CH1GIL01DS0", ErrCodeBadPlace},
+		{"CHCGZZ01DS0", ErrCodeBadRegion},
+		{"CHCGIL01QQQ", ErrCodeEntityPattern},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			assert.Equal(t, tt.expected, ErrorCodeOf(err))
+
+			var pe *ParseError
+			if errors.As(err, &pe) {
+				assert.Equal(t, tt.expected, pe.Code())
+			}
+		})
+	}
+
+	assert.Equal(t, ErrCodeUnknown, ErrorCodeOf(errors.New("other")))
+	assert.Equal(t, ErrCodeClassification, (&ParseError{Field: "classification"}).Code())
+	assert.Equal(t, "classification", ErrCodeClassification.String())
+	assert.Equal(t, "bad_region", ErrCodeBadRegion.String())
+}
+
+// TestParseOptionsValidate tests detection of contradictory options
+func TestParseOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *ParseOptions
+		wantErr bool
+	}{
+		{"Nil options", nil, false},
+		{"Defaults", &ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true}, false},
+		{"Strict alias agrees", &ParseOptions{Strict: true, StrictValidation: true}, false},
+		{"Strict alias disagrees", &ParseOptions{StrictValidation: true}, true},
+		{"Lenient unknown regions", &ParseOptions{AllowUnknownRegion: true}, false},
+		{"Strict unknown regions", &ParseOptions{Strict: true, AllowUnknownRegion: true}, true},
+		{"Nil validator", &ParseOptions{ExtraValidators: []Validator{nil}}, true},
+		{"Type preference", &ParseOptions{TypePreference: []CLLIType{CLLITypeCustomer, CLLITypeEntity}}, false},
+		{"Unknown type preference", &ParseOptions{TypePreference: []CLLIType{CLLITypeEntity, CLLITypeUnknown}}, true},
+		{"Out of range type preference", &ParseOptions{TypePreference: []CLLIType{CLLITypeCustomer + 1}}, true},
+		{"Strict structure", &ParseOptions{Strict: true, StrictStructure: true}, false},
+		{"Lenient strict structure", &ParseOptions{StrictStructure: true}, true},
+		{"Classifier", &ParseOptions{Classifier: DefaultClassifier}, false},
+		{"Type preference with classifier", &ParseOptions{Classifier: DefaultClassifier, TypePreference: []CLLIType{CLLITypeEntity}}, true},
+		{"Region registry", &ParseOptions{Regions: &RegionRegistry{}}, false},
+		{"Region registry with international", &ParseOptions{Regions: &RegionRegistry{}, AllowInternational: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidOptions)
+				_, err := NewParser(tt.opts)
+				assert.ErrorIs(t, err, ErrInvalidOptions)
+				assert.Panics(t, func() { MustNewParser(tt.opts) })
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Error message names the conflict", func(t *testing.T) {
+		err := (&ParseOptions{Strict: true, AllowUnknownRegion: true}).Validate()
+		require.Error(t, err)
+		assert.Equal(t, "invalid parse options: Strict cannot be combined with AllowUnknownRegion", err.Error())
+
+		err = (&ParseOptions{TypePreference: []CLLIType{CLLITypeEntity, 7}}).Validate()
+		require.Error(t, err)
+		assert.Equal(t, "invalid parse options: TypePreference[1] is 7, not a CLLI type", err.Error())
+
+		err = (&ParseOptions{StrictStructure: true}).Validate()
+		require.Error(t, err)
+		assert.Equal(t, "invalid parse options: StrictStructure requires Strict", err.Error())
+	})
+}
+
+// TestAllowUnknownRegion tests acceptance of unknown regions in lenient mode
+func TestAllowUnknownRegion(t *testing.T) {
+	c, err := ParseWithOptions("CHCGZZ01DS0", &ParseOptions{AllowUnknownRegion: true})
+	require.NoError(t, err)
+	assert.Equal(t, "ZZ", c.Region)
+	assert.Equal(t, "", c.CountryCode())
+
+	_, err = ParseWithOptions("CHCGZZ01DS0", &ParseOptions{Strict: true, AllowUnknownRegion: true})
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+}
+
+// TestRequireKnownPlace tests rejection of places missing from the reference dataset
+func TestRequireKnownPlace(t *testing.T) {
+	d, err := LoadDatasetCSV("reference", strings.NewReader("place,region,locality\nRYEB,NY,Rye Brook\nCHCG,IL,Chicago\n"))
+	require.NoError(t, err)
+	opts := &ParseOptions{Strict: true, NormalizeCase: true, RequireKnownPlace: true, PlaceReference: NewResolver(d)}
+
+	c, err := ParseWithOptions("ryebny01ds0", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "RYEB", c.Place)
+
+	_, err = ParseWithOptions("QQQQNY01DS0", opts)
+	assert.ErrorIs(t, err, ErrInvalidPlace)
+	assert.ErrorContains(t, err, "place code QQQQ is not known in region NY")
+	assert.Equal(t, ErrCodeBadPlace, ErrorCodeOf(err))
+
+	// The place must exist in the region given
+	_, err = ParseWithOptions("RYEBIL01DS0", opts)
+	assert.ErrorIs(t, err, ErrInvalidPlace)
+
+	// Lenient parsing flags the place instead
+	opts.Strict = false
+	c, err = ParseWithOptions("QQQQNY01DS0", opts)
+	require.NoError(t, err)
+	assert.Equal(t, []ComponentKind{ComponentPlace}, c.InvalidFields())
+
+	// Nil PlaceReference selects DefaultResolver
+	_, err = ParseWithOptions("CHCGIL01DS0", &ParseOptions{Strict: true, RequireKnownPlace: true})
+	assert.NoError(t, err)
+	_, err = ParseWithOptions("QQQQIL01DS0", &ParseOptions{Strict: true, RequireKnownPlace: true})
+	assert.ErrorIs(t, err, ErrInvalidPlace)
+}
+
+// TestLenientParsing tests that non-strict parsing accepts nonconforming
+// components and flags them instead of rejecting the code
+func TestLenientParsing(t *testing.T) {
+	lenient := &ParseOptions{NormalizeCase: true, TrimWhitespace: true}
+	tests := []struct {
+		input   string
+		invalid []ComponentKind
+	}{
+		{"CHCGIL01DS0", nil},
+		{"CHCGZZ01DS0", []ComponentKind{ComponentRegion}},
+		{"RYE NY01DS0", []ComponentKind{ComponentPlace}},
+		{"CHCGIL01QQQ", []ComponentKind{ComponentEntityCode}},
+		{"RYE ZZ01QQQ", []ComponentKind{ComponentPlace, ComponentRegion, ComponentEntityCode}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			assert.Equal(t, tt.invalid != nil, err != nil)
+
+			c, err := ParseWithOptions(tt.input, lenient)
+			require.NoError(t, err)
+			assert.Equal(t, tt.invalid, c.InvalidFields())
+			assert.Equal(t, tt.invalid == nil, c.IsValid())
+			for _, kind := range tt.invalid {
+				assert.False(t, c.FieldValid(kind))
+			}
+		})
+	}
+
+	c, err := ParseWithOptions("RYE NY01DS0", lenient)
+	require.NoError(t, err)
+	assert.Equal(t, "RYE", c.Place)
+	assert.Equal(t, "RYE NY01DS0", c.Canonical())
+
+	// Components that are not even well-formed are still rejected
+	for _, input := range []string{"CHC1IL01DS0", "CHCG1L01DS0", "R YENY01DS0", "CHCGIL01D-0"} {
+		_, err := ParseWithOptions(input, lenient)
+		assert.Error(t, err, input)
+	}
+
+	// AllowUnknownRegion accepts unknown regions as valid
+	c, err = ParseWithOptions("CHCGZZ01DS0", &ParseOptions{AllowUnknownRegion: true})
+	require.NoError(t, err)
+	assert.True(t, c.IsValid())
+}
+
+// TestParserHooks tests pre-normalization and post-parse hooks
+func TestParserHooks(t *testing.T) {
+	p := MustNewParser(nil)
+	p.PreNormalize(func(s string) string {
+		return strings.TrimSuffix(s, "/LEGACY")
+	})
+	p.PreNormalize(func(s string) string {
+		if s == "OLDCHI" {
+			return "CHCGIL01DS0"
+		}
+		return s
+	})
+
+	errNotILEC := errors.New("network site reserved for ILEC buildings")
+	p.PostParse(func(c *CLLI) error {
+		if c.NetworkSite == "99" {
+			return errNotILEC
+		}
+		return nil
+	})
+
+	c, err := p.Parse("chcgil01ds0/LEGACY")
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL01DS0", c.Original)
+
+	c, err = p.Parse("OLDCHI")
+	require.NoError(t, err)
+	assert.Equal(t, "CHCG", c.Place)
+
+	c, err = p.Parse("CHCGIL99DS0")
+	assert.Nil(t, c)
+	assert.ErrorIs(t, err, errNotILEC)
+	assert.Equal(t, ErrCodeRejected, ErrorCodeOf(err))
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "post_parse", pe.Field)
+}
+
+// TestExtraValidators tests that company-specific rules reject parsed codes
+func TestExtraValidators(t *testing.T) {
+	errSiteRange := errors.New("network site must be 01-49 for ILEC buildings")
+	opts := &ParseOptions{
+		Strict:         true,
+		NormalizeCase:  true,
+		TrimWhitespace: true,
+		ExtraValidators: []Validator{
+			ValidatorFunc(func(c *CLLI) error {
+				if c.NetworkSite > "49" {
+					return errSiteRange
+				}
+				return nil
+			}),
+		},
+	}
+
+	c, err := ParseWithOptions("chcgil01", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "01", c.NetworkSite)
+
+	c, err = ParseWithOptions(" CHCGIL52 ", opts)
+	assert.Nil(t, c)
+	assert.ErrorIs(t, err, errSiteRange)
+	assert.Equal(t, ErrCodeRejected, ErrorCodeOf(err))
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "validator", pe.Field)
+	assert.Equal(t, 1, pe.Start)
+	assert.Equal(t, 9, pe.End)
+
+	p, err := NewParser(opts)
+	require.NoError(t, err)
+	_, err = p.Parse("CHCGIL52")
+	assert.ErrorIs(t, err, errSiteRange)
+
+	// Nil validators are rejected by Validate and skipped by parsing
+	nilOpts := &ParseOptions{ExtraValidators: []Validator{nil}}
+	assert.ErrorIs(t, nilOpts.Validate(), ErrInvalidOptions)
+	_, err = ParseWithOptions("CHCGIL01", nilOpts)
+	assert.NoError(t, err)
+}
+
+// TestStrictStructure tests enforcing the length and type matrix
+func TestStrictStructure(t *testing.T) {
+	for _, strict := range []bool{true, false} {
+		opts := &ParseOptions{Strict: strict, StrictStructure: true, NormalizeCase: true, TrimWhitespace: true}
+
+		for _, input := range []string{"MPLSMN01", "MPLSMN01DS0", "MPLSMNMSDS1", "MPLSMNB1234", "MPLSMN1A234", "MPLSMN1A2345", "MPLSMN011234567"} {
+			_, err := ParseWithOptions(input, opts)
+			assert.NoError(t, err, input)
+		}
+
+		tests := []struct {
+			input string
+			field string
+			err   error
+		}{
+			{"MPLSMNMS", "network_site", ErrInvalidSite},
+			{"MPLSMNA1", "network_site", ErrInvalidSite},
+			{"MPLSMN01DS", "length", ErrInvalidCLLI},
+			{"MPLS", "length", ErrInvalidCLLI},
+			{"CHCGILB12345", "location_id", ErrInvalidLocation},
+			{"CHCGIL1A23456", "length", ErrInvalidCLLI},
+			{"CHCGIL12A345678", "customer_code", ErrInvalidCustomer},
+			{"MPLSMN01123456A", "customer_id", ErrInvalidCustomer},
+		}
+		for _, tt := range tests {
+			_, err := ParseWithOptions(tt.input, opts)
+			assert.ErrorIs(t, err, tt.err, tt.input)
+			var pe *ParseError
+			require.ErrorAs(t, err, &pe, tt.input)
+			assert.Equal(t, tt.field, pe.Field, tt.input)
+		}
+	}
+
+	_, err := ParseWithOptions("MPLSMNMS", &ParseOptions{Strict: true, StrictStructure: true})
+	assert.EqualError(t, err, "MPLSMNMS: parse error at position 6 in field network_site: invalid network site code: network site code MS must be 2 digits when no entity code follows")
+	_, err = ParseWithOptions("CHCGIL12A345678", &ParseOptions{StrictStructure: true})
+	assert.EqualError(t, err, "CHCGIL12A345678: parse error at position 8 in field customer_code: invalid customer code: customer code must be a single digit")
+
+	// Tails the default classifier accepts in other shapes are rejected
+	for _, input := range []string{"CHCGILB12345", "CHCGIL12A345678", "MPLSMN01123456A"} {
+		_, err := Parse(input)
+		require.NoError(t, err, input)
+	}
+
+	// Mixed entity sites are rejected where lenient parsing would accept them
+	opts := &ParseOptions{NormalizeCase: true, TypePreference: []CLLIType{CLLITypeEntity}}
+	_, err = ParseWithOptions("CHCGILA1012", opts)
+	require.NoError(t, err)
+	opts.StrictStructure = true
+	c, err := ParseWithOptions("CHCGILA1012", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CLLITypeNonBuilding, c.Type())
+}
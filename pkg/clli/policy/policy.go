@@ -0,0 +1,259 @@
+// Package policy evaluates CLLI codes against assignment standards stricter
+// than the Bell System specification, such as the internal rules of a
+// carrier, and reports every violation found:
+//
+//	p, err := policy.LoadFile("/etc/clli/policy.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	for _, v := range p.Evaluate(c) {
+//		fmt.Println(v)
+//	}
+//
+// Rules are declared in Go, with the rule constructors or a custom Rule,
+// or loaded from a YAML document:
+//
+//	name: acme
+//	regions: [IL, IN, WI]
+//	reserved_entities: [ZZZ, 9ZZ]
+//	entity_tables:
+//	  - sites: numeric
+//	    tables: [B, C]
+//	  - sites: alpha
+//	    tables: [E]
+//
+// A policy also plugs into parsing through clli.ParseOptions.ExtraValidators,
+// using Policy.Validator.
+//
+// policy is a module of its own, so importing the core clli package does not
+// pull in the YAML decoder.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// Errors wrapped by the violations of the built-in rules.
+var (
+	ErrRegionNotAllowed = errors.New("policy: region not allowed")
+	ErrReservedEntity   = errors.New("policy: entity code reserved")
+	ErrEntityTable      = errors.New("policy: entity table not allowed")
+)
+
+// ErrInvalidPolicy is returned for policy documents that cannot be loaded.
+var ErrInvalidPolicy = errors.New("policy: invalid policy")
+
+// SiteKind selects network sites by the characters they are made of.
+type SiteKind string
+
+const (
+	// SitesAny selects every network site
+	SitesAny SiteKind = ""
+
+	// SitesNumeric selects network sites of two digits, such as "01"
+	SitesNumeric SiteKind = "numeric"
+
+	// SitesAlpha selects network sites containing a letter, such as "AB"
+	SitesAlpha SiteKind = "alpha"
+)
+
+// Match reports whether site is of kind k.
+func (k SiteKind) Match(site string) bool {
+	numeric := len(site) > 0 && strings.Trim(site, "0123456789") == ""
+	switch k {
+	case SitesNumeric:
+		return numeric
+	case SitesAlpha:
+		return !numeric
+	default:
+		return true
+	}
+}
+
+// Rule checks CLLI codes against one assignment standard.
+type Rule struct {
+	Name      string                   // Identifies the rule in violations
+	Component clli.ComponentKind       // Component the rule constrains
+	Check     func(c *clli.CLLI) error // Returns a non-nil error if c breaks the rule
+}
+
+// Violation is a CLLI code breaking a rule of a policy.
+type Violation struct {
+	Code      string             // Formatted CLLI code
+	Rule      string             // Name of the rule broken
+	Component clli.ComponentKind // Component the rule constrains
+	Err       error              // Error returned by the rule
+}
+
+// Error returns the code, rule and reason, e.g.
+// "CHCGIL01ZZZ: reserved_entities: policy: entity code reserved: ZZZ".
+func (v Violation) Error() string {
+	return v.Code + ": " + v.Rule + ": " + v.Err.Error()
+}
+
+// Unwrap returns the error returned by the rule.
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// Policy is a named set of rules.
+type Policy struct {
+	Name  string
+	Rules []Rule
+}
+
+// New returns a policy with the given rules.
+func New(name string, rules ...Rule) *Policy {
+	return &Policy{Name: name, Rules: rules}
+}
+
+// Evaluate checks c against every rule of the policy, in order, and
+// returns the violations found, or nil if c conforms. A nil c conforms.
+func (p *Policy) Evaluate(c *clli.CLLI) []Violation {
+	if c == nil {
+		return nil
+	}
+	var violations []Violation
+	for _, rule := range p.Rules {
+		if err := rule.Check(c); err != nil {
+			violations = append(violations, Violation{Code: c.Format(), Rule: rule.Name, Component: rule.Component, Err: err})
+		}
+	}
+	return violations
+}
+
+// Validator returns a clli.Validator rejecting codes that break the policy,
+// with an error joining every violation.
+func (p *Policy) Validator() clli.Validator {
+	return clli.ValidatorFunc(func(c *clli.CLLI) error {
+		var errs []error
+		for _, v := range p.Evaluate(c) {
+			errs = append(errs, v)
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// AllowRegions returns a rule accepting only codes in the given regions.
+func AllowRegions(regions ...string) Rule {
+	allowed := upper(regions)
+	return Rule{
+		Name:      "regions",
+		Component: clli.ComponentRegion,
+		Check: func(c *clli.CLLI) error {
+			if !slices.Contains(allowed, c.Region) {
+				return fmt.Errorf("%w: %s", ErrRegionNotAllowed, c.Region)
+			}
+			return nil
+		},
+	}
+}
+
+// ReserveEntities returns a rule rejecting entity CLLIs with the given
+// entity codes, such as codes set aside for future assignment.
+func ReserveEntities(codes ...string) Rule {
+	reserved := upper(codes)
+	return Rule{
+		Name:      "reserved_entities",
+		Component: clli.ComponentEntityCode,
+		Check: func(c *clli.CLLI) error {
+			if c.IsEntityCLLI() && slices.Contains(reserved, c.EntityCode) {
+				return fmt.Errorf("%w: %s", ErrReservedEntity, c.EntityCode)
+			}
+			return nil
+		},
+	}
+}
+
+// AllowEntityTables returns a rule accepting entity codes at network sites
+// of the given kind only from the given Bell entity tables, "B" to "E".
+// Entity codes matching no table are rejected at such sites, and codes at
+// other sites are not checked.
+func AllowEntityTables(sites SiteKind, tables ...string) Rule {
+	allowed := upper(tables)
+	return Rule{
+		Name:      "entity_tables",
+		Component: clli.ComponentEntityCode,
+		Check: func(c *clli.CLLI) error {
+			if !c.IsEntityCLLI() || c.EntityCode == "" || !sites.Match(c.NetworkSite) {
+				return nil
+			}
+			info, ok := clli.DescribeEntityCode(c.EntityCode)
+			if !ok {
+				return fmt.Errorf("%w: %s matches no table", ErrEntityTable, c.EntityCode)
+			}
+			if !slices.Contains(allowed, info.Table) {
+				return fmt.Errorf("%w: %s is in table %s", ErrEntityTable, c.EntityCode, info.Table)
+			}
+			return nil
+		},
+	}
+}
+
+// document is the YAML form of a policy.
+type document struct {
+	Name             string   `yaml:"name"`
+	Regions          []string `yaml:"regions"`
+	ReservedEntities []string `yaml:"reserved_entities"`
+	EntityTables     []struct {
+		Sites  SiteKind `yaml:"sites"`
+		Tables []string `yaml:"tables"`
+	} `yaml:"entity_tables"`
+}
+
+// Load reads a policy from a YAML document. Unknown keys are rejected, so
+// misspelt rules are not silently ignored. Rules are added in the order
+// regions, reserved entities, entity tables.
+func Load(r io.Reader) (*Policy, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var doc document
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	p := New(doc.Name)
+	if len(doc.Regions) > 0 {
+		p.Rules = append(p.Rules, AllowRegions(doc.Regions...))
+	}
+	if len(doc.ReservedEntities) > 0 {
+		p.Rules = append(p.Rules, ReserveEntities(doc.ReservedEntities...))
+	}
+	for _, t := range doc.EntityTables {
+		switch t.Sites {
+		case SitesAny, SitesNumeric, SitesAlpha:
+		default:
+			return nil, fmt.Errorf("%w: unknown site kind %q", ErrInvalidPolicy, t.Sites)
+		}
+		p.Rules = append(p.Rules, AllowEntityTables(t.Sites, t.Tables...))
+	}
+	return p, nil
+}
+
+// LoadFile reads a policy from a YAML file.
+func LoadFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// upper returns a copy of values in uppercase.
+func upper(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToUpper(v)
+	}
+	return out
+}
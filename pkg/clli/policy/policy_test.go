@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+const testPolicy = `
+name: acme
+regions: [il, NY]
+reserved_entities: [ds9]
+entity_tables:
+  - sites: numeric
+    tables: [B]
+`
+
+// TestEvaluate tests evaluating codes against a loaded policy
+func TestEvaluate(t *testing.T) {
+	p, err := Load(strings.NewReader(testPolicy))
+	require.NoError(t, err)
+	assert.Equal(t, "acme", p.Name)
+	require.Len(t, p.Rules, 3)
+
+	assert.Empty(t, p.Evaluate(clli.MustParse("CHCGIL01DS0")))
+	assert.Empty(t, p.Evaluate(clli.MustParse("NYCMNYMM4QB")))
+	assert.Empty(t, p.Evaluate(nil))
+
+	violations := p.Evaluate(clli.MustParse("CHCGIL014QB"))
+	require.Len(t, violations, 1)
+	assert.Equal(t, "entity_tables", violations[0].Rule)
+	assert.Equal(t, clli.ComponentEntityCode, violations[0].Component)
+	assert.ErrorIs(t, violations[0], ErrEntityTable)
+	assert.Equal(t, "CHCGIL014QB: entity_tables: policy: entity table not allowed: 4QB is in table C", violations[0].Error())
+
+	violations = p.Evaluate(clli.MustParse("DLLSTX01DS9"))
+	require.Len(t, violations, 2)
+	assert.ErrorIs(t, violations[0], ErrRegionNotAllowed)
+	assert.ErrorIs(t, violations[1], ErrReservedEntity)
+}
+
+// TestValidator tests rejecting codes during parsing
+func TestValidator(t *testing.T) {
+	p := New("regions", AllowRegions("IL"))
+	opts := &clli.ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true, ExtraValidators: []clli.Validator{p.Validator()}}
+
+	_, err := clli.ParseWithOptions("CHCGIL01DS0", opts)
+	assert.NoError(t, err)
+
+	_, err = clli.ParseWithOptions("NYCMNY01DS0", opts)
+	assert.ErrorIs(t, err, ErrRegionNotAllowed)
+	assert.Equal(t, clli.ErrCodeRejected, clli.ErrorCodeOf(err))
+}
+
+// TestLoadErrors tests rejecting malformed policy documents
+func TestLoadErrors(t *testing.T) {
+	_, err := Load(strings.NewReader("regoins: [IL]\n"))
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+
+	_, err = Load(strings.NewReader("entity_tables:\n  - sites: mixed\n"))
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+
+	p, err := Load(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Empty(t, p.Rules)
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy), 0o644))
+	p, err = LoadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, p.Rules, 3)
+}
@@ -0,0 +1,174 @@
+package clli
+
+import "sort"
+
+// QualityCategory names an aspect of data quality graded by ScoreQuality.
+type QualityCategory string
+
+// Data-quality categories
+const (
+	// QualityValid is the share of codes accepted by Parse
+	QualityValid QualityCategory = "valid"
+
+	// QualityRegistered is the share of valid codes whose place and region
+	// appear in the resolver's datasets
+	QualityRegistered QualityCategory = "registered"
+
+	// QualityUnique is the share of codes that do not repeat an earlier code
+	// once case and surrounding whitespace are ignored
+	QualityUnique QualityCategory = "unique"
+
+	// QualityRegionConsistency is the share of valid codes using the region
+	// most often paired with their place code in the corpus, which flags
+	// likely region typos such as a stray CHCGIN among many CHCGIL codes
+	QualityRegionConsistency QualityCategory = "region_consistency"
+
+	// QualityEntityConformance is the share of entity-shaped codes whose
+	// entity code matches a row of the Bell entity tables
+	QualityEntityConformance QualityCategory = "entity_conformance"
+)
+
+// DefaultQualityWeights are the category weights used when QualityOptions
+// does not supply its own.
+var DefaultQualityWeights = map[QualityCategory]float64{
+	QualityValid:             0.35,
+	QualityRegistered:        0.20,
+	QualityUnique:            0.15,
+	QualityRegionConsistency: 0.15,
+	QualityEntityConformance: 0.15,
+}
+
+// QualityOptions controls data-quality scoring.
+type QualityOptions struct {
+	// Resolver supplies the reference datasets for QualityRegistered.
+	// Nil selects DefaultResolver.
+	Resolver *Resolver
+
+	// Weights overrides the category weights. Categories missing from the
+	// map are not scored. Nil selects DefaultQualityWeights.
+	Weights map[QualityCategory]float64
+}
+
+// CategoryScore is the grade for a single data-quality category.
+type CategoryScore struct {
+	Category QualityCategory
+	Passed   int     // Codes that passed the check
+	Total    int     // Codes the check applied to
+	Score    float64 // Passed / Total, from 0 to 1
+	Weight   float64 // Weight of the category in the overall score
+}
+
+// QualityReport is the data-quality grade of a CLLI corpus.
+type QualityReport struct {
+	Total      int             // Number of codes scored
+	Score      float64         // Weighted health score, from 0 to 100
+	Categories []CategoryScore // Per-category breakdown, ordered by category name
+}
+
+// Category returns the score for a category and whether it was graded.
+func (r *QualityReport) Category(c QualityCategory) (CategoryScore, bool) {
+	for _, s := range r.Categories {
+		if s.Category == c {
+			return s, true
+		}
+	}
+	return CategoryScore{}, false
+}
+
+// ScoreQuality grades a CLLI corpus, for tracking data-quality KPIs across
+// feeds. Each category is scored as the share of applicable codes passing its
+// check; the overall score is the weighted mean of the categories that applied
+// to at least one code, scaled to 0–100. A nil opts selects the defaults.
+func ScoreQuality(codes []string, opts *QualityOptions) *QualityReport {
+	resolver := DefaultResolver()
+	weights := DefaultQualityWeights
+	if opts != nil {
+		if opts.Resolver != nil {
+			resolver = opts.Resolver
+		}
+		if opts.Weights != nil {
+			weights = opts.Weights
+		}
+	}
+
+	counts := map[QualityCategory]*CategoryScore{}
+	tally := func(c QualityCategory, passed bool) {
+		s, ok := counts[c]
+		if !ok {
+			s = &CategoryScore{Category: c}
+			counts[c] = s
+		}
+		s.Total++
+		if passed {
+			s.Passed++
+		}
+	}
+
+	seen := make(map[string]bool, len(codes))
+	regionsByPlace := map[string]map[string]int{}
+	var valid []*CLLI
+
+	for _, code := range codes {
+		normalized := NormalizeForCompare(code)
+		tally(QualityUnique, !seen[normalized])
+		seen[normalized] = true
+
+		if len(normalized) == 11 && isAlpha(normalized[:6]) {
+			if cls, ok := DefaultClassifier.Classify(normalized[6:]); ok && cls.Type == CLLITypeEntity && len(cls.EntityCode) == 3 {
+				tally(QualityEntityConformance, entityTableRow(cls.EntityCode) != "")
+			}
+		}
+
+		c, err := Parse(code)
+		tally(QualityValid, err == nil)
+		if err != nil {
+			continue
+		}
+		valid = append(valid, c)
+
+		_, registered := resolver.LookupPlace(c.Place, c.Region)
+		tally(QualityRegistered, registered)
+
+		if regionsByPlace[c.Place] == nil {
+			regionsByPlace[c.Place] = map[string]int{}
+		}
+		regionsByPlace[c.Place][c.Region]++
+	}
+
+	for _, c := range valid {
+		tally(QualityRegionConsistency, c.Region == dominantRegion(regionsByPlace[c.Place]))
+	}
+
+	report := &QualityReport{Total: len(codes)}
+	var weighted, totalWeight float64
+	for category, weight := range weights {
+		s, ok := counts[category]
+		if !ok || weight <= 0 {
+			continue
+		}
+		s.Weight = weight
+		s.Score = float64(s.Passed) / float64(s.Total)
+		report.Categories = append(report.Categories, *s)
+		weighted += weight * s.Score
+		totalWeight += weight
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+	if totalWeight > 0 {
+		report.Score = 100 * weighted / totalWeight
+	}
+
+	return report
+}
+
+// dominantRegion returns the most frequent region, breaking ties alphabetically.
+func dominantRegion(counts map[string]int) string {
+	var best string
+	for region, n := range counts {
+		if n > counts[best] || (n == counts[best] && region < best) {
+			best = region
+		}
+	}
+	return best
+}
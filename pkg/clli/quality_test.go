@@ -0,0 +1,71 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScoreQuality tests grading of a CLLI corpus
+func TestScoreQuality(t *testing.T) {
+	codes := []string{
+		"CHCGIL01DS0",
+		"chcgil01ds0 ", // duplicate
+		"CHCGIL02DS1",
+		"CHCGIN01DS0", // region inconsistent with CHCG
+		"DLLSTX01DS0",
+		"ZZZZIL01DS0", // unregistered place
+		"CHCGIL01QQQ", // entity code matches no table row
+		"CHCGZZ01DS0", // invalid region
+	}
+
+	report := ScoreQuality(codes, nil)
+	assert.Equal(t, len(codes), report.Total)
+
+	expected := map[QualityCategory][2]int{
+		QualityValid:             {6, 8},
+		QualityRegistered:        {4, 6},
+		QualityUnique:            {7, 8},
+		QualityRegionConsistency: {5, 6},
+		QualityEntityConformance: {7, 8},
+	}
+	require.Len(t, report.Categories, len(expected))
+	for category, counts := range expected {
+		s, ok := report.Category(category)
+		require.True(t, ok, category)
+		assert.Equal(t, counts[0], s.Passed, category)
+		assert.Equal(t, counts[1], s.Total, category)
+		assert.InDelta(t, float64(counts[0])/float64(counts[1]), s.Score, 1e-9, category)
+		assert.Equal(t, DefaultQualityWeights[category], s.Weight, category)
+	}
+
+	want := 100 * (0.35*6/8 + 0.20*4/6 + 0.15*7/8 + 0.15*5/6 + 0.15*7/8)
+	assert.InDelta(t, want, report.Score, 1e-9)
+}
+
+// TestScoreQualityOptions tests custom weights and resolvers
+func TestScoreQualityOptions(t *testing.T) {
+	codes := []string{"LABXIL01DS0", "LABXIL02DS0"}
+
+	t.Run("Custom resolver", func(t *testing.T) {
+		r := NewResolver(NewDataset("lab", []PlaceRecord{{Place: "LABX", Region: "IL", City: "Lab"}}))
+		report := ScoreQuality(codes, &QualityOptions{Resolver: r})
+		s, _ := report.Category(QualityRegistered)
+		assert.Equal(t, 2, s.Passed)
+		assert.InDelta(t, 100, report.Score, 1e-9)
+	})
+
+	t.Run("Custom weights", func(t *testing.T) {
+		report := ScoreQuality(codes, &QualityOptions{Weights: map[QualityCategory]float64{QualityRegistered: 1}})
+		require.Len(t, report.Categories, 1)
+		assert.Zero(t, report.Score)
+	})
+
+	t.Run("Empty corpus", func(t *testing.T) {
+		report := ScoreQuality(nil, nil)
+		assert.Zero(t, report.Total)
+		assert.Empty(t, report.Categories)
+		assert.Zero(t, report.Score)
+	})
+}
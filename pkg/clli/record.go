@@ -0,0 +1,307 @@
+package clli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecordStatus is the status of a CLLI in a reference record.
+type RecordStatus string
+
+// Reference record statuses
+const (
+	RecordStatusActive       RecordStatus = "A" // In service
+	RecordStatusPlanned      RecordStatus = "P" // Assigned but not yet in service
+	RecordStatusDiscontinued RecordStatus = "D" // Withdrawn from service
+)
+
+// ReferenceRecord is one row of a CLLI reference extract, carrying the
+// location details published alongside each code.
+type ReferenceRecord struct {
+	CLLI             *CLLI             // Parsed CLLI code
+	Status           RecordStatus      // Assignment status
+	Address          string            // Street address
+	City             string            // City or locality
+	Region           string            // State or province code of the address
+	PostalCode       string            // ZIP or postal code
+	V                int               // V coordinate of the V&H grid
+	H                int               // H coordinate of the V&H grid
+	LATA             string            // Local access and transport area
+	OCN              string            // Operating company number of the owner
+	EffectiveDate    time.Time         // Date the record took effect
+	DiscontinuedDate time.Time         // Date the code was discontinued; zero if in service
+	Extra            map[string]string // Columns not mapped to a field, keyed by header
+}
+
+// Reference record column names, as they appear in the extract header row.
+const (
+	ColumnCLLI             = "CLLI"
+	ColumnStatus           = "STATUS"
+	ColumnAddress          = "ADDRESS"
+	ColumnCity             = "CITY"
+	ColumnRegion           = "STATE"
+	ColumnPostalCode       = "POSTAL_CODE"
+	ColumnV                = "V"
+	ColumnH                = "H"
+	ColumnLATA             = "LATA"
+	ColumnOCN              = "OCN"
+	ColumnEffectiveDate    = "EFFECTIVE_DATE"
+	ColumnDiscontinuedDate = "DISCONTINUED_DATE"
+)
+
+// RecordColumns lists the columns written by RecordWriter, in order.
+var RecordColumns = []string{
+	ColumnCLLI, ColumnStatus, ColumnAddress, ColumnCity, ColumnRegion, ColumnPostalCode,
+	ColumnV, ColumnH, ColumnLATA, ColumnOCN, ColumnEffectiveDate, ColumnDiscontinuedDate,
+}
+
+// RecordDateLayout is the date format used in reference extracts.
+const RecordDateLayout = "20060102"
+
+// RecordDelimiter is the default field separator of reference extracts.
+const RecordDelimiter = '|'
+
+// RecordError reports a malformed field in a reference extract.
+type RecordError struct {
+	Line   int    // 1-based line number in the extract
+	Column string // Column name
+	Err    error  // Underlying error
+}
+
+// Error returns the error message.
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record line %d column %s: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// RecordReader decodes reference records from a delimited extract whose
+// first row names the columns. Columns may appear in any order; only the
+// CLLI column is required, and unrecognized columns are kept in Extra.
+type RecordReader struct {
+	// Options controls how CLLI codes are parsed. Nil selects the same
+	// defaults as Parse.
+	Options *ParseOptions
+
+	r       *csv.Reader
+	columns []string
+	line    int
+}
+
+// NewRecordReader creates a RecordReader reading RecordDelimiter-separated fields from r.
+func NewRecordReader(r io.Reader) *RecordReader {
+	cr := csv.NewReader(r)
+	cr.Comma = RecordDelimiter
+	cr.LazyQuotes = true
+	cr.FieldsPerRecord = -1
+	return &RecordReader{r: cr}
+}
+
+// SetDelimiter changes the field separator. It must be called before the first Read.
+func (rr *RecordReader) SetDelimiter(d rune) {
+	rr.r.Comma = d
+}
+
+// Read returns the next record, or io.EOF when the extract is exhausted.
+// Malformed fields are reported as a *RecordError.
+func (rr *RecordReader) Read() (*ReferenceRecord, error) {
+	if rr.columns == nil {
+		if err := rr.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := rr.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	rr.line++
+
+	rec := &ReferenceRecord{}
+	for i, value := range fields {
+		if i >= len(rr.columns) {
+			break
+		}
+		value = strings.TrimSpace(value)
+		if err := rec.set(rr.columns[i], value, rr.Options); err != nil {
+			return nil, &RecordError{Line: rr.line, Column: rr.columns[i], Err: err}
+		}
+	}
+	if rec.CLLI == nil {
+		return nil, &RecordError{Line: rr.line, Column: ColumnCLLI, Err: ErrEmptyInput}
+	}
+
+	return rec, nil
+}
+
+// ReadAll reads every remaining record.
+func (rr *RecordReader) ReadAll() ([]*ReferenceRecord, error) {
+	var records []*ReferenceRecord
+	for {
+		rec, err := rr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, rec)
+	}
+}
+
+// readHeader reads and validates the header row.
+func (rr *RecordReader) readHeader() error {
+	header, err := rr.r.Read()
+	if err != nil {
+		return err
+	}
+	rr.line++
+
+	columns := make([]string, len(header))
+	found := false
+	for i, name := range header {
+		columns[i] = strings.ToUpper(strings.TrimSpace(name))
+		if columns[i] == ColumnCLLI {
+			found = true
+		}
+	}
+	if !found {
+		return &RecordError{Line: rr.line, Column: ColumnCLLI, Err: newMessageError(MsgRecordColumn)}
+	}
+
+	rr.columns = columns
+	return nil
+}
+
+// set assigns a column value to the corresponding record field.
+func (rec *ReferenceRecord) set(column, value string, opts *ParseOptions) error {
+	var err error
+	switch column {
+	case ColumnCLLI:
+		if opts == nil {
+			rec.CLLI, err = Parse(value)
+		} else {
+			rec.CLLI, err = ParseWithOptions(value, opts)
+		}
+	case ColumnStatus:
+		rec.Status = RecordStatus(strings.ToUpper(value))
+	case ColumnAddress:
+		rec.Address = value
+	case ColumnCity:
+		rec.City = value
+	case ColumnRegion:
+		rec.Region = strings.ToUpper(value)
+	case ColumnPostalCode:
+		rec.PostalCode = value
+	case ColumnV:
+		rec.V, err = parseCoordinate(value)
+	case ColumnH:
+		rec.H, err = parseCoordinate(value)
+	case ColumnLATA:
+		rec.LATA = value
+	case ColumnOCN:
+		rec.OCN = value
+	case ColumnEffectiveDate:
+		rec.EffectiveDate, err = parseRecordDate(value)
+	case ColumnDiscontinuedDate:
+		rec.DiscontinuedDate, err = parseRecordDate(value)
+	default:
+		if value != "" {
+			if rec.Extra == nil {
+				rec.Extra = make(map[string]string)
+			}
+			rec.Extra[column] = value
+		}
+	}
+	return err
+}
+
+// parseCoordinate parses a V or H coordinate; an empty field yields zero.
+func parseCoordinate(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// parseRecordDate parses a RecordDateLayout date; an empty field yields the zero time.
+func parseRecordDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(RecordDateLayout, s)
+}
+
+// RecordWriter encodes reference records in the layout read by RecordReader,
+// writing a header row of RecordColumns before the first record.
+type RecordWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// NewRecordWriter creates a RecordWriter writing RecordDelimiter-separated fields to w.
+func NewRecordWriter(w io.Writer) *RecordWriter {
+	cw := csv.NewWriter(w)
+	cw.Comma = RecordDelimiter
+	return &RecordWriter{w: cw}
+}
+
+// Write writes a single record. Extra columns are not written.
+func (rw *RecordWriter) Write(rec *ReferenceRecord) error {
+	if !rw.wroteHeader {
+		if err := rw.w.Write(RecordColumns); err != nil {
+			return err
+		}
+		rw.wroteHeader = true
+	}
+
+	var code string
+	if rec.CLLI != nil {
+		code = rec.CLLI.Format()
+	}
+
+	return rw.w.Write([]string{
+		code,
+		string(rec.Status),
+		rec.Address,
+		rec.City,
+		rec.Region,
+		rec.PostalCode,
+		formatCoordinate(rec.V),
+		formatCoordinate(rec.H),
+		rec.LATA,
+		rec.OCN,
+		formatRecordDate(rec.EffectiveDate),
+		formatRecordDate(rec.DiscontinuedDate),
+	})
+}
+
+// Flush writes any buffered data to the underlying writer and reports any error.
+func (rw *RecordWriter) Flush() error {
+	rw.w.Flush()
+	return rw.w.Error()
+}
+
+// formatCoordinate formats a V or H coordinate as the 5-digit zero-padded value used in extracts.
+func formatCoordinate(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%05d", n)
+}
+
+// formatRecordDate formats a date in RecordDateLayout; the zero time yields an empty field.
+func formatRecordDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(RecordDateLayout)
+}
@@ -0,0 +1,107 @@
+package clli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleExtract = `CLLI|STATUS|ADDRESS|CITY|STATE|POSTAL_CODE|V|H|LATA|OCN|EFFECTIVE_DATE|DISCONTINUED_DATE|BUILDING_NAME
+CHCGIL01DS0|A|225 W RANDOLPH ST|CHICAGO|IL|60606|05986|03426|358|9206|19840101||RANDOLPH
+DLLSTXB1234|D|400 S AKARD ST|DALLAS|TX|75202|08436|04034|552|9208|19900315|20200630|
+`
+
+// TestRecordReader tests decoding reference extracts
+func TestRecordReader(t *testing.T) {
+	records, err := NewRecordReader(strings.NewReader(sampleExtract)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	rec := records[0]
+	assert.Equal(t, "CHCGIL01DS0", rec.CLLI.Format())
+	assert.Equal(t, CLLITypeEntity, rec.CLLI.Type())
+	assert.Equal(t, RecordStatusActive, rec.Status)
+	assert.Equal(t, "225 W RANDOLPH ST", rec.Address)
+	assert.Equal(t, "CHICAGO", rec.City)
+	assert.Equal(t, "IL", rec.Region)
+	assert.Equal(t, "60606", rec.PostalCode)
+	assert.Equal(t, 5986, rec.V)
+	assert.Equal(t, 3426, rec.H)
+	assert.Equal(t, "358", rec.LATA)
+	assert.Equal(t, "9206", rec.OCN)
+	assert.Equal(t, time.Date(1984, 1, 1, 0, 0, 0, 0, time.UTC), rec.EffectiveDate)
+	assert.True(t, rec.DiscontinuedDate.IsZero())
+	assert.Equal(t, map[string]string{"BUILDING_NAME": "RANDOLPH"}, rec.Extra)
+
+	assert.Equal(t, RecordStatusDiscontinued, records[1].Status)
+	assert.Equal(t, time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC), records[1].DiscontinuedDate)
+	assert.Nil(t, records[1].Extra)
+}
+
+// TestRecordReaderColumnOrder tests headers in any order and a custom delimiter
+func TestRecordReaderColumnOrder(t *testing.T) {
+	r := NewRecordReader(strings.NewReader("city,clli\nChicago,chcgil01ds0\n"))
+	r.SetDelimiter(',')
+
+	rec, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL01DS0", rec.CLLI.Format())
+	assert.Equal(t, "Chicago", rec.City)
+
+	_, err = r.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+// TestRecordReaderErrors tests reporting of malformed extracts
+func TestRecordReaderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column string
+	}{
+		{"Missing CLLI column", "CITY|STATE\nCHICAGO|IL\n", 1, ColumnCLLI},
+		{"Invalid CLLI", "CLLI\nCHCGZZ01DS0\n", 2, ColumnCLLI},
+		{"Empty CLLI", "CLLI|CITY\n|CHICAGO\n", 2, ColumnCLLI},
+		{"Invalid coordinate", "CLLI|V\nCHCGIL01DS0|59X6\n", 2, ColumnV},
+		{"Invalid date", "CLLI|EFFECTIVE_DATE\nCHCGIL01DS0|1984-01-01\n", 2, ColumnEffectiveDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecordReader(strings.NewReader(tt.input)).Read()
+			var re *RecordError
+			require.ErrorAs(t, err, &re)
+			assert.Equal(t, tt.line, re.Line)
+			assert.Equal(t, tt.column, re.Column)
+		})
+	}
+}
+
+// TestRecordRoundTrip tests that written extracts read back unchanged
+func TestRecordRoundTrip(t *testing.T) {
+	records, err := NewRecordReader(strings.NewReader(sampleExtract)).ReadAll()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := NewRecordWriter(&buf)
+	for _, rec := range records {
+		require.NoError(t, w.Write(rec))
+	}
+	require.NoError(t, w.Flush())
+
+	assert.True(t, strings.HasPrefix(buf.String(), strings.Join(RecordColumns, "|")+"\n"))
+
+	again, err := NewRecordReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, again, len(records))
+	for i := range records {
+		records[i].Extra = nil
+		assert.Equal(t, records[i], again[i])
+	}
+}
@@ -0,0 +1,98 @@
+package clli
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// RedactionPolicy selects the CLLI components masked by String, LogValue
+// and LogAttrs, so logs can carry location context without leaking full
+// facility identifiers. The zero policy masks nothing. Format, Canonical and
+// the component fields are never redacted.
+type RedactionPolicy struct {
+	// Components lists the components to mask.
+	Components []ComponentKind
+
+	// Mask replaces each character of a masked component. Zero selects '*'.
+	Mask byte
+}
+
+// RedactFacility masks the components identifying a facility within its
+// place: the network site, entity code, location ID and customer ID.
+var RedactFacility = RedactionPolicy{
+	Components: []ComponentKind{ComponentNetworkSite, ComponentEntityCode, ComponentLocationID, ComponentCustomerID},
+}
+
+// redactionPolicy is the default policy applied by String and the logging methods.
+var redactionPolicy atomic.Pointer[RedactionPolicy]
+
+// SetRedactionPolicy replaces the default policy applied by String,
+// LogValue and LogAttrs to CLLIs not parsed by a Parser with a policy of
+// its own. The zero policy disables redaction.
+func SetRedactionPolicy(p RedactionPolicy) {
+	p.Components = append([]ComponentKind(nil), p.Components...)
+	redactionPolicy.Store(&p)
+}
+
+// CurrentRedactionPolicy returns the default policy set with
+// SetRedactionPolicy.
+func CurrentRedactionPolicy() RedactionPolicy {
+	if p := redactionPolicy.Load(); p != nil {
+		return *p
+	}
+	return RedactionPolicy{}
+}
+
+// redactionPolicy returns the policy applied to c: that of the Parser that
+// parsed it, or else the default policy.
+func (c *CLLI) redactionPolicy() RedactionPolicy {
+	if c != nil && c.redaction != nil {
+		return *c.redaction
+	}
+	return CurrentRedactionPolicy()
+}
+
+// Redact returns c formatted with the components selected by p masked,
+// such as "CHCGIL*****" under RedactFacility. Returns "" if c is nil.
+func (p RedactionPolicy) Redact(c *CLLI) string {
+	if c == nil {
+		return ""
+	}
+	return p.apply(c).Format()
+}
+
+// apply returns a copy of c with the components selected by p masked, or c
+// itself if p masks nothing.
+func (p RedactionPolicy) apply(c *CLLI) *CLLI {
+	if len(p.Components) == 0 {
+		return c
+	}
+	mask := p.Mask
+	if mask == 0 {
+		mask = '*'
+	}
+
+	r := *c
+	fields := r.componentFields()
+	for _, kind := range p.Components {
+		if kind >= 0 && int(kind) < len(fields) && *fields[kind] != "" {
+			*fields[kind] = strings.Repeat(string(mask), len(*fields[kind]))
+		}
+	}
+	return &r
+}
+
+// componentFields returns pointers to the component fields of c, indexed
+// by ComponentKind.
+func (c *CLLI) componentFields() [ComponentCustomerID + 1]*string {
+	return [...]*string{
+		ComponentPlace:        &c.Place,
+		ComponentRegion:       &c.Region,
+		ComponentNetworkSite:  &c.NetworkSite,
+		ComponentEntityCode:   &c.EntityCode,
+		ComponentLocationCode: &c.LocationCode,
+		ComponentLocationID:   &c.LocationID,
+		ComponentCustomerCode: &c.CustomerCode,
+		ComponentCustomerID:   &c.CustomerID,
+	}
+}
@@ -0,0 +1,364 @@
+package clli
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// RegionInfo describes a two-letter CLLI region code.
+type RegionInfo struct {
+	Code        string // 2-letter region code
+	Name        string // State, province or region name
+	Subdivision string // Kind of subdivision, e.g. "state", "province" or "territory"
+	CountryCode string // ISO 3166-1 alpha-2 country code
+	CountryName string // Full country name
+	TimeZone    string // Predominant IANA time zone, e.g. "America/Chicago"
+}
+
+// RegionRegistry maps region codes to their country and subdivision metadata.
+// The default registry is consulted by region validation and by every
+// geographic method; add entries to it to recognize internal or lab region
+// codes everywhere, or set ParseOptions.Regions to a registry of your own
+// to recognize them only in the parsers configured with it. A
+// RegionRegistry is safe for concurrent use.
+type RegionRegistry struct {
+	mu      sync.RWMutex
+	regions map[string]RegionInfo
+}
+
+// NewRegionRegistry creates a registry containing the given regions.
+// Returns an error if any region code is not two uppercase letters.
+func NewRegionRegistry(regions ...RegionInfo) (*RegionRegistry, error) {
+	r := &RegionRegistry{regions: make(map[string]RegionInfo, len(regions))}
+	for _, info := range regions {
+		if err := r.Register(info); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// usTerritories lists the US territories, which use the North American
+// numbering plan and CLLI codes like the states. Their country codes are
+// their own ISO 3166-1 codes.
+var usTerritories = []RegionInfo{
+	{Code: "AS", Name: "American Samoa", Subdivision: "territory", CountryCode: "AS", CountryName: "American Samoa"},
+	{Code: "GU", Name: "Guam", Subdivision: "territory", CountryCode: "GU", CountryName: "Guam"},
+	{Code: "MP", Name: "Northern Mariana Islands", Subdivision: "territory", CountryCode: "MP", CountryName: "Northern Mariana Islands"},
+	{Code: "PR", Name: "Puerto Rico", Subdivision: "territory", CountryCode: "PR", CountryName: "Puerto Rico"},
+	{Code: "VI", Name: "U.S. Virgin Islands", Subdivision: "territory", CountryCode: "VI", CountryName: "U.S. Virgin Islands"},
+}
+
+// usMilitaryRegions lists the US armed forces overseas designations. "AE"
+// (Armed Forces Europe) is also the code of the United Arab Emirates, which
+// CountryRegistry resolves.
+var usMilitaryRegions = []RegionInfo{
+	{Code: "AA", Name: "Armed Forces Americas", Subdivision: "military", CountryCode: "US", CountryName: "United States"},
+	{Code: "AE", Name: "Armed Forces Europe", Subdivision: "military", CountryCode: "US", CountryName: "United States"},
+	{Code: "AP", Name: "Armed Forces Pacific", Subdivision: "military", CountryCode: "US", CountryName: "United States"},
+}
+
+// defaultRegionRegistry holds the US states, territories and military
+// designations and the Canadian provinces.
+var defaultRegionRegistry = func() *RegionRegistry {
+	r := &RegionRegistry{regions: make(map[string]RegionInfo, len(usStates)+len(usTerritories)+len(usMilitaryRegions)+len(canadianProvinces))}
+	for code, name := range usStates {
+		subdivision := "state"
+		if code == "DC" {
+			subdivision = "district"
+		}
+		r.regions[code] = RegionInfo{Code: code, Name: name, Subdivision: subdivision, CountryCode: "US", CountryName: "United States", TimeZone: regionTimeZones[code]}
+	}
+	for code, name := range canadianProvinces {
+		subdivision := "province"
+		switch code {
+		case "NT", "NU", "YT":
+			subdivision = "territory"
+		}
+		r.regions[code] = RegionInfo{Code: code, Name: name, Subdivision: subdivision, CountryCode: "CA", CountryName: "Canada", TimeZone: regionTimeZones[code]}
+	}
+	for _, info := range slices.Concat(usTerritories, usMilitaryRegions) {
+		info.TimeZone = regionTimeZones[info.Code]
+		r.regions[info.Code] = info
+	}
+	return r
+}()
+
+// DefaultRegionRegistry returns the registry consulted by validation and the
+// geographic methods. It initially holds the US states, territories and
+// military designations and the Canadian provinces.
+func DefaultRegionRegistry() *RegionRegistry {
+	return defaultRegionRegistry
+}
+
+// internationalRegions lists the country region codes used outside the US
+// and Canada: Caribbean and other North American Numbering Plan countries,
+// Mexico, and overseas countries. Country codes follow ISO 3166-1 except
+// where Telcordia practice differs, as with "UK". Some codes are also US
+// state, Canadian province or Mexican state codes, such as Antigua and
+// Barbuda (AG, Aguascalientes) and the Bahamas (BS, Baja California Sur);
+// see InternationalRegionRegistry for how such codes are resolved.
+var internationalRegions = []RegionInfo{
+	// Caribbean and Atlantic
+	{Code: "AG", Name: "Antigua and Barbuda", Subdivision: "country", CountryCode: "AG", CountryName: "Antigua and Barbuda"},
+	{Code: "AI", Name: "Anguilla", Subdivision: "territory", CountryCode: "AI", CountryName: "Anguilla"},
+	{Code: "BB", Name: "Barbados", Subdivision: "country", CountryCode: "BB", CountryName: "Barbados"},
+	{Code: "BM", Name: "Bermuda", Subdivision: "territory", CountryCode: "BM", CountryName: "Bermuda"},
+	{Code: "BS", Name: "Bahamas", Subdivision: "country", CountryCode: "BS", CountryName: "Bahamas"},
+	{Code: "CU", Name: "Cuba", Subdivision: "country", CountryCode: "CU", CountryName: "Cuba"},
+	{Code: "DM", Name: "Dominica", Subdivision: "country", CountryCode: "DM", CountryName: "Dominica"},
+	{Code: "DO", Name: "Dominican Republic", Subdivision: "country", CountryCode: "DO", CountryName: "Dominican Republic"},
+	{Code: "GD", Name: "Grenada", Subdivision: "country", CountryCode: "GD", CountryName: "Grenada"},
+	{Code: "HT", Name: "Haiti", Subdivision: "country", CountryCode: "HT", CountryName: "Haiti"},
+	{Code: "JM", Name: "Jamaica", Subdivision: "country", CountryCode: "JM", CountryName: "Jamaica"},
+	{Code: "KN", Name: "Saint Kitts and Nevis", Subdivision: "country", CountryCode: "KN", CountryName: "Saint Kitts and Nevis"},
+	{Code: "LC", Name: "Saint Lucia", Subdivision: "country", CountryCode: "LC", CountryName: "Saint Lucia"},
+	{Code: "SX", Name: "Sint Maarten", Subdivision: "territory", CountryCode: "SX", CountryName: "Sint Maarten"},
+	{Code: "TC", Name: "Turks and Caicos Islands", Subdivision: "territory", CountryCode: "TC", CountryName: "Turks and Caicos Islands"},
+	{Code: "TT", Name: "Trinidad and Tobago", Subdivision: "country", CountryCode: "TT", CountryName: "Trinidad and Tobago"},
+	{Code: "VC", Name: "Saint Vincent and the Grenadines", Subdivision: "country", CountryCode: "VC", CountryName: "Saint Vincent and the Grenadines"},
+	{Code: "VG", Name: "British Virgin Islands", Subdivision: "territory", CountryCode: "VG", CountryName: "British Virgin Islands"},
+
+	// Mexico; its states are listed in mexicanStates
+	{Code: "MX", Name: "Mexico", Subdivision: "country", CountryCode: "MX", CountryName: "Mexico"},
+
+	// Overseas
+	{Code: "AE", Name: "United Arab Emirates", Subdivision: "country", CountryCode: "AE", CountryName: "United Arab Emirates"},
+	{Code: "AT", Name: "Austria", Subdivision: "country", CountryCode: "AT", CountryName: "Austria"},
+	{Code: "AU", Name: "Australia", Subdivision: "country", CountryCode: "AU", CountryName: "Australia"},
+	{Code: "BE", Name: "Belgium", Subdivision: "country", CountryCode: "BE", CountryName: "Belgium"},
+	{Code: "BR", Name: "Brazil", Subdivision: "country", CountryCode: "BR", CountryName: "Brazil"},
+	{Code: "CH", Name: "Switzerland", Subdivision: "country", CountryCode: "CH", CountryName: "Switzerland"},
+	{Code: "CL", Name: "Chile", Subdivision: "country", CountryCode: "CL", CountryName: "Chile"},
+	{Code: "CN", Name: "China", Subdivision: "country", CountryCode: "CN", CountryName: "China"},
+	{Code: "DK", Name: "Denmark", Subdivision: "country", CountryCode: "DK", CountryName: "Denmark"},
+	{Code: "EG", Name: "Egypt", Subdivision: "country", CountryCode: "EG", CountryName: "Egypt"},
+	{Code: "ES", Name: "Spain", Subdivision: "country", CountryCode: "ES", CountryName: "Spain"},
+	{Code: "FI", Name: "Finland", Subdivision: "country", CountryCode: "FI", CountryName: "Finland"},
+	{Code: "FR", Name: "France", Subdivision: "country", CountryCode: "FR", CountryName: "France"},
+	{Code: "GR", Name: "Greece", Subdivision: "country", CountryCode: "GR", CountryName: "Greece"},
+	{Code: "HK", Name: "Hong Kong", Subdivision: "territory", CountryCode: "HK", CountryName: "Hong Kong"},
+	{Code: "IE", Name: "Ireland", Subdivision: "country", CountryCode: "IE", CountryName: "Ireland"},
+	{Code: "IT", Name: "Italy", Subdivision: "country", CountryCode: "IT", CountryName: "Italy"},
+	{Code: "JP", Name: "Japan", Subdivision: "country", CountryCode: "JP", CountryName: "Japan"},
+	{Code: "KR", Name: "South Korea", Subdivision: "country", CountryCode: "KR", CountryName: "South Korea"},
+	{Code: "NO", Name: "Norway", Subdivision: "country", CountryCode: "NO", CountryName: "Norway"},
+	{Code: "NZ", Name: "New Zealand", Subdivision: "country", CountryCode: "NZ", CountryName: "New Zealand"},
+	{Code: "PH", Name: "Philippines", Subdivision: "country", CountryCode: "PH", CountryName: "Philippines"},
+	{Code: "PL", Name: "Poland", Subdivision: "country", CountryCode: "PL", CountryName: "Poland"},
+	{Code: "PT", Name: "Portugal", Subdivision: "country", CountryCode: "PT", CountryName: "Portugal"},
+	{Code: "RU", Name: "Russia", Subdivision: "country", CountryCode: "RU", CountryName: "Russia"},
+	{Code: "SE", Name: "Sweden", Subdivision: "country", CountryCode: "SE", CountryName: "Sweden"},
+	{Code: "SG", Name: "Singapore", Subdivision: "country", CountryCode: "SG", CountryName: "Singapore"},
+	{Code: "TR", Name: "Turkey", Subdivision: "country", CountryCode: "TR", CountryName: "Turkey"},
+	{Code: "TW", Name: "Taiwan", Subdivision: "country", CountryCode: "TW", CountryName: "Taiwan"},
+	{Code: "UK", Name: "United Kingdom", Subdivision: "country", CountryCode: "GB", CountryName: "United Kingdom"},
+	{Code: "VE", Name: "Venezuela", Subdivision: "country", CountryCode: "VE", CountryName: "Venezuela"},
+	{Code: "ZA", Name: "South Africa", Subdivision: "country", CountryCode: "ZA", CountryName: "South Africa"},
+}
+
+// mexicanStates lists the 32 federal entities of Mexico under their CLLI
+// region codes. Five of them share their code with a US state or Canadian
+// province: Baja California (BC), Coahuila (CO), Michoacán (MI), Morelos
+// (MO) and Nuevo León (NL).
+var mexicanStates = []RegionInfo{
+	{Code: "AG", Name: "Aguascalientes", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "BC", Name: "Baja California", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "BS", Name: "Baja California Sur", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "CM", Name: "Campeche", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "CS", Name: "Chiapas", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "CH", Name: "Chihuahua", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "DF", Name: "Ciudad de México", Subdivision: "district", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "CO", Name: "Coahuila", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "CL", Name: "Colima", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "DG", Name: "Durango", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "EM", Name: "Estado de México", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "GT", Name: "Guanajuato", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "GR", Name: "Guerrero", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "HG", Name: "Hidalgo", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "JA", Name: "Jalisco", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "MI", Name: "Michoacán", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "MO", Name: "Morelos", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "NA", Name: "Nayarit", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "NL", Name: "Nuevo León", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "OA", Name: "Oaxaca", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "PU", Name: "Puebla", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "QT", Name: "Querétaro", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "QR", Name: "Quintana Roo", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "SL", Name: "San Luis Potosí", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "SI", Name: "Sinaloa", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "SO", Name: "Sonora", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "TB", Name: "Tabasco", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "TM", Name: "Tamaulipas", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "TL", Name: "Tlaxcala", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "VE", Name: "Veracruz", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "YU", Name: "Yucatán", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+	{Code: "ZA", Name: "Zacatecas", Subdivision: "state", CountryCode: "MX", CountryName: "Mexico"},
+}
+
+// mexicanStateRegistry holds mexicanStates.
+var mexicanStateRegistry = func() *RegionRegistry {
+	r := &RegionRegistry{regions: make(map[string]RegionInfo, len(mexicanStates))}
+	for _, info := range mexicanStates {
+		info.TimeZone = mexicanStateTimeZones[info.Code]
+		r.regions[info.Code] = info
+	}
+	return r
+}()
+
+// MexicanStateRegistry returns the registry of the Mexican states under
+// their CLLI region codes. Unlike InternationalRegionRegistry, it includes
+// the states whose codes are also US state or Canadian province codes, so
+// that a code known to be Mexican, such as "NL" for Nuevo León, can be
+// resolved explicitly.
+func MexicanStateRegistry() *RegionRegistry {
+	return mexicanStateRegistry
+}
+
+// countryRegistry holds internationalRegions.
+var countryRegistry = func() *RegionRegistry {
+	r := &RegionRegistry{regions: make(map[string]RegionInfo, len(internationalRegions))}
+	for _, info := range internationalRegions {
+		info.TimeZone = countryTimeZones[info.Code]
+		r.regions[info.Code] = info
+	}
+	return r
+}()
+
+// CountryRegistry returns the registry of the countries outside the US and
+// Canada under their CLLI region codes. Unlike InternationalRegionRegistry,
+// it includes the countries whose codes are also US state, Canadian
+// province or Mexican state codes, so that a code known to name a country,
+// such as "BS" for the Bahamas, can be resolved explicitly.
+func CountryRegistry() *RegionRegistry {
+	return countryRegistry
+}
+
+// internationalRegionRegistry holds the Mexican states and countries whose
+// codes are not domestic, with a Mexican state taking precedence over a
+// country.
+var internationalRegionRegistry = func() *RegionRegistry {
+	r := &RegionRegistry{regions: make(map[string]RegionInfo, len(internationalRegions)+len(mexicanStates))}
+	for _, registry := range []*RegionRegistry{mexicanStateRegistry, countryRegistry} {
+		for _, info := range registry.Regions() {
+			if _, domestic := defaultRegionRegistry.Lookup(info.Code); domestic {
+				continue
+			}
+			if _, ok := r.regions[info.Code]; !ok {
+				r.regions[info.Code] = info
+			}
+		}
+	}
+	return r
+}()
+
+// InternationalRegionRegistry returns the registry of international region
+// codes accepted when ParseOptions.AllowInternational is set: the countries
+// outside the US and Canada and the Mexican states. A code is resolved by
+// precedence: a US state, territory or Canadian province (the default
+// registry) first, then a Mexican state, then a country. So "NL" resolves
+// to Newfoundland and Labrador rather than Nuevo León, and "BS" to Baja
+// California Sur rather than the Bahamas. To resolve a code with another
+// meaning, look it up in MexicanStateRegistry or CountryRegistry, or use
+// CLLI.RegionIn.
+func InternationalRegionRegistry() *RegionRegistry {
+	return internationalRegionRegistry
+}
+
+// lookupRegion returns the metadata for a region code from the default
+// registry, falling back to the international registry.
+func lookupRegion(code string) (RegionInfo, bool) {
+	if info, ok := defaultRegionRegistry.Lookup(code); ok {
+		return info, true
+	}
+	return internationalRegionRegistry.Lookup(code)
+}
+
+// RegionIn returns the metadata for the CLLI's region code from r instead
+// of by the default precedence of the geographic methods, for codes known
+// to carry another meaning. For example, c.RegionIn(MexicanStateRegistry())
+// resolves "NL" in a Monterrey CLLI as Nuevo León, and
+// c.RegionIn(CountryRegistry()) resolves "BS" as the Bahamas.
+func (c *CLLI) RegionIn(r *RegionRegistry) (RegionInfo, bool) {
+	return r.Lookup(c.Region)
+}
+
+// Register adds a region, replacing any existing entry with the same code.
+// Returns an error wrapping ErrInvalidRegion if the code is not two uppercase letters.
+func (r *RegionRegistry) Register(info RegionInfo) error {
+	if err := validateRegionFormat(info.Code); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRegion, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.regions[info.Code] = info
+	return nil
+}
+
+// RegisterRegion adds a region to the default registry under code, replacing
+// any existing entry, so strict validation without ParseOptions.Regions and
+// the geographic methods recognize it. info.Code is set to code. Returns an error wrapping
+// ErrInvalidRegion if code is not two uppercase letters.
+func RegisterRegion(code string, info RegionInfo) error {
+	info.Code = code
+	return defaultRegionRegistry.Register(info)
+}
+
+// UnregisterRegion removes a region code from the default registry.
+func UnregisterRegion(code string) {
+	defaultRegionRegistry.Unregister(code)
+}
+
+// Unregister removes a region code from the registry.
+func (r *RegionRegistry) Unregister(code string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.regions, code)
+}
+
+// Lookup returns the metadata for a region code.
+func (r *RegionRegistry) Lookup(code string) (RegionInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.regions[code]
+	return info, ok
+}
+
+// Regions returns every registered region ordered by code.
+func (r *RegionRegistry) Regions() []RegionInfo {
+	r.mu.RLock()
+	regions := make([]RegionInfo, 0, len(r.regions))
+	for _, info := range r.regions {
+		regions = append(regions, info)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Code < regions[j].Code })
+	return regions
+}
+
+// Len returns the number of registered regions.
+func (r *RegionRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.regions)
+}
+
+// memStats estimates the memory held by the registry.
+func (r *RegionRegistry) memStats() DatasetStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry := int64(unsafe.Sizeof("") + unsafe.Sizeof(RegionInfo{}) + mapEntryOverhead)
+
+	bytes := int64(len(r.regions)) * entry
+	for code, info := range r.regions {
+		bytes += int64(len(code) + len(info.Code) + len(info.Name) + len(info.Subdivision) +
+			len(info.CountryCode) + len(info.CountryName) + len(info.TimeZone))
+	}
+	return DatasetStats{Name: "regions", Kind: "table", Entries: len(r.regions), Bytes: bytes}
+}
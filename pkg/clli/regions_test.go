@@ -0,0 +1,295 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDefaultRegionRegistry tests the built-in region metadata
+func TestDefaultRegionRegistry(t *testing.T) {
+	r := DefaultRegionRegistry()
+	assert.Equal(t, len(usStates)+len(usTerritories)+len(usMilitaryRegions)+len(canadianProvinces), r.Len())
+
+	tests := []struct {
+		code     string
+		expected RegionInfo
+	}{
+		{"IL", RegionInfo{Code: "IL", Name: "Illinois", Subdivision: "state", CountryCode: "US", CountryName: "United States", TimeZone: "America/Chicago"}},
+		{"DC", RegionInfo{Code: "DC", Name: "District of Columbia", Subdivision: "district", CountryCode: "US", CountryName: "United States", TimeZone: "America/New_York"}},
+		{"ON", RegionInfo{Code: "ON", Name: "Ontario", Subdivision: "province", CountryCode: "CA", CountryName: "Canada", TimeZone: "America/Toronto"}},
+		{"YT", RegionInfo{Code: "YT", Name: "Yukon", Subdivision: "territory", CountryCode: "CA", CountryName: "Canada", TimeZone: "America/Whitehorse"}},
+		{"PR", RegionInfo{Code: "PR", Name: "Puerto Rico", Subdivision: "territory", CountryCode: "PR", CountryName: "Puerto Rico", TimeZone: "America/Puerto_Rico"}},
+		{"GU", RegionInfo{Code: "GU", Name: "Guam", Subdivision: "territory", CountryCode: "GU", CountryName: "Guam", TimeZone: "Pacific/Guam"}},
+		{"AP", RegionInfo{Code: "AP", Name: "Armed Forces Pacific", Subdivision: "military", CountryCode: "US", CountryName: "United States"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			info, ok := r.Lookup(tt.code)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+
+	regions := r.Regions()
+	require.Len(t, regions, r.Len())
+	assert.Equal(t, "AA", regions[0].Code)
+}
+
+// TestRegionRegistry tests registering and removing regions
+func TestRegionRegistry(t *testing.T) {
+	r, err := NewRegionRegistry(RegionInfo{Code: "ZX", Name: "Lab East"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.Len())
+
+	_, err = NewRegionRegistry(RegionInfo{Code: "zx"})
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+	assert.ErrorIs(t, r.Register(RegionInfo{Code: "ZXY"}), ErrInvalidRegion)
+
+	require.NoError(t, r.Register(RegionInfo{Code: "ZX", Name: "Lab West"}))
+	info, ok := r.Lookup("ZX")
+	require.True(t, ok)
+	assert.Equal(t, "Lab West", info.Name)
+
+	r.Unregister("ZX")
+	_, ok = r.Lookup("ZX")
+	assert.False(t, ok)
+}
+
+// TestRegisteredRegionParsing tests that custom regions are honoured by
+// validation and the geographic methods
+func TestRegisteredRegionParsing(t *testing.T) {
+	_, err := Parse("LABSZX01DS0")
+	require.ErrorIs(t, err, ErrInvalidRegion)
+
+	require.NoError(t, DefaultRegionRegistry().Register(RegionInfo{
+		Code:        "ZX",
+		Name:        "Lab Region",
+		Subdivision: "lab",
+		CountryCode: "US",
+		CountryName: "United States",
+	}))
+	defer DefaultRegionRegistry().Unregister("ZX")
+
+	c, err := Parse("LABSZX01DS0")
+	require.NoError(t, err)
+	assert.Equal(t, "Lab Region", c.StateName())
+	assert.Equal(t, "ZX", c.StateCode())
+	assert.Equal(t, "US", c.CountryCode())
+	assert.Equal(t, "United States", c.CountryName())
+	assert.NoError(t, ValidateRegion("zx", false))
+}
+
+// TestRegisterRegion tests registering regions in the default registry
+func TestRegisterRegion(t *testing.T) {
+	// US territories validate strictly without registration
+	for _, code := range []string{"SNJNPR01DS0", "CHASVI01DS0", "HGTNGU01DS0", "PGPGAS01DS0", "SAIPMP01DS0"} {
+		_, err := Parse(code)
+		assert.NoError(t, err, code)
+	}
+
+	_, err := Parse("LABSZY01DS0")
+	require.ErrorIs(t, err, ErrInvalidRegion)
+
+	require.NoError(t, RegisterRegion("ZY", RegionInfo{Name: "Lab Region", CountryCode: "US", CountryName: "United States"}))
+	defer UnregisterRegion("ZY")
+
+	c, err := Parse("LABSZY01DS0")
+	require.NoError(t, err)
+	assert.Equal(t, "Lab Region", c.StateName())
+	info, ok := DefaultRegionRegistry().Lookup("ZY")
+	require.True(t, ok)
+	assert.Equal(t, "ZY", info.Code)
+
+	assert.ErrorIs(t, RegisterRegion("zy", RegionInfo{}), ErrInvalidRegion)
+
+	UnregisterRegion("ZY")
+	_, err = Parse("LABSZY01DS0")
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+}
+
+// TestParseOptionsRegions tests parsing with a region registry of its own
+func TestParseOptionsRegions(t *testing.T) {
+	regions, err := NewRegionRegistry(append(DefaultRegionRegistry().Regions(),
+		RegionInfo{Code: "ZY", Name: "Lab Region", CountryCode: "US", CountryName: "United States"})...)
+	require.NoError(t, err)
+	opts := &ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true, Regions: regions}
+
+	c, err := ParseWithOptions("labszy01ds0", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "ZY", c.Region)
+	assert.True(t, Validate("LABSZY01DS0", opts).Valid())
+	_, err = ParseWithOptions("CHCGIL01DS0", opts)
+	assert.NoError(t, err)
+
+	// The default registry is unaffected
+	_, err = Parse("LABSZY01DS0")
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+	assert.False(t, Validate("LABSZY01DS0", nil).Valid())
+}
+
+// TestInternationalRegions tests parsing international regions with
+// AllowInternational
+func TestInternationalRegions(t *testing.T) {
+	for _, info := range InternationalRegionRegistry().Regions() {
+		_, ok := DefaultRegionRegistry().Lookup(info.Code)
+		assert.False(t, ok, "%s shadows a domestic region", info.Code)
+	}
+
+	_, err := Parse("LNDNUK01DS0")
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+
+	for _, opts := range []*ParseOptions{
+		{AllowInternational: true},
+		{Strict: true, AllowInternational: true},
+	} {
+		c, err := ParseWithOptions("LNDNUK01DS0", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "GB", c.CountryCode())
+		assert.Equal(t, "United Kingdom", c.CountryName())
+
+		c, err = ParseWithOptions("SNJNPR01DS0", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "Puerto Rico", c.StateName())
+
+		c, err = ParseWithOptions("CHCGZZ01DS0", opts)
+		if opts.Strict {
+			assert.ErrorIs(t, err, ErrInvalidRegion)
+		} else {
+			require.NoError(t, err)
+			assert.False(t, c.FieldValid(ComponentRegion))
+		}
+	}
+
+	// Domestic codes keep their meaning
+	c, err := ParseWithOptions("CHCGIL01DS0", &ParseOptions{AllowInternational: true})
+	require.NoError(t, err)
+	assert.Equal(t, "US", c.CountryCode())
+}
+
+// TestOverseasRegions tests country resolution of US territories and
+// military designations
+func TestOverseasRegions(t *testing.T) {
+	c := MustParse("SNJNPR01DS0")
+	assert.Equal(t, "Puerto Rico", c.StateName())
+	assert.Equal(t, "PR", c.CountryCode())
+	assert.Equal(t, "PR", c.StateCode())
+	assert.Equal(t, "America/Puerto_Rico", c.TimeZone().String())
+
+	c = MustParse("ANDRAP01DS0")
+	assert.Equal(t, "Armed Forces Pacific", c.StateName())
+	assert.Equal(t, "US", c.CountryCode())
+
+	// AE is Armed Forces Europe, with or without international codes
+	c = MustParse("RMSTAE01DS0")
+	assert.Equal(t, "Armed Forces Europe", c.StateName())
+	assert.Equal(t, "US", c.CountryCode())
+
+	// AE remains reachable as the United Arab Emirates
+	c, err := ParseWithOptions("DUBIAE01DS0", &ParseOptions{AllowInternational: true})
+	require.NoError(t, err)
+	assert.Equal(t, "US", c.CountryCode())
+	info, ok := c.RegionIn(CountryRegistry())
+	require.True(t, ok)
+	assert.Equal(t, "AE", info.CountryCode)
+	assert.Equal(t, "United Arab Emirates", info.CountryName)
+	assert.Equal(t, "Asia/Dubai", info.TimeZone)
+}
+
+// TestNorthAmericanRegions tests country resolution of Mexican states and
+// Caribbean countries
+func TestNorthAmericanRegions(t *testing.T) {
+	opts := &ParseOptions{AllowInternational: true}
+	tests := []struct {
+		input   string
+		country string
+		state   string
+		zone    string
+	}{
+		{"GDLJJA01DS0", "MX", "Jalisco", "America/Mexico_City"},
+		{"CHIHCH01DS0", "MX", "Chihuahua", "America/Chihuahua"},
+		{"ZCTCZA01DS0", "MX", "Zacatecas", "America/Mexico_City"},
+		{"LAPZBS01DS0", "MX", "Baja California Sur", "America/Mazatlan"},
+		{"HRMSSO01DS0", "MX", "Sonora", "America/Hermosillo"},
+		{"CNCNQR01DS0", "MX", "Quintana Roo", "America/Cancun"},
+		{"MXCYMX01DS0", "MX", "Mexico", "America/Mexico_City"},
+		{"KGTNJM01DS0", "JM", "Jamaica", "America/Jamaica"},
+		{"SNDODO01DS0", "DO", "Dominican Republic", "America/Santo_Domingo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := ParseWithOptions(tt.input, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.country, c.CountryCode())
+			assert.Equal(t, tt.state, c.StateName())
+			assert.Equal(t, tt.zone, c.TimeZone().String())
+		})
+	}
+}
+
+// TestMexicanStates tests the Mexican state registry and the precedence of
+// codes shared with domestic regions
+func TestMexicanStates(t *testing.T) {
+	states := MexicanStateRegistry().Regions()
+	require.Len(t, states, 32)
+	for _, info := range states {
+		assert.Equal(t, "MX", info.CountryCode, info.Code)
+		assert.NotEmpty(t, info.TimeZone, info.Code)
+
+		// Every state not shadowed by a domestic region parses as Mexican
+		if _, domestic := DefaultRegionRegistry().Lookup(info.Code); domestic {
+			continue
+		}
+		intl, ok := InternationalRegionRegistry().Lookup(info.Code)
+		require.True(t, ok, info.Code)
+		assert.Equal(t, info, intl)
+	}
+
+	// Domestic codes take precedence over Mexican states unless resolved
+	// explicitly
+	c, err := ParseWithOptions("MTRYNL01DS0", &ParseOptions{AllowInternational: true})
+	require.NoError(t, err)
+	assert.Equal(t, "CA", c.CountryCode())
+	info, ok := c.RegionIn(MexicanStateRegistry())
+	require.True(t, ok)
+	assert.Equal(t, "Nuevo León", info.Name)
+	assert.Equal(t, "America/Monterrey", info.TimeZone)
+}
+
+// TestCountryRegistry tests that countries sharing a code with a state stay
+// reachable
+func TestCountryRegistry(t *testing.T) {
+	for _, info := range CountryRegistry().Regions() {
+		assert.NotEmpty(t, info.CountryCode, info.Code)
+		assert.NotEmpty(t, info.TimeZone, info.Code)
+	}
+
+	tests := []struct {
+		input    string
+		state    string
+		country  string
+		resolved string
+	}{
+		{"NASSBS01DS0", "Baja California Sur", "BS", "Bahamas"},
+		{"STJHAG01DS0", "Aguascalientes", "AG", "Antigua and Barbuda"},
+		{"ZRCHCH01DS0", "Chihuahua", "CH", "Switzerland"},
+		{"CRCSVE01DS0", "Veracruz", "VE", "Venezuela"},
+		{"JHBGZA01DS0", "Zacatecas", "ZA", "South Africa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := ParseWithOptions(tt.input, &ParseOptions{AllowInternational: true})
+			require.NoError(t, err)
+			assert.Equal(t, tt.state, c.StateName())
+
+			info, ok := c.RegionIn(CountryRegistry())
+			require.True(t, ok)
+			assert.Equal(t, tt.country, info.CountryCode)
+			assert.Equal(t, tt.resolved, info.Name)
+		})
+	}
+
+	_, ok := MustParse("CHCGIL01DS0").RegionIn(CountryRegistry())
+	assert.False(t, ok)
+}
@@ -0,0 +1,187 @@
+package clli
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Registry is an in-memory index of parsed CLLIs for inventory caches.
+// Codes are stored once under their formatted code and indexed by place,
+// region, network site, type and entity code prefix, so each query is a map
+// lookup followed by sorting its results. CLLIs must not be modified after
+// they are added. A Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	codes   map[string]*CLLI
+	indexes [indexCount]map[string]map[string]*CLLI // Index -> key -> code -> CLLI
+}
+
+// registryIndex names one index of a Registry.
+type registryIndex int
+
+const (
+	indexPlace registryIndex = iota
+	indexRegion
+	indexNetworkSite
+	indexEntityPrefix
+	indexType
+	indexCount
+)
+
+// registryKey is the key of a CLLI in one index.
+type registryKey struct {
+	index registryIndex
+	key   string
+}
+
+// NewRegistry creates a registry containing the given codes.
+func NewRegistry(codes ...*CLLI) *Registry {
+	r := &Registry{codes: make(map[string]*CLLI, len(codes))}
+	for i := range r.indexes {
+		r.indexes[i] = make(map[string]map[string]*CLLI)
+	}
+	for _, c := range codes {
+		r.Add(c)
+	}
+	return r
+}
+
+// Add stores c, replacing any CLLI with the same code.
+// Reports whether the code was not already present. A nil c is ignored.
+func (r *Registry) Add(c *CLLI) bool {
+	if c == nil {
+		return false
+	}
+	code := c.Format()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, exists := r.codes[code]
+	if exists {
+		r.unindex(code, old)
+	}
+	r.codes[code] = c
+	for _, k := range registryKeys(c) {
+		set := r.indexes[k.index][k.key]
+		if set == nil {
+			set = make(map[string]*CLLI)
+			r.indexes[k.index][k.key] = set
+		}
+		set[code] = c
+	}
+	return !exists
+}
+
+// Remove deletes the CLLI with the given code, ignoring case and
+// surrounding whitespace. Reports whether it was present.
+func (r *Registry) Remove(code string) bool {
+	code = NormalizeForCompare(code)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.codes[code]
+	if !ok {
+		return false
+	}
+	delete(r.codes, code)
+	r.unindex(code, c)
+	return true
+}
+
+// Get returns the CLLI with the given code, ignoring case and surrounding
+// whitespace.
+func (r *Registry) Get(code string) (*CLLI, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.codes[NormalizeForCompare(code)]
+	return c, ok
+}
+
+// Len returns the number of stored CLLIs.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.codes)
+}
+
+// Snapshot returns every stored CLLI ordered by code. The slice is a copy
+// and is not affected by later changes to the registry.
+func (r *Registry) Snapshot() []*CLLI {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedSet(r.codes)
+}
+
+// ByPlace returns the CLLIs at a place in a region, ordered by code.
+func (r *Registry) ByPlace(place, region string) []*CLLI {
+	return r.lookup(indexPlace, NormalizeForCompare(place)+NormalizeForCompare(region))
+}
+
+// ByRegion returns the CLLIs in a region, ordered by code.
+func (r *Registry) ByRegion(region string) []*CLLI {
+	return r.lookup(indexRegion, NormalizeForCompare(region))
+}
+
+// ByNetworkSite returns the CLLIs at a building, given by its place, region
+// and network site, ordered by code.
+func (r *Registry) ByNetworkSite(place, region, site string) []*CLLI {
+	return r.lookup(indexNetworkSite, NormalizeForCompare(place)+NormalizeForCompare(region)+NormalizeForCompare(site))
+}
+
+// ByEntityPrefix returns the CLLIs whose entity code starts with prefix,
+// ordered by code. For example "DS" returns every DS switch entity.
+func (r *Registry) ByEntityPrefix(prefix string) []*CLLI {
+	return r.lookup(indexEntityPrefix, NormalizeForCompare(prefix))
+}
+
+// ByType returns the CLLIs of type t, ordered by code.
+func (r *Registry) ByType(t CLLIType) []*CLLI {
+	return r.lookup(indexType, t.String())
+}
+
+// lookup returns the CLLIs stored under key in an index.
+func (r *Registry) lookup(index registryIndex, key string) []*CLLI {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedSet(r.indexes[index][key])
+}
+
+// unindex removes c, stored under code, from every index.
+func (r *Registry) unindex(code string, c *CLLI) {
+	for _, k := range registryKeys(c) {
+		delete(r.indexes[k.index][k.key], code)
+		if len(r.indexes[k.index][k.key]) == 0 {
+			delete(r.indexes[k.index], k.key)
+		}
+	}
+}
+
+// registryKeys returns the index keys of c. Entity codes are indexed under
+// each of their prefixes.
+func registryKeys(c *CLLI) []registryKey {
+	keys := []registryKey{
+		{indexPlace, c.Place + c.Region},
+		{indexRegion, c.Region},
+		{indexType, c.Type().String()},
+	}
+	if c.NetworkSite != "" {
+		keys = append(keys, registryKey{indexNetworkSite, c.Place + c.Region + c.NetworkSite})
+	}
+	for i := 1; i <= len(c.EntityCode); i++ {
+		keys = append(keys, registryKey{indexEntityPrefix, c.EntityCode[:i]})
+	}
+	return keys
+}
+
+// sortedSet returns the CLLIs in a set ordered by code.
+func sortedSet(set map[string]*CLLI) []*CLLI {
+	out := make([]*CLLI, 0, len(set))
+	for _, c := range set {
+		out = append(out, c)
+	}
+	slices.SortFunc(out, func(a, b *CLLI) int { return strings.Compare(a.Format(), b.Format()) })
+	return out
+}
@@ -0,0 +1,39 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistry tests adding, querying and removing CLLIs
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(parseAll(t, "CHCGIL01DS0", "CHCGIL01DS1", "CHCGIL02MG0", "CHCGILB1234", "DLLSTX01DS0", "DLLSTX12")...)
+	assert.Equal(t, 6, r.Len())
+
+	assert.Equal(t, []string{"CHCGIL01DS0", "CHCGIL01DS1", "CHCGIL02MG0", "CHCGILB1234"}, formatAll(r.ByPlace("chcg", "il")))
+	assert.Equal(t, []string{"DLLSTX01DS0", "DLLSTX12"}, formatAll(r.ByRegion("TX")))
+	assert.Equal(t, []string{"CHCGIL01DS0", "CHCGIL01DS1"}, formatAll(r.ByNetworkSite("CHCG", "IL", "01")))
+	assert.Equal(t, []string{"CHCGIL01DS0", "CHCGIL01DS1", "DLLSTX01DS0"}, formatAll(r.ByEntityPrefix("DS")))
+	assert.Equal(t, []string{"CHCGIL01DS1"}, formatAll(r.ByEntityPrefix("DS1")))
+	assert.Equal(t, []string{"CHCGILB1234", "DLLSTX12"}, formatAll(r.ByType(CLLITypeNonBuilding)))
+	assert.Empty(t, r.ByPlace("NYCM", "NY"))
+
+	c, ok := r.Get(" chcgil01ds0 ")
+	require.True(t, ok)
+	assert.Equal(t, "CHCGIL01DS0", c.Format())
+
+	// Replacing a code keeps a single entry
+	assert.False(t, r.Add(MustParse("CHCGIL01DS0")))
+	assert.Equal(t, 6, r.Len())
+	assert.False(t, r.Add(nil))
+
+	snapshot := r.Snapshot()
+	assert.True(t, r.Remove("chcgil01ds0"))
+	assert.False(t, r.Remove("CHCGIL01DS0"))
+	assert.Len(t, snapshot, 6)
+	assert.Equal(t, 5, r.Len())
+	assert.Equal(t, []string{"CHCGIL01DS1"}, formatAll(r.ByNetworkSite("CHCG", "IL", "01")))
+	assert.Equal(t, []string{"CHCGIL01DS1", "DLLSTX01DS0"}, formatAll(r.ByEntityPrefix("D")))
+}
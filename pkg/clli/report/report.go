@@ -0,0 +1,250 @@
+// Package report renders the results of a batch parse as audit reports:
+// counts of the parsed codes by type and region, and the records that
+// failed with the reason for each. A report renders as an aligned text
+// table, Markdown or a standalone HTML page:
+//
+//	codes, errs := clli.ParseBatch(inputs, nil)
+//	r := report.New("Nightly inventory", inputs, codes, errs)
+//	if err := r.Markdown(os.Stdout); err != nil {
+//		return err
+//	}
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// DefaultTitle is the title of reports created with an empty title.
+const DefaultTitle = "CLLI batch report"
+
+// ErrUnknownFormat is returned by Render for unknown format names.
+var ErrUnknownFormat = errors.New("report: unknown format")
+
+// Count is the number of codes in one group, such as a type or region.
+type Count struct {
+	Name  string
+	Count int
+}
+
+// Failure is a record that failed to parse.
+type Failure struct {
+	Record int    // 1-based position of the record in the batch
+	Input  string // Record as given
+	Field  string // ParseError field of the failure, or "" for other errors
+	Reason string // Description of the failure
+}
+
+// Report summarizes a batch parse.
+type Report struct {
+	Title    string
+	Total    int       // Number of records
+	Valid    int       // Records that parsed
+	Invalid  int       // Records that failed to parse
+	Types    []Count   // Parsed codes by type, in CLLIType order
+	Regions  []Count   // Parsed codes by region, most frequent first
+	Failures []Failure // Failed records, in batch order
+}
+
+// New builds a report from the results of clli.ParseBatch for inputs.
+// Exactly one of codes[i] and errs[i] is expected to be non-nil, as
+// ParseBatch returns them. An empty title selects DefaultTitle.
+func New(title string, inputs []string, codes []*clli.CLLI, errs []error) *Report {
+	summary := clli.SummarizeBatch(codes, errs)
+	r := &Report{
+		Title:   cmp.Or(title, DefaultTitle),
+		Total:   summary.Total,
+		Valid:   summary.Valid,
+		Invalid: summary.Invalid,
+	}
+
+	types := make([]clli.CLLIType, 0, len(summary.ByType))
+	for t := range summary.ByType {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	for _, t := range types {
+		r.Types = append(r.Types, Count{Name: t.String(), Count: summary.ByType[t]})
+	}
+
+	regions := map[string]int{}
+	for i := range summary.Total {
+		var c *clli.CLLI
+		var err error
+		if i < len(codes) {
+			c = codes[i]
+		}
+		if i < len(errs) {
+			err = errs[i]
+		}
+		if err == nil && c != nil {
+			regions[c.Region]++
+			continue
+		}
+
+		f := Failure{Record: i + 1}
+		if i < len(inputs) {
+			f.Input = inputs[i]
+		}
+		var pe *clli.ParseError
+		switch {
+		case errors.As(err, &pe):
+			f.Field, f.Reason = pe.Field, pe.Err.Error()
+			if f.Input == "" {
+				f.Input = pe.Input
+			}
+		case err != nil:
+			f.Reason = err.Error()
+		default:
+			f.Reason = "no result"
+		}
+		r.Failures = append(r.Failures, f)
+	}
+	for region, n := range regions {
+		r.Regions = append(r.Regions, Count{Name: region, Count: n})
+	}
+	slices.SortFunc(r.Regions, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return r
+}
+
+// Render writes the report in the named format: "text", "markdown" or
+// "html".
+func (r *Report) Render(w io.Writer, format string) error {
+	switch format {
+	case "text":
+		return r.Text(w)
+	case "markdown":
+		return r.Markdown(w)
+	case "html":
+		return r.HTML(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Text writes the report as plain text with aligned columns.
+func (r *Report) Text(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\n\n", r.Title)
+	fmt.Fprintf(tw, "Total: %d\tValid: %d\tInvalid: %d\n", r.Total, r.Valid, r.Invalid)
+
+	section := func(header string, rows [][]string) {
+		if len(rows) == 0 {
+			return
+		}
+		fmt.Fprintf(tw, "\n%s\n", header)
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+	section("TYPE\tCOUNT", countRows(r.Types))
+	section("REGION\tCOUNT", countRows(r.Regions))
+	section("RECORD\tINPUT\tFIELD\tREASON", r.failureRows())
+	return tw.Flush()
+}
+
+// Markdown writes the report as a Markdown heading, summary and tables.
+func (r *Report) Markdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	fmt.Fprintf(&b, "%d records: %d valid, %d invalid.\n", r.Total, r.Valid, r.Invalid)
+
+	table := func(heading string, header []string, rows [][]string) {
+		if len(rows) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		fmt.Fprintf(&b, "| %s |\n|%s\n", strings.Join(header, " | "), strings.Repeat(" --- |", len(header)))
+		for _, row := range rows {
+			for i, cell := range row {
+				row[i] = markdownEscape(cell)
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(row, " | "))
+		}
+	}
+	table("By type", []string{"Type", "Count"}, countRows(r.Types))
+	table("By region", []string{"Region", "Count"}, countRows(r.Regions))
+	table("Invalid records", []string{"Record", "Input", "Field", "Reason"}, r.failureRows())
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// HTML writes the report as a standalone HTML page.
+func (r *Report) HTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+// countRows returns counts as table rows.
+func countRows(counts []Count) [][]string {
+	rows := make([][]string, len(counts))
+	for i, c := range counts {
+		rows[i] = []string{c.Name, fmt.Sprint(c.Count)}
+	}
+	return rows
+}
+
+// failureRows returns the failures as table rows.
+func (r *Report) failureRows() [][]string {
+	rows := make([][]string, len(r.Failures))
+	for i, f := range r.Failures {
+		rows[i] = []string{fmt.Sprint(f.Record), f.Input, f.Field, f.Reason}
+	}
+	return rows
+}
+
+// markdownEscape escapes characters with special meaning in Markdown tables.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "[", `\[`, "]", `\]`, "*", `\*`).Replace(s)
+}
+
+// htmlTemplate renders a Report as a standalone HTML page.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Total}} records: {{.Valid}} valid, {{.Invalid}} invalid.</p>
+{{- if .Types}}
+<h2>By type</h2>
+<table>
+<tr><th>Type</th><th>Count</th></tr>
+{{- range .Types}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Regions}}
+<h2>By region</h2>
+<table>
+<tr><th>Region</th><th>Count</th></tr>
+{{- range .Regions}}
+<tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Failures}}
+<h2>Invalid records</h2>
+<table>
+<tr><th>Record</th><th>Input</th><th>Field</th><th>Reason</th></tr>
+{{- range .Failures}}
+<tr><td>{{.Record}}</td><td>{{.Input}}</td><td>{{.Field}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
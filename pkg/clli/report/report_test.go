@@ -0,0 +1,58 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dbitech/go-clli/pkg/clli"
+)
+
+// newTestReport parses a small batch with one invalid record
+func newTestReport(t *testing.T) *Report {
+	t.Helper()
+	inputs := []string{"CHCGIL01DS0", "CHCGZZ01DS0", "DLLSTXB1234", "CHCGIL02CG0"}
+	codes, errs := clli.ParseBatch(inputs, nil)
+	return New("", inputs, codes, errs)
+}
+
+// TestNew tests counting a batch by type and region
+func TestNew(t *testing.T) {
+	r := newTestReport(t)
+	assert.Equal(t, DefaultTitle, r.Title)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, 3, r.Valid)
+	assert.Equal(t, 1, r.Invalid)
+	assert.Equal(t, []Count{{"Entity", 2}, {"NonBuilding", 1}}, r.Types)
+	assert.Equal(t, []Count{{"IL", 2}, {"TX", 1}}, r.Regions)
+
+	require.Len(t, r.Failures, 1)
+	assert.Equal(t, 2, r.Failures[0].Record)
+	assert.Equal(t, "CHCGZZ01DS0", r.Failures[0].Input)
+	assert.Equal(t, "region", r.Failures[0].Field)
+}
+
+// TestRender tests rendering a report in every format
+func TestRender(t *testing.T) {
+	r := newTestReport(t)
+	r.Title = "Audit <nightly>"
+
+	var b strings.Builder
+	require.NoError(t, r.Render(&b, "text"))
+	assert.Contains(t, b.String(), "Total: 4  Valid: 3  Invalid: 1\n")
+	assert.Contains(t, b.String(), "2       CHCGZZ01DS0  region")
+
+	b.Reset()
+	require.NoError(t, r.Render(&b, "markdown"))
+	assert.True(t, strings.HasPrefix(b.String(), "# Audit <nightly>\n\n4 records: 3 valid, 1 invalid.\n"))
+	assert.Contains(t, b.String(), "| Region | Count |\n| --- | --- |\n| IL | 2 |\n| TX | 1 |\n")
+
+	b.Reset()
+	require.NoError(t, r.Render(&b, "html"))
+	assert.Contains(t, b.String(), "<h1>Audit &lt;nightly&gt;</h1>")
+	assert.Contains(t, b.String(), "<tr><td>2</td><td>CHCGZZ01DS0</td><td>region</td>")
+
+	assert.ErrorIs(t, r.Render(&b, "pdf"), ErrUnknownFormat)
+}
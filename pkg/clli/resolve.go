@@ -0,0 +1,59 @@
+package clli
+
+import "context"
+
+// Context-aware geographic resolution
+// These variants of the geographic methods honor context cancellation and
+// deadlines, so enrichment backed by slow datasets cannot hang request paths.
+
+// ResolveCity is the context-aware variant of CityName.
+// Returns an empty string and no error if the place code is unknown, or
+// ctx.Err() if the context ends before the lookup completes.
+func (c *CLLI) ResolveCity(ctx context.Context) (string, error) {
+	return DefaultGeoResolver().City(ctx, c.Place, c.Region)
+}
+
+// ResolveStateName is the context-aware variant of StateName.
+// Returns ctx.Err() if the context ends before the lookup completes.
+func (c *CLLI) ResolveStateName(ctx context.Context) (string, error) {
+	return withContext(ctx, func() (string, error) {
+		return getStateName(c.Region), nil
+	})
+}
+
+// ResolveCountryName is the context-aware variant of CountryName.
+// Returns ctx.Err() if the context ends before the lookup completes.
+func (c *CLLI) ResolveCountryName(ctx context.Context) (string, error) {
+	return withContext(ctx, func() (string, error) {
+		return getCountryName(c.Region), nil
+	})
+}
+
+// withContext runs fn, abandoning it if ctx ends first.
+// Contexts that can never be cancelled run fn inline without a goroutine.
+func withContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	if ctx.Done() == nil {
+		return fn()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
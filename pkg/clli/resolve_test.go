@@ -0,0 +1,65 @@
+package clli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolveWithContext tests the context-aware geographic methods
+func TestResolveWithContext(t *testing.T) {
+	c := MustParse("CHCGIL01DS0")
+
+	t.Run("Background context", func(t *testing.T) {
+		city, err := c.ResolveCity(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Chicago", city)
+
+		state, err := c.ResolveStateName(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Illinois", state)
+
+		country, err := c.ResolveCountryName(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "United States", country)
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		city, err := c.ResolveCity(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Chicago", city)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		city, err := c.ResolveCity(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, city)
+	})
+}
+
+// TestWithContextAbandonsSlowLookups tests that a slow lookup cannot outlive its deadline
+func TestWithContextAbandonsSlowLookups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	_, err := withContext(ctx, func() (string, error) {
+		<-release
+		return "late", nil
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,334 @@
+package clli
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// PlaceRecord describes a place code within a region.
+type PlaceRecord struct {
+	Place  string // 4-character place code
+	Region string // 2-character region code
+	City   string // City or locality name
+
+	// Latitude and Longitude locate the place in decimal degrees. They are
+	// meaningful only when HasCoordinates is set.
+	Latitude       float64
+	Longitude      float64
+	HasCoordinates bool
+
+	// Dataset and Snapshot identify the dataset the record was found in.
+	// They are filled in by lookups and ignored by NewDataset.
+	Dataset  string
+	Snapshot string
+}
+
+// placeKey identifies a place record by place and region.
+type placeKey struct {
+	place, region string
+}
+
+// Dataset is a named, immutable table of place records.
+type Dataset struct {
+	name     string
+	snapshot string
+	places   map[placeKey]PlaceRecord
+}
+
+// NewDataset creates a dataset from records. Place and region codes are
+// normalized to uppercase; later records replace earlier ones with the same key.
+func NewDataset(name string, records []PlaceRecord) *Dataset {
+	return NewDatasetSnapshot(name, "", records)
+}
+
+// NewDatasetSnapshot creates a dataset for a dated snapshot of a source,
+// such as "2024-06". Snapshots of the same dataset share its name and are
+// ordered by comparing their snapshot strings, so use sortable dates.
+func NewDatasetSnapshot(name, snapshot string, records []PlaceRecord) *Dataset {
+	d := &Dataset{
+		name:     name,
+		snapshot: snapshot,
+		places:   make(map[placeKey]PlaceRecord, len(records)),
+	}
+	for _, r := range records {
+		r.Place = strings.ToUpper(strings.TrimRight(r.Place, " "))
+		r.Region = strings.ToUpper(r.Region)
+		r.Dataset, r.Snapshot = "", ""
+		d.places[placeKey{r.Place, r.Region}] = r
+	}
+	return d
+}
+
+// Name returns the dataset name.
+func (d *Dataset) Name() string {
+	return d.name
+}
+
+// Snapshot returns the dataset snapshot version, or "" if it is unversioned.
+func (d *Dataset) Snapshot() string {
+	return d.snapshot
+}
+
+// Len returns the number of records in the dataset.
+func (d *Dataset) Len() int {
+	return len(d.places)
+}
+
+// Lookup returns the record for a place and region.
+func (d *Dataset) Lookup(place, region string) (PlaceRecord, bool) {
+	r, ok := d.places[placeKey{strings.TrimRight(place, " "), region}]
+	if ok {
+		r.Dataset, r.Snapshot = d.name, d.snapshot
+	}
+	return r, ok
+}
+
+// builtinDataset holds the built-in city mappings.
+var builtinDataset = func() *Dataset {
+	var records []PlaceRecord
+	for place, regions := range cityMappings {
+		for region, city := range regions {
+			records = append(records, PlaceRecord{Place: place, Region: region, City: city})
+		}
+	}
+	return NewDataset("builtin", records)
+}()
+
+// Resolver performs geographic enrichment of CLLI codes from loaded datasets.
+// Datasets are consulted in the order they were added. A Resolver is safe for
+// concurrent use.
+type Resolver struct {
+	mu        sync.RWMutex
+	datasets  []*Dataset
+	snapshots map[string][]*Dataset // Available snapshots by dataset name
+	pins      map[string]string     // Pinned snapshot by dataset name
+	cache     Cache
+	cacheTTL  time.Duration
+	switches  SwitchResolver // Switch lookups, see SetSwitchResolver
+}
+
+// NewResolver creates a Resolver consulting the given datasets in order.
+func NewResolver(datasets ...*Dataset) *Resolver {
+	return &Resolver{datasets: datasets}
+}
+
+var defaultResolver = func() *Resolver {
+	if d := embeddedDataset(); d != nil {
+		return NewResolver(builtinDataset, d)
+	}
+	return NewResolver(builtinDataset)
+}()
+
+// DefaultResolver returns the Resolver backed by the built-in datasets and,
+// unless built with the clli_nogeodata tag, the embedded place dataset.
+func DefaultResolver() *Resolver {
+	return defaultResolver
+}
+
+// AddDataset appends a dataset, consulted after those already loaded.
+func (r *Resolver) AddDataset(d *Dataset) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.datasets = append(r.datasets, d)
+}
+
+// AddSnapshot makes a dataset snapshot available. The first snapshot of a
+// dataset is appended to the lookup order; afterwards the dataset serves the
+// latest available snapshot unless a snapshot has been pinned with Pin.
+func (r *Resolver) AddSnapshot(d *Dataset) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.snapshots == nil {
+		r.snapshots = make(map[string][]*Dataset)
+	}
+	r.snapshots[d.name] = append(r.snapshots[d.name], d)
+
+	for i, active := range r.datasets {
+		if active.name != d.name {
+			continue
+		}
+		if _, pinned := r.pins[d.name]; !pinned && d.snapshot > active.snapshot {
+			r.datasets[i] = d
+		}
+		return
+	}
+	r.datasets = append(r.datasets, d)
+}
+
+// Pin fixes the named dataset to one of its available snapshots, so that
+// enrichment results stay reproducible as newer snapshots are added.
+// Returns an error if no such snapshot has been added.
+//
+// Entries already stored in a cache installed with SetCache are not
+// invalidated; use a cache whose contents match the pinned snapshot.
+func (r *Resolver) Pin(name, snapshot string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pinned *Dataset
+	for _, d := range r.snapshots[name] {
+		if d.snapshot == snapshot {
+			pinned = d
+		}
+	}
+	if pinned == nil {
+		return newMessageError(MsgDatasetSnapshot, name, snapshot)
+	}
+
+	for i, active := range r.datasets {
+		if active.name == name {
+			r.datasets[i] = pinned
+		}
+	}
+	if r.pins == nil {
+		r.pins = make(map[string]string)
+	}
+	r.pins[name] = snapshot
+	return nil
+}
+
+// Unpin releases a pinned dataset, which then serves its latest snapshot.
+func (r *Resolver) Unpin(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pins, name)
+	latest := latestSnapshot(r.snapshots[name])
+	if latest == nil {
+		return
+	}
+	for i, active := range r.datasets {
+		if active.name == name {
+			r.datasets[i] = latest
+		}
+	}
+}
+
+// Pinned returns the snapshot the named dataset is pinned to, if any.
+func (r *Resolver) Pinned(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot, ok := r.pins[name]
+	return snapshot, ok
+}
+
+// Snapshots returns the snapshot in use for each loaded dataset, keyed by
+// dataset name, for recording alongside enrichment output.
+func (r *Resolver) Snapshots() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make(map[string]string, len(r.datasets))
+	for _, d := range r.datasets {
+		versions[d.name] = d.snapshot
+	}
+	return versions
+}
+
+// latestSnapshot returns the snapshot with the greatest version.
+func latestSnapshot(snapshots []*Dataset) *Dataset {
+	var latest *Dataset
+	for _, d := range snapshots {
+		if latest == nil || d.snapshot > latest.snapshot {
+			latest = d
+		}
+	}
+	return latest
+}
+
+// SetCache installs a cache consulted before the datasets, with entries
+// stored for ttl. Passing a nil cache disables caching.
+func (r *Resolver) SetCache(c Cache, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache, r.cacheTTL = c, ttl
+}
+
+// Datasets returns the loaded datasets in lookup order.
+func (r *Resolver) Datasets() []*Dataset {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*Dataset(nil), r.datasets...)
+}
+
+// LookupPlace returns the first record for a place and region across all datasets.
+func (r *Resolver) LookupPlace(place, region string) (PlaceRecord, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.datasets {
+		if rec, ok := d.Lookup(place, region); ok {
+			return rec, true
+		}
+	}
+	return PlaceRecord{}, false
+}
+
+// Coordinates returns the approximate latitude and longitude of a place
+// from the first dataset that records them.
+func (r *Resolver) Coordinates(place, region string) (lat, lon float64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.datasets {
+		if rec, found := d.Lookup(place, region); found && rec.HasCoordinates {
+			return rec.Latitude, rec.Longitude, true
+		}
+	}
+	return 0, 0, false
+}
+
+// City returns the city for a place and region, or an empty string if unknown.
+// Returns ctx.Err() if the context ends before the lookup completes.
+func (r *Resolver) City(ctx context.Context, place, region string) (string, error) {
+	r.mu.RLock()
+	cache, ttl := r.cache, r.cacheTTL
+	r.mu.RUnlock()
+
+	return withContext(ctx, func() (string, error) {
+		return cachedLookup(ctx, cache, ttl, "city/"+place+"/"+region, func() (string, error) {
+			rec, _ := r.LookupPlace(place, region)
+			return rec.City, nil
+		})
+	})
+}
+
+// DatasetStats reports the approximate memory used by a dataset or index.
+type DatasetStats struct {
+	Name    string // Dataset or index name
+	Kind    string // "dataset", "index" or "table"
+	Entries int    // Number of entries
+	Bytes   int64  // Estimated heap bytes, including map overhead
+}
+
+// mapEntryOverhead approximates the per-entry cost of a Go map beyond its keys and values.
+const mapEntryOverhead = 16
+
+// MemStats reports the estimated memory held by each loaded dataset and
+// by the default region registry, for sizing enrichment services.
+// Figures are estimates derived from string lengths and map overhead.
+func (r *Resolver) MemStats() []DatasetStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := make([]DatasetStats, 0, len(r.datasets)+1)
+	for _, d := range r.datasets {
+		stats = append(stats, d.memStats())
+	}
+	return append(stats, defaultRegionRegistry.memStats())
+}
+
+// memStats estimates the memory held by the dataset.
+func (d *Dataset) memStats() DatasetStats {
+	entry := int64(unsafe.Sizeof(placeKey{}) + unsafe.Sizeof(PlaceRecord{}) + mapEntryOverhead)
+
+	bytes := int64(len(d.places)) * entry
+	for k, rec := range d.places {
+		bytes += int64(len(k.place) + len(k.region) + len(rec.Place) + len(rec.Region) + len(rec.City))
+	}
+	return DatasetStats{Name: d.name, Kind: "dataset", Entries: len(d.places), Bytes: bytes}
+}
@@ -0,0 +1,103 @@
+package clli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolver tests dataset lookups through a Resolver
+func TestResolver(t *testing.T) {
+	lab := NewDataset("lab", []PlaceRecord{
+		{Place: "labx", Region: "il", City: "Lab City"},
+		{Place: "CHCG", Region: "IL", City: "Chicago Lab"},
+	})
+	r := NewResolver(builtinDataset, lab)
+
+	city, err := r.City(context.Background(), "LABX", "IL")
+	require.NoError(t, err)
+	assert.Equal(t, "Lab City", city)
+
+	city, err = r.City(context.Background(), "CHCG", "IL")
+	require.NoError(t, err)
+	assert.Equal(t, "Chicago", city, "earlier datasets take precedence")
+
+	city, err = r.City(context.Background(), "NOPE", "IL")
+	require.NoError(t, err)
+	assert.Empty(t, city)
+
+	assert.Len(t, r.Datasets(), 2)
+	assert.Equal(t, "lab", r.Datasets()[1].Name())
+	assert.Equal(t, 2, lab.Len())
+}
+
+// TestResolverMemStats tests memory reporting for loaded datasets
+func TestResolverMemStats(t *testing.T) {
+	r := NewResolver(builtinDataset)
+	r.AddDataset(NewDataset("empty", nil))
+
+	stats := r.MemStats()
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, "builtin", stats[0].Name)
+	assert.Equal(t, "dataset", stats[0].Kind)
+	assert.Equal(t, builtinDataset.Len(), stats[0].Entries)
+	assert.Positive(t, stats[0].Bytes)
+
+	assert.Equal(t, "empty", stats[1].Name)
+	assert.Zero(t, stats[1].Entries)
+	assert.Zero(t, stats[1].Bytes)
+
+	assert.Equal(t, "regions", stats[2].Name)
+	assert.Equal(t, DefaultRegionRegistry().Len(), stats[2].Entries)
+	assert.Positive(t, stats[2].Bytes)
+}
+
+// TestResolverSnapshots tests loading and pinning dated dataset snapshots
+func TestResolverSnapshots(t *testing.T) {
+	ctx := context.Background()
+	jan := NewDatasetSnapshot("lerg", "2024-01", []PlaceRecord{{Place: "LABX", Region: "IL", City: "Old Town"}})
+	jun := NewDatasetSnapshot("lerg", "2024-06", []PlaceRecord{{Place: "LABX", Region: "IL", City: "New Town"}})
+
+	r := NewResolver(builtinDataset)
+	r.AddSnapshot(jan)
+	r.AddSnapshot(jun)
+
+	t.Run("Latest snapshot by default", func(t *testing.T) {
+		rec, ok := r.LookupPlace("LABX", "IL")
+		require.True(t, ok)
+		assert.Equal(t, PlaceRecord{Place: "LABX", Region: "IL", City: "New Town", Dataset: "lerg", Snapshot: "2024-06"}, rec)
+		assert.Equal(t, map[string]string{"builtin": "", "lerg": "2024-06"}, r.Snapshots())
+		assert.Len(t, r.Datasets(), 2)
+	})
+
+	t.Run("Pinned snapshot", func(t *testing.T) {
+		require.NoError(t, r.Pin("lerg", "2024-01"))
+		city, err := r.City(ctx, "LABX", "IL")
+		require.NoError(t, err)
+		assert.Equal(t, "Old Town", city)
+
+		pinned, ok := r.Pinned("lerg")
+		assert.True(t, ok)
+		assert.Equal(t, "2024-01", pinned)
+
+		// Newer snapshots do not displace a pin
+		r.AddSnapshot(NewDatasetSnapshot("lerg", "2024-09", nil))
+		rec, _ := r.LookupPlace("LABX", "IL")
+		assert.Equal(t, "2024-01", rec.Snapshot)
+	})
+
+	t.Run("Unpin", func(t *testing.T) {
+		r.Unpin("lerg")
+		_, ok := r.Pinned("lerg")
+		assert.False(t, ok)
+		assert.Equal(t, "2024-09", r.Snapshots()["lerg"])
+	})
+
+	t.Run("Unknown snapshot", func(t *testing.T) {
+		assert.Error(t, r.Pin("lerg", "2023-12"))
+		assert.Error(t, r.Pin("missing", "2024-01"))
+	})
+}
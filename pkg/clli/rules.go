@@ -0,0 +1,229 @@
+package clli
+
+import "encoding/json"
+
+// RuleLevel states when a validation rule is enforced.
+type RuleLevel string
+
+// Rule enforcement levels
+const (
+	// RuleAlways rules are enforced in both strict and non-strict parsing
+	RuleAlways RuleLevel = "always"
+
+	// RuleStrict rules are enforced only when ParseOptions.Strict is set
+	RuleStrict RuleLevel = "strict"
+
+	// RuleOption rules are enforced only when the ParseOptions field named
+	// by Rule.Option is set
+	RuleOption RuleLevel = "option"
+)
+
+// Rule describes one validation rule enforced by the parser, for building
+// help text and client-side pre-validation from the library itself.
+type Rule struct {
+	ID          string    `json:"id"`                 // Stable rule identifier
+	Component   string    `json:"component"`          // ParseError field reported when the rule fails
+	Level       RuleLevel `json:"level"`              // When the rule is enforced
+	Option      string    `json:"option,omitempty"`   // ParseOptions field enabling a RuleOption rule
+	Description string    `json:"description"`        // Human-readable statement of the rule
+	Citation    string    `json:"citation,omitempty"` // Specification the rule derives from
+	Pass        []string  `json:"pass"`               // Inputs satisfying the rule
+	Fail        []string  `json:"fail"`               // Inputs violating the rule
+}
+
+// specCitation is the specification most rules derive from.
+const specCitation = "Bell System Practices Section 795-100-100"
+
+// rules lists every validation rule in the order the parser checks them.
+var rules = []Rule{
+	{
+		ID:          "input.non_empty",
+		Component:   "input",
+		Level:       RuleAlways,
+		Description: "Input must contain at least one non-whitespace character.",
+		Pass:        []string{"CHCGIL01DS0"},
+		Fail:        []string{"", "   "},
+	},
+	{
+		ID:          "length.min",
+		Component:   "length",
+		Level:       RuleAlways,
+		Description: "Input must be at least 4 characters long (the place code).",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01"},
+		Fail:        []string{"CHC"},
+	},
+	{
+		ID:          "length.min_strict",
+		Component:   "length",
+		Level:       RuleStrict,
+		Description: "Input must be at least 8 characters long (place, region and network site).",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01"},
+		Fail:        []string{"CHCGIL0"},
+	},
+	{
+		ID:          "length.max",
+		Component:   "length",
+		Level:       RuleAlways,
+		Description: "Input must be at most 15 characters long (the customer CLLI format).",
+		Citation:    specCitation,
+		Pass:        []string{"DLLSTX011234567"},
+		Fail:        []string{"DLLSTX0112345678"},
+	},
+	{
+		ID:        "length.structure",
+		Component: "length",
+		Level:     RuleOption,
+		Option:    "StrictStructure",
+		Description: "Input must have 8 (building), 11 (entity, non-building or customer), " +
+			"12 or 15 (customer) characters.",
+		Citation: specCitation,
+		Pass:     []string{"CHCGIL01", "CHCGIL01DS0", "MPLSMN1A2345", "DLLSTX011234567"},
+		Fail:     []string{"CHCGIL1A23456", "DLLSTXB123456"},
+	},
+	{
+		ID:          "characters.alphanumeric",
+		Component:   "characters",
+		Level:       RuleAlways,
+		Description: "Input may contain only the letters A-Z and digits 0-9.",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01DS0"},
+		Fail:        []string{"CHCG-L01DS0", "CHCGIL01DS*"},
+	},
+	{
+		ID:          "place.letters",
+		Component:   "place",
+		Level:       RuleAlways,
+		Description: "The place code (characters 1-4) must be four letters.",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01DS0"},
+		Fail:        []string{"CHC1IL01DS0"},
+	},
+	{
+		ID:          "region.letters",
+		Component:   "region",
+		Level:       RuleAlways,
+		Description: "The region code (characters 5-6) must be two letters.",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01DS0"},
+		Fail:        []string{"CHCG1L01DS0"},
+	},
+	{
+		ID:        "region.registered",
+		Component: "region",
+		Level:     RuleStrict,
+		Description: "The region code must be a registered state or province code. " +
+			"Non-strict parsing accepts any two letters, and AllowInternational " +
+			"accepts the international region codes.",
+		Citation: specCitation,
+		Pass:     []string{"CHCGIL01DS0", "TOROON01DS0"},
+		Fail:     []string{"CHCGZZ01DS0"},
+	},
+	{
+		ID:        "place.known",
+		Component: "place",
+		Level:     RuleOption,
+		Option:    "RequireKnownPlace",
+		Description: "The place and region must be listed in the place reference. " +
+			"Non-strict parsing reports the place as invalid instead.",
+		Pass: []string{"CHCGIL01DS0"},
+		Fail: []string{"QQQQIL01DS0"},
+	},
+	{
+		ID:        "network_site.structure",
+		Component: "network_site",
+		Level:     RuleOption,
+		Option:    "StrictStructure",
+		Description: "The network site of a building CLLI without an entity code must be two digits, " +
+			"and that of an entity CLLI two digits or two letters.",
+		Citation: specCitation,
+		Pass:     []string{"CHCGIL01", "CHCGILABDS0"},
+		Fail:     []string{"CHCGILAB", "CHCGILA1"},
+	},
+	{
+		ID:          "location_id.structure",
+		Component:   "location_id",
+		Level:       RuleOption,
+		Option:      "StrictStructure",
+		Description: "The location ID of a non-building CLLI (characters 8-11) must be four digits.",
+		Citation:    specCitation,
+		Pass:        []string{"DLLSTXB1234"},
+		Fail:        []string{"DLLSTXB12345"},
+	},
+	{
+		ID:          "customer_code.structure",
+		Component:   "customer_code",
+		Level:       RuleOption,
+		Option:      "StrictStructure",
+		Description: "The customer code of a customer CLLI must be a digit.",
+		Citation:    specCitation,
+		Pass:        []string{"MPLSMN1A234", "DLLSTX011234567"},
+		Fail:        []string{"DLLSTX01A234567"},
+	},
+	{
+		ID:        "customer_id.structure",
+		Component: "customer_id",
+		Level:     RuleOption,
+		Option:    "StrictStructure",
+		Description: "The customer ID must be a letter and three digits in an 11-character customer CLLI, " +
+			"and six digits after the network site of a 15-character one.",
+		Citation: specCitation,
+		Pass:     []string{"MPLSMN1A234", "DLLSTX011234567"},
+		Fail:     []string{"DLLSTX01123456A"},
+	},
+	{
+		ID:          "customer.tail_12",
+		Component:   "customer_id",
+		Level:       RuleAlways,
+		Description: "A 12-character customer CLLI must end in a customer code digit followed by a letter and four digits.",
+		Citation:    specCitation,
+		Pass:        []string{"MPLSMN1A2345"},
+		Fail:        []string{"MPLSMN1AB345", "MPLSMN123456"},
+	},
+	{
+		ID:        "location_id.assignment",
+		Component: "location_id",
+		Level:     RuleOption,
+		Option:    "ValidateLocationIDs",
+		Description: "The location ID must be assignable under its location code letter, " +
+			"as registered with SetLocationIDRange. Non-strict parsing reports the location ID as invalid instead.",
+		Pass: []string{"DLLSTXB1234"},
+		Fail: []string{"DLLSTXB0000", "DLLSTXC1234"},
+	},
+	{
+		ID:          "network_site.entity",
+		Component:   "network_site",
+		Level:       RuleStrict,
+		Description: "The network site of an entity CLLI (characters 7-8) must be two digits or two letters.",
+		Citation:    specCitation,
+		Pass:        []string{"CHCGIL01DS0", "CHCGILABDS0"},
+		Fail:        []string{"CHCGILA1DS0"},
+	},
+	{
+		ID:          "entity_code.table",
+		Component:   "entity_code",
+		Level:       RuleStrict,
+		Description: "The entity code (characters 9-11) must match a pattern from the Bell entity tables B-E.",
+		Citation:    specCitation + ", Tables B-E",
+		Pass:        []string{"CHCGIL01DS0", "CHCGIL0101B", "CHCGIL011MD", "CHCGIL01Q12"},
+		Fail:        []string{"CHCGIL01QQQ"},
+	},
+}
+
+// Rules returns every validation rule the parser enforces, in the order
+// they are checked. The returned slice is a copy and may be modified.
+func Rules() []Rule {
+	out := make([]Rule, len(rules))
+	for i, r := range rules {
+		r.Pass = append([]string(nil), r.Pass...)
+		r.Fail = append([]string(nil), r.Fail...)
+		out[i] = r
+	}
+	return out
+}
+
+// RulesJSON returns the rule catalog as indented JSON.
+func RulesJSON() ([]byte, error) {
+	return json.MarshalIndent(rules, "", "  ")
+}
@@ -0,0 +1,89 @@
+package clli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRulesExamples checks every example in the rule catalog against the parser
+func TestRulesExamples(t *testing.T) {
+	lenient := &ParseOptions{NormalizeCase: true, TrimWhitespace: true}
+
+	ids := map[string]bool{}
+	for _, rule := range Rules() {
+		t.Run(rule.ID, func(t *testing.T) {
+			assert.False(t, ids[rule.ID], "duplicate rule ID")
+			ids[rule.ID] = true
+			assert.NotEmpty(t, rule.Description)
+			require.NotEmpty(t, rule.Pass)
+			require.NotEmpty(t, rule.Fail)
+
+			// Option rules are checked with their option set on top of
+			// the defaults of Parse
+			strict := &ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true}
+			if rule.Level == RuleOption {
+				field := reflect.ValueOf(strict).Elem().FieldByName(rule.Option)
+				require.True(t, field.IsValid() && field.Kind() == reflect.Bool, "option %q", rule.Option)
+				field.SetBool(true)
+			} else {
+				assert.Empty(t, rule.Option)
+			}
+
+			for _, input := range rule.Pass {
+				_, err := ParseWithOptions(input, strict)
+				assert.NoError(t, err, "pass example %q", input)
+			}
+
+			for _, input := range rule.Fail {
+				_, err := ParseWithOptions(input, strict)
+				var pe *ParseError
+				if assert.ErrorAs(t, err, &pe, "fail example %q", input) {
+					assert.Equal(t, rule.Component, pe.Field, "fail example %q", input)
+				}
+
+				switch rule.Level {
+				case RuleAlways:
+					_, err = ParseWithOptions(input, lenient)
+					assert.Error(t, err, "fail example %q in non-strict mode", input)
+				case RuleStrict:
+					_, err = ParseWithOptions(input, lenient)
+					assert.NoError(t, err, "fail example %q in non-strict mode", input)
+				case RuleOption:
+					_, err = Parse(input)
+					assert.NoError(t, err, "fail example %q without %s", input, rule.Option)
+				default:
+					t.Errorf("unknown level %q", rule.Level)
+				}
+			}
+		})
+	}
+}
+
+// TestRulesJSON tests the JSON export of the rule catalog
+func TestRulesJSON(t *testing.T) {
+	data, err := RulesJSON()
+	require.NoError(t, err)
+
+	var decoded []Rule
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, Rules(), decoded)
+
+	var raw []map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "input.non_empty", raw[0]["id"])
+	assert.NotContains(t, raw[0], "citation")
+	assert.Equal(t, "always", raw[0]["level"])
+}
+
+// TestRulesCopy tests that callers cannot modify the catalog
+func TestRulesCopy(t *testing.T) {
+	r := Rules()
+	r[0].ID = "changed"
+	r[0].Pass[0] = "changed"
+	assert.Equal(t, "input.non_empty", Rules()[0].ID)
+	assert.Equal(t, "CHCGIL01DS0", Rules()[0].Pass[0])
+}
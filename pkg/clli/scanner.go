@@ -0,0 +1,141 @@
+package clli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// Scanner reads a newline- or comma-delimited list of CLLI codes and parses
+// them one at a time, so large extracts such as a LERG dump can be processed
+// without loading them into memory. Blank entries are skipped.
+//
+//	s := clli.NewScanner(f)
+//	s.OnError = func(line int, input string, err error) { log.Print(err) }
+//	for s.Scan() {
+//		process(s.CLLI())
+//	}
+//	if err := s.Err(); err != nil {
+//		return err
+//	}
+type Scanner struct {
+	// Options controls how codes are parsed. Nil selects the same defaults
+	// as Parse.
+	Options *ParseOptions
+
+	// OnError is called for each code that fails to parse, after which
+	// scanning continues. When nil, the first parse failure stops the scan
+	// and is reported by Err.
+	OnError func(line int, input string, err error)
+
+	ctx   context.Context
+	s     *bufio.Scanner
+	line  int // Line of the next token
+	delim byte
+	cur   *CLLI
+	text  string
+	at    int // Line of the current token
+	err   error
+}
+
+// NewScanner creates a Scanner reading from r.
+func NewScanner(r io.Reader) *Scanner {
+	return NewScannerContext(context.Background(), r)
+}
+
+// NewScannerContext creates a Scanner reading from r that stops once ctx
+// is done, reporting ctx.Err() from Err. Cancellation is checked between
+// entries; a read blocked in r is not interrupted.
+func NewScannerContext(ctx context.Context, r io.Reader) *Scanner {
+	sc := &Scanner{ctx: ctx, s: bufio.NewScanner(r), line: 1}
+	sc.s.Split(sc.split)
+	return sc
+}
+
+// Buffer sets the initial buffer and the maximum size of a single entry, as
+// bufio.Scanner.Buffer does. It must be called before the first Scan.
+func (sc *Scanner) Buffer(buf []byte, max int) {
+	sc.s.Buffer(buf, max)
+}
+
+// Scan advances to the next valid CLLI, which is then available through
+// CLLI. It returns false when the input is exhausted or scanning stopped
+// on an error.
+func (sc *Scanner) Scan() bool {
+	if sc.err != nil {
+		return false
+	}
+
+	for {
+		if err := sc.ctx.Err(); err != nil {
+			sc.cur, sc.err = nil, err
+			return false
+		}
+		if !sc.s.Scan() {
+			break
+		}
+		line := sc.line
+		if sc.delim == '\n' {
+			sc.line++
+		}
+
+		text := strings.TrimSpace(sc.s.Text())
+		if text == "" {
+			continue
+		}
+
+		c, err := ParseWithOptions(text, sc.Options)
+		if err != nil {
+			if sc.OnError == nil {
+				sc.cur, sc.err = nil, err
+				return false
+			}
+			sc.OnError(line, text, err)
+			continue
+		}
+
+		sc.cur, sc.text, sc.at = c, text, line
+		return true
+	}
+
+	sc.cur, sc.err = nil, sc.s.Err()
+	return false
+}
+
+// CLLI returns the code parsed by the most recent call to Scan.
+func (sc *Scanner) CLLI() *CLLI {
+	return sc.cur
+}
+
+// Text returns the trimmed input of the most recent code.
+func (sc *Scanner) Text() string {
+	return sc.text
+}
+
+// Line returns the 1-based line on which the most recent code appeared.
+func (sc *Scanner) Line() int {
+	return sc.at
+}
+
+// Err returns the first read error, the context error if the Scanner's
+// context is done, or the first parse error when OnError is nil. It returns
+// nil if scanning stopped at the end of the input.
+func (sc *Scanner) Err() error {
+	return sc.err
+}
+
+// split is a bufio.SplitFunc that splits on newlines and commas, recording
+// which delimiter ended each token so that line numbers can be tracked.
+func (sc *Scanner) split(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, ",\n"); i >= 0 {
+		sc.delim = data[i]
+		return i + 1, bytes.TrimSuffix(data[:i], []byte("\r")), nil
+	}
+	if atEOF && len(data) > 0 {
+		sc.delim = 0
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
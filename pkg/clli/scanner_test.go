@@ -0,0 +1,76 @@
+package clli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScanner tests streaming newline- and comma-delimited input
+func TestScanner(t *testing.T) {
+	input := "CHCGIL01DS0, dllstxb1234\r\n\nCHCGZZ01DS0\nMPLSMN1A2345,,TOROON01DS0"
+
+	type failure struct {
+		line  int
+		input string
+	}
+	var failures []failure
+
+	s := NewScanner(strings.NewReader(input))
+	s.OnError = func(line int, input string, err error) {
+		assert.ErrorIs(t, err, ErrInvalidRegion)
+		failures = append(failures, failure{line, input})
+	}
+
+	var codes []string
+	var lines []int
+	for s.Scan() {
+		codes = append(codes, s.CLLI().Format())
+		lines = append(lines, s.Line())
+	}
+	require.NoError(t, s.Err())
+
+	assert.Equal(t, []string{"CHCGIL01DS0", "DLLSTXB1234", "MPLSMN1A2345", "TOROON01DS0"}, codes)
+	assert.Equal(t, []int{1, 1, 4, 4}, lines)
+	assert.Equal(t, []failure{{3, "CHCGZZ01DS0"}}, failures)
+}
+
+// TestScannerStopsOnError tests that parse errors stop the scan without a callback
+func TestScannerStopsOnError(t *testing.T) {
+	s := NewScanner(strings.NewReader("CHCGIL01DS0\nCHCGZZ01DS0\nTOROON01DS0\n"))
+
+	require.True(t, s.Scan())
+	assert.Equal(t, "CHCGIL01DS0", s.Text())
+	assert.False(t, s.Scan())
+	assert.Nil(t, s.CLLI())
+	assert.ErrorIs(t, s.Err(), ErrInvalidRegion)
+	assert.False(t, s.Scan())
+}
+
+// TestScannerBuffer tests the maximum entry size
+func TestScannerBuffer(t *testing.T) {
+	s := NewScanner(strings.NewReader("CHCGIL01DS0\n" + strings.Repeat("A", 64)))
+	s.Buffer(make([]byte, 16), 32)
+	s.OnError = func(int, string, error) {}
+
+	assert.True(t, s.Scan())
+	assert.False(t, s.Scan())
+	assert.True(t, errors.Is(s.Err(), bufio.ErrTooLong))
+}
+
+// TestScannerContext tests that scanning stops when the context is done
+func TestScannerContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewScannerContext(ctx, strings.NewReader("CHCGIL01DS0\nTOROON01DS0\n"))
+
+	require.True(t, s.Scan())
+	cancel()
+	assert.False(t, s.Scan())
+	assert.Nil(t, s.CLLI())
+	assert.ErrorIs(t, s.Err(), context.Canceled)
+}
@@ -0,0 +1,108 @@
+package clli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the Earth in kilometres.
+const earthRadiusKm = 6371.0
+
+// Span is a link between two CLLI locations, such as a fiber span or
+// transport link, running from its A end to its Z end.
+type Span struct {
+	A *CLLI `json:"a"` // A-end location
+	Z *CLLI `json:"z"` // Z-end location
+}
+
+var _ json.Unmarshaler = (*Span)(nil)
+
+// ParseSpan parses a span in the form returned by Span.String, such as
+// "CHCGIL01-NYCMNY02", parsing each end with Parse.
+func ParseSpan(s string) (Span, error) {
+	a, z, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return Span{}, fmt.Errorf("%s: %w: %w", s, ErrInvalidCLLI, newMessageError(MsgSpanFormat))
+	}
+	ca, err := Parse(a)
+	if err != nil {
+		return Span{}, err
+	}
+	cz, err := Parse(z)
+	if err != nil {
+		return Span{}, err
+	}
+	return Span{A: ca, Z: cz}, nil
+}
+
+// String returns the formatted ends separated by a hyphen, such as
+// "CHCGIL01-NYCMNY02", with the components selected by the redaction
+// policy of each end masked.
+func (s Span) String() string {
+	return s.A.redactionPolicy().Redact(s.A) + "-" + s.Z.redactionPolicy().Redact(s.Z)
+}
+
+// SameRegion reports whether both ends are in the same region.
+func (s Span) SameRegion() bool {
+	return s.A.Region == s.Z.Region
+}
+
+// SamePlace reports whether both ends are at the same place in the same region.
+func (s Span) SamePlace() bool {
+	return ByPlace(s.A) == ByPlace(s.Z)
+}
+
+// Reverse returns the span with its ends swapped.
+func (s Span) Reverse() Span {
+	return Span{A: s.Z, Z: s.A}
+}
+
+// Distance returns the great-circle distance between the places of the two
+// ends in kilometres, located with DefaultCoordinateResolver. Returns false
+// if either place has no known coordinates.
+func (s Span) Distance() (float64, bool) {
+	lat1, lon1, ok1 := s.A.Coordinates()
+	lat2, lon2, ok2 := s.Z.Coordinates()
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dlat, dlon := rad(lat2-lat1), rad(lon2-lon1)
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dlon/2)*math.Sin(dlon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h))), true
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting an object with "a"
+// and "z" members, as encoded by encoding/json, or a string in the form
+// accepted by ParseSpan.
+func (s *Span) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		span, err := ParseSpan(str)
+		if err != nil {
+			return err
+		}
+		*s = span
+		return nil
+	}
+
+	var ends struct {
+		A *CLLI `json:"a"`
+		Z *CLLI `json:"z"`
+	}
+	if err := json.Unmarshal(data, &ends); err != nil {
+		return err
+	}
+	if ends.A == nil || ends.Z == nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCLLI, newMessageError(MsgSpanEnds))
+	}
+	*s = Span(ends)
+	return nil
+}
@@ -0,0 +1,64 @@
+package clli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSpan tests span endpoint utilities
+func TestSpan(t *testing.T) {
+	// Coordinates come from a fixed dataset, so the test also passes when
+	// the embedded places are excluded with the clli_nogeodata build tag
+	SetCoordinateResolver(NewResolver(NewDataset("span", []PlaceRecord{
+		{Place: "CHCG", Region: "IL", City: "Chicago", Latitude: 41.8781, Longitude: -87.6298, HasCoordinates: true},
+		{Place: "NYCM", Region: "NY", City: "New York City", Latitude: 40.7128, Longitude: -74.0060, HasCoordinates: true},
+	})))
+	t.Cleanup(func() { SetCoordinateResolver(nil) })
+
+	s, err := ParseSpan("chcgil01-NYCMNY02")
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL01-NYCMNY02", s.String())
+	assert.False(t, s.SameRegion())
+	assert.False(t, s.SamePlace())
+	assert.Equal(t, "NYCMNY02-CHCGIL01", s.Reverse().String())
+
+	km, ok := s.Distance()
+	require.True(t, ok)
+	assert.InDelta(t, 1145, km, 5)
+
+	local := Span{A: MustParse("CHCGIL01DS0"), Z: MustParse("CHCGIL02DS0")}
+	assert.True(t, local.SameRegion())
+	assert.True(t, local.SamePlace())
+	km, ok = local.Distance()
+	require.True(t, ok)
+	assert.Zero(t, km)
+
+	_, ok = Span{A: MustParse("CHCGIL01"), Z: MustParse("LABXIL01")}.Distance()
+	assert.False(t, ok)
+
+	for _, input := range []string{"CHCGIL01", "CHCGIL01-", "CHCGZZ01-NYCMNY02"} {
+		_, err := ParseSpan(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestSpanJSON tests encoding and decoding spans
+func TestSpanJSON(t *testing.T) {
+	s := Span{A: MustParse("CHCGIL01"), Z: MustParse("NYCMNY02")}
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"CHCGIL01","z":"NYCMNY02"}`, string(data))
+
+	var decoded Span
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, s.String(), decoded.String())
+
+	require.NoError(t, json.Unmarshal([]byte(`"DLLSTX01-HSTNTX01"`), &decoded))
+	assert.Equal(t, "DLLSTX01-HSTNTX01", decoded.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"a":"CHCGIL01"}`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`"CHCGIL01"`), &decoded))
+}
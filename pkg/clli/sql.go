@@ -0,0 +1,77 @@
+package clli
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+var (
+	_ driver.Valuer = CLLI{}
+	_ sql.Scanner   = (*CLLI)(nil)
+	_ driver.Valuer = NullCLLI{}
+	_ sql.Scanner   = (*NullCLLI)(nil)
+)
+
+// Value implements driver.Valuer, storing the CLLI as its formatted string
+// so it fits a VARCHAR column. It has a value receiver, like MarshalText, so
+// both CLLI values and pointers can be passed as query arguments;
+// database/sql stores a nil *CLLI as NULL. Use NullCLLI for nullable
+// columns.
+func (c CLLI) Value() (driver.Value, error) {
+	return c.Format(), nil
+}
+
+// Scan implements sql.Scanner, parsing a string or []byte column value as
+// UnmarshalBinary does, so values written by Value for CLLIs parsed with
+// AllowInternational or a padded place load again. Values that fail
+// validation are reported as errors, so invalid rows are caught when
+// loaded. NULL is an error; scan nullable columns into a NullCLLI instead.
+func (c *CLLI) Scan(src any) error {
+	var code string
+	switch v := src.(type) {
+	case string:
+		code = v
+	case []byte:
+		code = string(v)
+	case nil:
+		return newMessageError(MsgScanNull, ErrEmptyInput)
+	default:
+		return newMessageError(MsgScanType, src)
+	}
+
+	parsed, err := parseEncoded(code)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// NullCLLI is a CLLI that may be NULL, analogous to sql.NullString.
+type NullCLLI struct {
+	CLLI  CLLI
+	Valid bool // Valid is true if CLLI is not NULL
+}
+
+// Value implements driver.Valuer, storing NULL when Valid is false.
+func (n NullCLLI) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.CLLI.Value()
+}
+
+// Scan implements sql.Scanner. NULL sets Valid to false; any other value is
+// scanned as a CLLI.
+func (n *NullCLLI) Scan(src any) error {
+	if src == nil {
+		n.CLLI, n.Valid = CLLI{}, false
+		return nil
+	}
+	if err := n.CLLI.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,72 @@
+package clli
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSQLValue tests storing CLLIs as column values
+func TestSQLValue(t *testing.T) {
+	v, err := MustParse(" chcgil01ds0 ").Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("CHCGIL01DS0"), v)
+
+	// database/sql converts arguments with the default converter, which
+	// accepts values and pointers and stores nil pointers as NULL
+	v, err = driver.DefaultParameterConverter.ConvertValue(*MustParse("CHCGIL01DS0"))
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("CHCGIL01DS0"), v)
+	v, err = driver.DefaultParameterConverter.ConvertValue(MustParse("CHCGIL01DS0"))
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("CHCGIL01DS0"), v)
+	var nilCLLI *CLLI
+	v, err = driver.DefaultParameterConverter.ConvertValue(nilCLLI)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = NullCLLI{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = NullCLLI{CLLI: *MustParse("DLLSTXB1234"), Valid: true}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("DLLSTXB1234"), v)
+}
+
+// TestSQLScan tests loading CLLIs from column values with validation
+func TestSQLScan(t *testing.T) {
+	for _, src := range []any{"CHCGIL01DS0", []byte("CHCGIL01DS0")} {
+		var c CLLI
+		require.NoError(t, c.Scan(src))
+		assert.Equal(t, *MustParse("CHCGIL01DS0"), c)
+	}
+
+	for _, p := range optionParsed(t) {
+		v, err := p.Value()
+		require.NoError(t, err)
+		var c CLLI
+		require.NoError(t, c.Scan(v))
+		assert.Equal(t, p.Format(), c.Format())
+	}
+
+	var c CLLI
+	assert.ErrorIs(t, c.Scan("CHCGZZ01DS0"), ErrInvalidRegion)
+	assert.ErrorIs(t, c.Scan(nil), ErrEmptyInput)
+	assert.Error(t, c.Scan(42))
+	assert.False(t, c.IsValid())
+
+	var n NullCLLI
+	require.NoError(t, n.Scan("MPLSMN1A2345"))
+	assert.True(t, n.Valid)
+	assert.Equal(t, CLLITypeCustomer, n.CLLI.Type())
+
+	require.NoError(t, n.Scan(nil))
+	assert.False(t, n.Valid)
+	assert.Equal(t, CLLI{}, n.CLLI)
+
+	assert.Error(t, n.Scan("CHCG"))
+	assert.False(t, n.Valid)
+}
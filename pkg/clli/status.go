@@ -0,0 +1,78 @@
+package clli
+
+// FieldStatus describes how a component of a parsed CLLI was obtained.
+type FieldStatus int
+
+const (
+	// FieldAbsent indicates a component the code does not have
+	FieldAbsent FieldStatus = iota
+
+	// FieldClean indicates a component read from the input that passed
+	// strict validation
+	FieldClean
+
+	// FieldInferred indicates a component filled in by the parser rather
+	// than read from the input, such as the region of a short code
+	FieldInferred
+
+	// FieldRelaxed indicates a component accepted only under relaxed rules:
+	// non-strict parsing or options such as AllowUnknownRegion
+	FieldRelaxed
+)
+
+// String returns the string representation of the field status
+func (s FieldStatus) String() string {
+	switch s {
+	case FieldClean:
+		return "clean"
+	case FieldInferred:
+		return "inferred"
+	case FieldRelaxed:
+		return "relaxed"
+	default:
+		return "absent"
+	}
+}
+
+// ComponentStatus reports how each component of a parsed CLLI was
+// obtained, for pipelines that parse leniently but report data quality.
+type ComponentStatus struct {
+	Place        FieldStatus
+	Region       FieldStatus
+	NetworkSite  FieldStatus
+	EntityCode   FieldStatus
+	LocationCode FieldStatus
+	LocationID   FieldStatus
+	CustomerCode FieldStatus
+	CustomerID   FieldStatus
+}
+
+// Status returns how each component was obtained, e.g.
+// c.Status().Region == FieldInferred for a code too short to carry one.
+func (c *CLLI) Status() ComponentStatus {
+	return ComponentStatus{
+		Place:        c.fieldStatus(ComponentPlace, c.Place),
+		Region:       c.fieldStatus(ComponentRegion, c.Region),
+		NetworkSite:  c.fieldStatus(ComponentNetworkSite, c.NetworkSite),
+		EntityCode:   c.fieldStatus(ComponentEntityCode, c.EntityCode),
+		LocationCode: c.fieldStatus(ComponentLocationCode, c.LocationCode),
+		LocationID:   c.fieldStatus(ComponentLocationID, c.LocationID),
+		CustomerCode: c.fieldStatus(ComponentCustomerCode, c.CustomerCode),
+		CustomerID:   c.fieldStatus(ComponentCustomerID, c.CustomerID),
+	}
+}
+
+// fieldStatus returns the status of one component with the given value.
+func (c *CLLI) fieldStatus(kind ComponentKind, value string) FieldStatus {
+	bit := uint16(1) << kind
+	switch {
+	case value == "":
+		return FieldAbsent
+	case c.inferred&bit != 0:
+		return FieldInferred
+	case (c.invalid|c.relaxed)&bit != 0:
+		return FieldRelaxed
+	default:
+		return FieldClean
+	}
+}
@@ -0,0 +1,37 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatus tests reporting how each component was obtained
+func TestStatus(t *testing.T) {
+	lenient := &ParseOptions{NormalizeCase: true, TrimWhitespace: true}
+	tests := []struct {
+		input    string
+		opts     *ParseOptions
+		expected ComponentStatus
+	}{
+		{"CHCGIL01DS0", nil, ComponentStatus{Place: FieldClean, Region: FieldClean, NetworkSite: FieldClean, EntityCode: FieldClean}},
+		{"CHCGILB1234", nil, ComponentStatus{Place: FieldClean, Region: FieldClean, LocationCode: FieldClean, LocationID: FieldClean}},
+		{"MPLS", lenient, ComponentStatus{Place: FieldClean, Region: FieldInferred}},
+		{"CHCGZZ01DS0", lenient, ComponentStatus{Place: FieldClean, Region: FieldRelaxed, NetworkSite: FieldClean, EntityCode: FieldClean}},
+		{"CHCGZZ01DS0", &ParseOptions{AllowUnknownRegion: true}, ComponentStatus{Place: FieldClean, Region: FieldRelaxed, NetworkSite: FieldClean, EntityCode: FieldClean}},
+		{"LNDNUK01DS0", &ParseOptions{AllowInternational: true}, ComponentStatus{Place: FieldClean, Region: FieldClean, NetworkSite: FieldClean, EntityCode: FieldClean}},
+		{"CHCGILA0DS0", lenient, ComponentStatus{Place: FieldClean, Region: FieldClean, NetworkSite: FieldRelaxed, EntityCode: FieldClean}},
+		{"RYE NY01QQQ", lenient, ComponentStatus{Place: FieldRelaxed, Region: FieldClean, NetworkSite: FieldClean, EntityCode: FieldRelaxed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := ParseWithOptions(tt.input, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, c.Status())
+		})
+	}
+
+	assert.Equal(t, "inferred", FieldInferred.String())
+	assert.Equal(t, "absent", ComponentStatus{}.Region.String())
+}
@@ -0,0 +1,146 @@
+package clli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Suggestion is a proposed correction for a malformed CLLI.
+type Suggestion struct {
+	Code     string // Corrected code, which Parse accepts
+	Distance int    // Edit distance from the input, counting a transposition as one edit
+	Reason   string // Description of the correction
+}
+
+// confusables pairs characters commonly mistaken for one another when codes
+// are read off equipment labels or typed by hand.
+var confusables = map[byte]byte{
+	'0': 'O', 'O': '0',
+	'1': 'I', 'I': '1',
+	'2': 'Z', 'Z': '2',
+	'5': 'S', 'S': '5',
+	'8': 'B', 'B': '8',
+}
+
+// maxConfusableEdits limits how many confusable characters are replaced in a
+// single suggestion.
+const maxConfusableEdits = 2
+
+// Suggest proposes likely corrections for a malformed CLLI, for help-desk
+// tools fixing typos from field technicians. It tries replacing confusable
+// characters (O and 0, I and 1, S and 5, B and 8, Z and 2), swapping
+// transposed characters such as a reversed region, and completing a
+// truncated entity code. Only corrections that Parse accepts are returned,
+// ordered by edit distance and then by code, up to n of them; n <= 0 returns
+// all. Returns nil if the input is already valid.
+func Suggest(input string, n int) []Suggestion {
+	s := NormalizeForCompare(input)
+	if s == "" {
+		return nil
+	}
+	if _, err := Parse(s); err == nil {
+		return nil
+	}
+
+	found := make(map[string]Suggestion)
+	try := func(code, reason string) {
+		if _, seen := found[code]; seen || code == s {
+			return
+		}
+		if _, err := parseDefault(code); err != nil {
+			return
+		}
+		found[code] = Suggestion{Code: code, Distance: editDistance(s, code), Reason: reason}
+	}
+
+	// Confusable characters, up to maxConfusableEdits at a time
+	var positions []int
+	for i := 0; i < len(s); i++ {
+		if _, ok := confusables[s[i]]; ok {
+			positions = append(positions, i)
+		}
+	}
+	var substitute func(b []byte, start int, reasons []string)
+	substitute = func(b []byte, start int, reasons []string) {
+		if len(reasons) > 0 {
+			try(string(b), strings.Join(reasons, "; "))
+		}
+		if len(reasons) == maxConfusableEdits {
+			return
+		}
+		for _, i := range positions {
+			if i < start {
+				continue
+			}
+			orig := b[i]
+			b[i] = confusables[orig]
+			substitute(b, i+1, append(reasons, fmt.Sprintf("replaced %c with %c at position %d", orig, b[i], i+1)))
+			b[i] = orig
+		}
+	}
+	substitute([]byte(s), 0, nil)
+
+	// Transposed adjacent characters
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == s[i+1] {
+			continue
+		}
+		b := []byte(s)
+		b[i], b[i+1] = b[i+1], b[i]
+		reason := fmt.Sprintf("swapped characters at positions %d and %d", i+1, i+2)
+		if i == 4 {
+			reason = "swapped transposed region letters"
+		}
+		try(string(b), reason)
+	}
+
+	// Entity code missing its last character
+	if len(s) == 10 {
+		for _, c := range []byte(entityCodeChars) {
+			try(s+string(c), "completed truncated entity code")
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(found))
+	for _, sg := range found {
+		suggestions = append(suggestions, sg)
+	}
+	slices.SortFunc(suggestions, func(a, b Suggestion) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+	if n > 0 && len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions and transpositions
+// of adjacent characters needed to turn one into the other.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,67 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSuggest tests corrections for common typos
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		code   string
+		reason string
+	}{
+		{"O for zero", "CHCGILO1DS0", "CHCGIL01DS0", "replaced O with 0 at position 7"},
+		{"one for I", "CHCG1L01DS0", "CHCGIL01DS0", "replaced 1 with I at position 5"},
+		{"two confusions", "CHCG1LO1DS0", "CHCGIL01DS0", "replaced 1 with I at position 5; replaced O with 0 at position 7"},
+		{"transposed region", "CHCGLI01DS0", "CHCGIL01DS0", "swapped transposed region letters"},
+		{"truncated entity", "CHCGIL01DS", "CHCGIL01DS0", "completed truncated entity code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := Suggest(tt.input, 0)
+			for _, s := range suggestions {
+				_, err := Parse(s.Code)
+				assert.NoError(t, err, s.Code)
+			}
+			for _, s := range suggestions {
+				if s.Code == tt.code {
+					assert.Contains(t, s.Reason, tt.reason)
+					return
+				}
+			}
+			t.Errorf("no suggestion %s for %s in %v", tt.code, tt.input, suggestions)
+		})
+	}
+}
+
+// TestSuggestRanking tests ordering and limiting suggestions
+func TestSuggestRanking(t *testing.T) {
+	suggestions := Suggest("chcg1lo1ds0", 1)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, Suggestion{Code: "CHCGIL01DS0", Distance: 2, Reason: "replaced 1 with I at position 5; replaced O with 0 at position 7"}, suggestions[0])
+
+	suggestions = Suggest("CHCGIL01DS", 3)
+	require.Len(t, suggestions, 3)
+	for _, s := range suggestions {
+		assert.Equal(t, 1, s.Distance)
+	}
+	assert.Equal(t, "CHCGIL01DS0", suggestions[0].Code)
+
+	assert.Nil(t, Suggest("CHCGIL01DS0", 5))
+	assert.Nil(t, Suggest("", 5))
+	assert.Empty(t, Suggest("@@@@", 5))
+}
+
+// TestEditDistance tests the optimal string alignment distance
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("CHCGIL", "CHCGIL"))
+	assert.Equal(t, 1, editDistance("CHCGLI", "CHCGIL"))
+	assert.Equal(t, 1, editDistance("CHCGIL01DS", "CHCGIL01DS0"))
+	assert.Equal(t, 2, editDistance("CHCG1LO1", "CHCGIL01"))
+	assert.Equal(t, 3, editDistance("", "ABC"))
+}
@@ -0,0 +1,187 @@
+package clli
+
+import "context"
+
+// Switch identity
+// Entity CLLIs of switching equipment identify the switches listed in
+// LERG-style routing datasets. The library does not ship such data; it
+// defines the record model and the SwitchResolver hook so callers can plug
+// in their own licensed source.
+
+// SwitchRole is the role of a switch in host/remote arrangements.
+type SwitchRole int
+
+const (
+	SwitchRoleUnknown    SwitchRole = iota // Role not recorded
+	SwitchRoleStandalone                   // Switch with no remotes
+	SwitchRoleHost                         // Host serving one or more remotes
+	SwitchRoleRemote                       // Remote homed on a host
+)
+
+// String returns the string representation of the switch role
+func (r SwitchRole) String() string {
+	switch r {
+	case SwitchRoleStandalone:
+		return "standalone"
+	case SwitchRoleHost:
+		return "host"
+	case SwitchRoleRemote:
+		return "remote"
+	default:
+		return "unknown"
+	}
+}
+
+// SwitchInfo describes the switch identified by an entity CLLI.
+type SwitchInfo struct {
+	CLLI      string     // 11-character switch CLLI
+	Role      SwitchRole // Host/remote role
+	HostCLLI  string     // CLLI of the host switch, for remotes
+	OCN       string     // Operating Company Number of the switch owner
+	Equipment string     // Switch type, such as "5ESS" or "DMS100" (optional)
+	NPANXX    []string   // NPA-NXX codes served, as six digits such as "312555"
+}
+
+// SwitchResolver looks up switch attributes by switch CLLI. Implementations
+// must be safe for concurrent use.
+type SwitchResolver interface {
+	// Switch returns the switch identified by clli, an uppercase 11-character
+	// entity CLLI, and false if it is not known.
+	Switch(ctx context.Context, clli string) (SwitchInfo, bool, error)
+}
+
+// SwitchResolverFunc adapts an ordinary function to the SwitchResolver interface.
+type SwitchResolverFunc func(ctx context.Context, clli string) (SwitchInfo, bool, error)
+
+// Switch calls f(ctx, clli).
+func (f SwitchResolverFunc) Switch(ctx context.Context, clli string) (SwitchInfo, bool, error) {
+	return f(ctx, clli)
+}
+
+var (
+	_ SwitchResolver = (*SwitchTable)(nil)
+	_ SwitchResolver = SwitchResolverFunc(nil)
+	_ SwitchResolver = (*Resolver)(nil)
+)
+
+// SwitchTable is an in-memory SwitchResolver indexed by CLLI and by NPA-NXX,
+// for datasets small enough to load whole. It is safe for concurrent use
+// once built.
+type SwitchTable struct {
+	switches map[string]SwitchInfo
+	byNPANXX map[string]string
+}
+
+// NewSwitchTable indexes records by CLLI and NPA-NXX. CLLIs are matched
+// ignoring case and surrounding whitespace; later records replace earlier
+// ones with the same CLLI.
+func NewSwitchTable(records []SwitchInfo) *SwitchTable {
+	t := &SwitchTable{
+		switches: make(map[string]SwitchInfo, len(records)),
+		byNPANXX: make(map[string]string),
+	}
+	for _, r := range records {
+		r.CLLI = NormalizeForCompare(r.CLLI)
+		r.HostCLLI = NormalizeForCompare(r.HostCLLI)
+		t.switches[r.CLLI] = r
+		for _, code := range r.NPANXX {
+			t.byNPANXX[code] = r.CLLI
+		}
+	}
+	return t
+}
+
+// Len returns the number of switches in the table.
+func (t *SwitchTable) Len() int {
+	return len(t.switches)
+}
+
+// Switch implements SwitchResolver.
+func (t *SwitchTable) Switch(_ context.Context, clli string) (SwitchInfo, bool, error) {
+	info, ok := t.switches[NormalizeForCompare(clli)]
+	return info, ok, nil
+}
+
+// ServingSwitch returns the switch serving an NPA-NXX given as six digits,
+// and false if no switch serves it.
+func (t *SwitchTable) ServingSwitch(npanxx string) (SwitchInfo, bool) {
+	clli, ok := t.byNPANXX[npanxx]
+	if !ok {
+		return SwitchInfo{}, false
+	}
+	return t.switches[clli], true
+}
+
+// Remotes returns the CLLIs of the switches homed on host, in no particular order.
+func (t *SwitchTable) Remotes(host string) []string {
+	host = NormalizeForCompare(host)
+	var remotes []string
+	for clli, info := range t.switches {
+		if info.HostCLLI == host && info.Role == SwitchRoleRemote {
+			remotes = append(remotes, clli)
+		}
+	}
+	return remotes
+}
+
+// SetSwitchResolver sets the SwitchResolver consulted by r.Switch. A nil s
+// removes it, after which no switches are known.
+func (r *Resolver) SetSwitchResolver(s SwitchResolver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.switches = s
+}
+
+// SwitchResolver returns the SwitchResolver set with SetSwitchResolver, or
+// nil if none is set.
+func (r *Resolver) SwitchResolver() SwitchResolver {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.switches
+}
+
+// Switch implements SwitchResolver by consulting the SwitchResolver set
+// with SetSwitchResolver. Reports false if none is set.
+func (r *Resolver) Switch(ctx context.Context, clli string) (SwitchInfo, bool, error) {
+	s := r.SwitchResolver()
+	if s == nil {
+		return SwitchInfo{}, false, nil
+	}
+	return s.Switch(ctx, clli)
+}
+
+// SetSwitchResolver sets the SwitchResolver of DefaultResolver, used by
+// SwitchCLLIInfo and ResolveSwitch. A nil r removes it, after which no
+// switches are known.
+func SetSwitchResolver(r SwitchResolver) {
+	DefaultResolver().SetSwitchResolver(r)
+}
+
+// DefaultSwitchResolver returns the SwitchResolver of DefaultResolver, or
+// nil if none is set.
+func DefaultSwitchResolver() SwitchResolver {
+	return DefaultResolver().SwitchResolver()
+}
+
+// SwitchCLLIInfo returns the switch identified by this entity CLLI, looked
+// up with DefaultResolver. Returns false if the CLLI is not an entity CLLI,
+// no switch resolver is set, the switch is not known or the lookup fails.
+func (c *CLLI) SwitchCLLIInfo() (SwitchInfo, bool) {
+	info, ok, _ := c.ResolveSwitch(context.Background())
+	return info, ok
+}
+
+// ResolveSwitch is the context-aware variant of SwitchCLLIInfo, which also
+// reports lookup errors.
+func (c *CLLI) ResolveSwitch(ctx context.Context) (SwitchInfo, bool, error) {
+	return c.ResolveSwitchWith(ctx, DefaultResolver())
+}
+
+// ResolveSwitchWith is the variant of ResolveSwitch that uses the given
+// SwitchResolver, such as a Resolver of its own, instead of DefaultResolver.
+func (c *CLLI) ResolveSwitchWith(ctx context.Context, r SwitchResolver) (SwitchInfo, bool, error) {
+	if !c.IsEntityCLLI() || len(c.EntityCode) != 3 {
+		return SwitchInfo{}, false, nil
+	}
+	return r.Switch(ctx, NormalizeForCompare(c.Format()))
+}
@@ -0,0 +1,98 @@
+package clli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwitchTable tests switch lookups by CLLI, NPA-NXX and host
+func TestSwitchTable(t *testing.T) {
+	table := NewSwitchTable([]SwitchInfo{
+		{CLLI: "chcgil01ds0", Role: SwitchRoleHost, OCN: "9533", Equipment: "5ESS", NPANXX: []string{"312555", "312556"}},
+		{CLLI: "CHCGIL02RS0", Role: SwitchRoleRemote, HostCLLI: "CHCGIL01DS0", OCN: "9533", NPANXX: []string{"312557"}},
+	})
+	assert.Equal(t, 2, table.Len())
+
+	info, ok, err := table.Switch(context.Background(), " CHCGIL01DS0")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "CHCGIL01DS0", info.CLLI)
+	assert.Equal(t, "host", info.Role.String())
+
+	info, ok = table.ServingSwitch("312557")
+	require.True(t, ok)
+	assert.Equal(t, "CHCGIL02RS0", info.CLLI)
+	_, ok = table.ServingSwitch("212555")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"CHCGIL02RS0"}, table.Remotes("chcgil01ds0"))
+	assert.Empty(t, table.Remotes("CHCGIL02RS0"))
+}
+
+// TestSwitchCLLIInfo tests resolving switches through the default resolver
+func TestSwitchCLLIInfo(t *testing.T) {
+	c := MustParse("CHCGIL01DS0")
+	_, ok := c.SwitchCLLIInfo()
+	assert.False(t, ok)
+
+	SetSwitchResolver(NewSwitchTable([]SwitchInfo{{CLLI: "CHCGIL01DS0", OCN: "9533"}}))
+	defer SetSwitchResolver(nil)
+
+	info, ok := c.SwitchCLLIInfo()
+	require.True(t, ok)
+	assert.Equal(t, "9533", info.OCN)
+	assert.Equal(t, SwitchRoleUnknown, info.Role)
+
+	// Only entity CLLIs identify switches
+	_, ok = MustParse("CHCGILB1234").SwitchCLLIInfo()
+	assert.False(t, ok)
+
+	SetSwitchResolver(nil)
+	assert.Nil(t, DefaultSwitchResolver())
+	_, ok = c.SwitchCLLIInfo()
+	assert.False(t, ok)
+}
+
+// TestResolverSwitches tests switch lookups through a Resolver of its own
+func TestResolverSwitches(t *testing.T) {
+	r := NewResolver()
+	c := MustParse("CHCGIL01DS0")
+	_, ok, err := c.ResolveSwitchWith(context.Background(), r)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	r.SetSwitchResolver(NewSwitchTable([]SwitchInfo{{CLLI: "CHCGIL01DS0", OCN: "9533"}}))
+	info, ok, err := c.ResolveSwitchWith(context.Background(), r)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "9533", info.OCN)
+
+	// The default resolver is unaffected
+	assert.Nil(t, DefaultSwitchResolver())
+	_, ok = c.SwitchCLLIInfo()
+	assert.False(t, ok)
+}
+
+// TestResolveSwitchWith tests per-call resolver injection and error reporting
+func TestResolveSwitchWith(t *testing.T) {
+	errUnavailable := errors.New("LERG service unavailable")
+	r := SwitchResolverFunc(func(_ context.Context, clli string) (SwitchInfo, bool, error) {
+		if clli == "DLLSTX01DS0" {
+			return SwitchInfo{}, false, errUnavailable
+		}
+		return SwitchInfo{CLLI: clli}, true, nil
+	})
+
+	info, ok, err := MustParse("chcgil01ds0").ResolveSwitchWith(context.Background(), r)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "CHCGIL01DS0", info.CLLI)
+
+	_, ok, err = MustParse("DLLSTX01DS0").ResolveSwitchWith(context.Background(), r)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, errUnavailable)
+}
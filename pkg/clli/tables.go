@@ -0,0 +1,281 @@
+package clli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bell entity tables
+//
+// Entity codes are matched against the patterns of Tables B–E of Bell System
+// Practices Section 795-100-100. Patterns are written in the notation of the
+// specification and compiled once at startup, so a code is valid exactly when
+// it matches a row below and no code needs to be listed individually. The
+// rows are those of the Entity Code Patterns in docs/SPECIFICATIONS.md,
+// except where a comment notes a narrower row.
+
+// entityClasses are the named character classes used by the entity tables.
+var entityClasses = map[string]string{
+	"a":  "A-Z",               // All letters
+	"a1": "ACE-HJ-NP-SVXZ",    // Letters other than B, D, I, O, T, U, W and Y
+	"a2": "A-FH-Z",            // Letters other than G
+	"n":  "0-9",               // All digits
+	"x":  "A-Z0-9",            // All letters and digits
+	"x1": "ACE-HJ-NP-SVXZ0-9", // a1 and digits
+	"x2": "A-FH-Z0-9",         // a2 and digits
+}
+
+// entityTableEntry describes one row of the Bell entity tables.
+type entityTableEntry struct {
+	table   string // Table letter, "B" to "E"
+	pattern string // Pattern in specification notation
+	label   string // Description used instead of the pattern, if set
+
+	// Meaning decoded by EntityDetail
+	equipment string            // Equipment the row denotes, e.g. "Toll crossbar"
+	prefixes  map[string]string // Equipment named by a two-letter prefix, overriding equipment; see equipmentOf
+	function  string            // Switching function the row encodes: "toll", "tandem" or "remote"
+	unit      [2]int            // Bounds of the unit designator in the code; equal if there is none
+
+	sequences [][3]charSet // Compiled pattern
+}
+
+// name returns the row name reported by Explain, e.g. "Table B: [0-9]GT".
+func (e *entityTableEntry) name() string {
+	if e.label != "" {
+		return "Table " + e.table + ": " + e.label
+	}
+	return "Table " + e.table + ": " + e.pattern
+}
+
+// matches reports whether a three-character code matches the row.
+func (e *entityTableEntry) matches(code string) bool {
+	for _, seq := range e.sequences {
+		if seq[0].has(code[0]) && seq[1].has(code[1]) && seq[2].has(code[2]) {
+			return true
+		}
+	}
+	return false
+}
+
+// entityTable lists the rows of Tables B–E in the order they are matched.
+var entityTable = compileEntityTable([]entityTableEntry{
+	// Table B: reserved families, matched first since the equipment
+	// prefixes below overlap them, e.g. XCX
+	{table: "B", pattern: "Z[A-Z]Z"},
+	{table: "B", pattern: "X[A-Z]X"},
+
+	// Table B: switching entities
+	{table: "B", pattern: "(MG|SG|CG|DS|RL|PS|RP|CM|VS|OS|OL)[x1]", label: "two-letter equipment prefix",
+		equipment: "Switching equipment", prefixes: tableBPrefixEquipment, unit: [2]int{2, 3}},
+	// Prefixes in common field use beyond the published table
+	{table: "B", pattern: "(RT|SW|MS|XC)[x1]", label: "two-letter equipment prefix",
+		equipment: "Switching equipment", prefixes: fieldPrefixEquipment, unit: [2]int{2, 3}},
+	{table: "B", pattern: "[0-9]{2}[x1]", equipment: "Switching system", unit: [2]int{0, 2}},
+	{table: "B", pattern: "[CB0-9][0-9]T", equipment: "Tandem switch", function: "tandem", unit: [2]int{0, 2}},
+	{table: "B", pattern: "[0-9]GT", equipment: "Toll crossbar", function: "toll", unit: [2]int{0, 1}},
+	{table: "B", pattern: "RS[0-9]", equipment: "Remote switch", function: "remote", unit: [2]int{2, 3}},
+	{table: "B", pattern: "CT[x1]", equipment: "Switching equipment", unit: [2]int{2, 3}},
+
+	// Table C: switchboard and desk entities
+	{table: "C", pattern: "[0-9][CDBINQWMVROLPEUTZ0-9]B", equipment: "Switchboard", unit: [2]int{0, 1}},
+
+	// Table D: miscellaneous switching entities
+	{table: "D", pattern: "[0-9][AXCTWDEINPQ]D", equipment: "Miscellaneous switching equipment", unit: [2]int{0, 1}},
+	{table: "D", pattern: "[A-Z0-9][UM]D", equipment: "Miscellaneous switching equipment", unit: [2]int{0, 1}},
+
+	// Table E: non-switching entities. The specification row is
+	// [FAEKMPSTW][x2][x1]; it is split so that a letter in the first two
+	// places must also be in a1, keeping codes such as ABC, TAA and WAA
+	// invalid as they have always been
+	{table: "E", pattern: "[FAEKMPSTW][0-9][x1]", equipment: "Non-switching equipment"},
+	{table: "E", pattern: "[FAEKMPS][ACE-FHJ-NP-SVXZ][x1]", label: "[FAEKMPS][a1 and x2][x1]",
+		equipment: "Non-switching equipment"},
+	{table: "E", pattern: "Q[0-9][0-9]", equipment: "Non-switching equipment", unit: [2]int{1, 3}},
+})
+
+// tableBPrefixEquipment names the equipment of the two-letter prefixes of
+// Table B whose meaning is established; the others are reported as
+// switching equipment.
+var tableBPrefixEquipment = map[string]string{
+	"DS": "Digital switch",
+	"MG": "Media gateway",
+	"SG": "Signaling gateway",
+	"PS": "Packet switch",
+	"RL": "Remote line unit",
+	"OS": "Operator services switch",
+}
+
+// fieldPrefixEquipment names the equipment of the two-letter prefixes in
+// field use beyond the published table.
+var fieldPrefixEquipment = map[string]string{
+	"RT": "Router",
+	"SW": "Switch",
+	"MS": "Multiplexer",
+	"XC": "Cross-connect",
+}
+
+// equipmentOf returns the equipment the row denotes for a code matching
+// it, preferring the name of the code's two-letter prefix.
+func (e *entityTableEntry) equipmentOf(code string) string {
+	if name, ok := e.prefixes[code[:2]]; ok {
+		return name
+	}
+	return e.equipment
+}
+
+// lookupEntityTable returns the first row matched by a three-character
+// entity code, or nil if it matches none.
+func lookupEntityTable(code string) *entityTableEntry {
+	if len(code) != 3 {
+		return nil
+	}
+	for i := range entityTable {
+		if entityTable[i].matches(code) {
+			return &entityTable[i]
+		}
+	}
+	return nil
+}
+
+// compileEntityTable compiles the pattern of every row, panicking on a
+// malformed pattern since the table is fixed at build time.
+func compileEntityTable(rows []entityTableEntry) []entityTableEntry {
+	for i := range rows {
+		seqs, err := compileEntityPattern(rows[i].pattern)
+		if err != nil {
+			panic(fmt.Sprintf("clli: entity table %s: %v", rows[i].table, err))
+		}
+		rows[i].sequences = seqs
+	}
+	return rows
+}
+
+// charSet is a set of the characters A-Z and 0-9.
+type charSet uint64
+
+// charIndex returns the bit of an uppercase letter or digit, or -1.
+func charIndex(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10
+	default:
+		return -1
+	}
+}
+
+// has reports whether c is in the set.
+func (s charSet) has(c byte) bool {
+	i := charIndex(c)
+	return i >= 0 && s&(1<<i) != 0
+}
+
+// parseCharSet parses the contents of a bracket expression, either a named
+// class such as "x1" or ranges and characters such as "CB0-9".
+func parseCharSet(spec string) (charSet, error) {
+	if named, ok := entityClasses[spec]; ok {
+		spec = named
+	}
+
+	var s charSet
+	for i := 0; i < len(spec); i++ {
+		lo, hi := spec[i], spec[i]
+		if i+2 < len(spec) && spec[i+1] == '-' {
+			hi = spec[i+2]
+			i += 2
+		}
+		l, h := charIndex(lo), charIndex(hi)
+		if l < 0 || h < l {
+			return 0, newMessageError(MsgTableClass, spec)
+		}
+		for j := l; j <= h; j++ {
+			s |= 1 << j
+		}
+	}
+	return s, nil
+}
+
+// compileEntityPattern expands a pattern into every three-character
+// sequence of character sets it matches. The notation supports literal
+// characters, bracket expressions, {n} repetition and (a|b) alternation.
+func compileEntityPattern(pattern string) ([][3]charSet, error) {
+	alts, err := expandPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs [][3]charSet
+	for _, alt := range alts {
+		if len(alt) != 3 {
+			return nil, newMessageError(MsgTableLength, pattern)
+		}
+		seqs = append(seqs, [3]charSet(alt))
+	}
+	return seqs, nil
+}
+
+// expandPattern returns the alternative character-set sequences of a pattern.
+func expandPattern(pattern string) ([][]charSet, error) {
+	result := [][]charSet{nil}
+	for i := 0; i < len(pattern); {
+		var atom [][]charSet
+		switch c := pattern[i]; c {
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, newMessageError(MsgTableUnterminated, '[', pattern)
+			}
+			s, err := parseCharSet(pattern[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			atom = [][]charSet{{s}}
+			i += end + 1
+		case '(':
+			end := strings.IndexByte(pattern[i:], ')')
+			if end < 0 {
+				return nil, newMessageError(MsgTableUnterminated, '(', pattern)
+			}
+			for _, alt := range strings.Split(pattern[i+1:i+end], "|") {
+				expanded, err := expandPattern(alt)
+				if err != nil {
+					return nil, err
+				}
+				atom = append(atom, expanded...)
+			}
+			i += end + 1
+		default:
+			idx := charIndex(c)
+			if idx < 0 {
+				return nil, newMessageError(MsgTableCharacter, c, pattern)
+			}
+			atom = [][]charSet{{1 << idx}}
+			i++
+		}
+
+		repeat := 1
+		if i < len(pattern) && pattern[i] == '{' {
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, newMessageError(MsgTableUnterminated, '{', pattern)
+			}
+			if _, err := fmt.Sscanf(pattern[i+1:i+end], "%d", &repeat); err != nil || repeat < 1 {
+				return nil, newMessageError(MsgTableRepetition, pattern)
+			}
+			i += end + 1
+		}
+
+		for range repeat {
+			var next [][]charSet
+			for _, prefix := range result {
+				for _, a := range atom {
+					next = append(next, append(append([]charSet(nil), prefix...), a...))
+				}
+			}
+			result = next
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEntityTable tests that codes are matched by table pattern rather than by enumeration
+func TestEntityTable(t *testing.T) {
+	tests := []struct {
+		code string
+		row  string
+	}{
+		{"DS0", "Table B: two-letter equipment prefix"},
+		{"SW1", "Table B: two-letter equipment prefix"},
+		{"345", "Table B: [0-9]{2}[x1]"},
+		{"C9T", "Table B: [CB0-9][0-9]T"},
+		{"ZAZ", "Table B: Z[A-Z]Z"},
+		{"XCX", "Table B: X[A-Z]X"},
+		{"CTX", "Table B: CT[x1]"},
+		{"4QB", "Table C: [0-9][CDBINQWMVROLPEUTZ0-9]B"},
+		{"AUD", "Table D: [A-Z0-9][UM]D"},
+		{"P90", "Table E: [FAEKMPSTW][0-9][x1]"},
+		{"K33", "Table E: [FAEKMPSTW][0-9][x1]"},
+		{"W7Z", "Table E: [FAEKMPSTW][0-9][x1]"},
+		{"KHZ", "Table E: [FAEKMPS][a1 and x2][x1]"},
+		{"WHZ", ""}, // W is not in a1
+		{"ABC", ""}, // B is not in a1
+		{"Q12", "Table E: Q[0-9][0-9]"},
+		{"DSB", ""}, // B is not in x1
+		{"KGA", ""}, // G is not in x2
+		{"QQQ", ""},
+		{"DS", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.row, entityTableRow(tt.code))
+		})
+	}
+}
+
+// TestCompileEntityPattern tests the pattern notation used by the entity tables
+func TestCompileEntityPattern(t *testing.T) {
+	seqs, err := compileEntityPattern("(AB|[0-9]{2})[x1]")
+	require.NoError(t, err)
+	assert.Len(t, seqs, 2)
+
+	for _, pattern := range []string{"AB", "ABCD", "[x1", "(AB", "A{0}BC", "[Z-A]BC", "a12"} {
+		_, err := compileEntityPattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
@@ -0,0 +1,29 @@
+package clli
+
+import "encoding"
+
+var (
+	_ encoding.TextMarshaler   = CLLI{}
+	_ encoding.TextUnmarshaler = (*CLLI)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler, encoding the CLLI as its
+// formatted code. It has a value receiver so CLLI values can be used as
+// JSON map keys, and works with YAML, TOML and flag.TextVar through the
+// text interfaces.
+func (c CLLI) MarshalText() ([]byte, error) {
+	return []byte(c.Format()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing text as
+// UnmarshalBinary does so invalid codes are rejected when configuration is
+// loaded, while codes written by MarshalText for CLLIs parsed with
+// AllowInternational or a padded place decode again.
+func (c *CLLI) UnmarshalText(text []byte) error {
+	parsed, err := parseEncoded(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
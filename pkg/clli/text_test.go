@@ -0,0 +1,65 @@
+package clli
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTextMarshaling tests the encoding.TextMarshaler round trip
+func TestTextMarshaling(t *testing.T) {
+	text, err := MustParse(" chcgil01ds0 ").MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "CHCGIL01DS0", string(text))
+
+	var c CLLI
+	require.NoError(t, c.UnmarshalText([]byte("dllstx01ds1")))
+	assert.Equal(t, "DS1", c.EntityCode)
+	assert.True(t, c.IsValid())
+
+	err = c.UnmarshalText([]byte("CHCGZZ01DS0"))
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+	assert.Equal(t, "DS1", c.EntityCode, "failed unmarshal leaves the CLLI unchanged")
+}
+
+// TestTextOptions tests text round trips of CLLIs accepted only with parse options
+func TestTextOptions(t *testing.T) {
+	for _, p := range optionParsed(t) {
+		text, err := p.MarshalText()
+		require.NoError(t, err)
+		var c CLLI
+		require.NoError(t, c.UnmarshalText(text))
+		assert.Equal(t, p.Format(), c.Format())
+		assert.Equal(t, p.Type(), c.Type())
+	}
+}
+
+// TestTextMapKeys tests CLLIs as JSON map keys
+func TestTextMapKeys(t *testing.T) {
+	in := map[CLLI]int{*MustParse("CHCGIL01DS0"): 1}
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"CHCGIL01DS0": 1}`, string(data))
+
+	var out map[CLLI]int
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, 1, out[*MustParse("CHCGIL01DS0")])
+}
+
+// TestTextFlag tests CLLIs as command-line flags
+func TestTextFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var c CLLI
+	fs.TextVar(&c, "clli", MustParse("CHCGIL01DS0"), "switch CLLI")
+
+	require.NoError(t, fs.Parse(nil))
+	assert.Equal(t, "CHCGIL01DS0", c.Format())
+	require.NoError(t, fs.Parse([]string{"-clli", "nycmny01ds0"}))
+	assert.Equal(t, "NYCMNY01DS0", c.Format())
+	assert.Error(t, fs.Parse([]string{"-clli", "bogus"}))
+}
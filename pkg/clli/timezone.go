@@ -0,0 +1,112 @@
+package clli
+
+import (
+	"sync"
+	"time"
+)
+
+// Time zone resolution
+// Regions map to the IANA time zone observed by most of their central
+// offices; places in a region that observe a different zone are listed
+// separately. The zone database is embedded unless built with the
+// clli_nogeodata tag, in which case the system database is used.
+
+// regionTimeZones maps region codes to their predominant IANA time zone.
+var regionTimeZones = map[string]string{
+	// US states
+	"AL": "America/Chicago", "AK": "America/Anchorage", "AZ": "America/Phoenix", "AR": "America/Chicago",
+	"CA": "America/Los_Angeles", "CO": "America/Denver", "CT": "America/New_York", "DE": "America/New_York",
+	"FL": "America/New_York", "GA": "America/New_York", "HI": "Pacific/Honolulu", "ID": "America/Boise",
+	"IL": "America/Chicago", "IN": "America/Indiana/Indianapolis", "IA": "America/Chicago", "KS": "America/Chicago",
+	"KY": "America/Kentucky/Louisville", "LA": "America/Chicago", "ME": "America/New_York", "MD": "America/New_York",
+	"MA": "America/New_York", "MI": "America/Detroit", "MN": "America/Chicago", "MS": "America/Chicago",
+	"MO": "America/Chicago", "MT": "America/Denver", "NE": "America/Chicago", "NV": "America/Los_Angeles",
+	"NH": "America/New_York", "NJ": "America/New_York", "NM": "America/Denver", "NY": "America/New_York",
+	"NC": "America/New_York", "ND": "America/Chicago", "OH": "America/New_York", "OK": "America/Chicago",
+	"OR": "America/Los_Angeles", "PA": "America/New_York", "RI": "America/New_York", "SC": "America/New_York",
+	"SD": "America/Chicago", "TN": "America/Chicago", "TX": "America/Chicago", "UT": "America/Denver",
+	"VT": "America/New_York", "VA": "America/New_York", "WA": "America/Los_Angeles", "WV": "America/New_York",
+	"WI": "America/Chicago", "WY": "America/Denver", "DC": "America/New_York",
+
+	// Canadian provinces and territories
+	"AB": "America/Edmonton", "BC": "America/Vancouver", "MB": "America/Winnipeg", "NB": "America/Moncton",
+	"NL": "America/St_Johns", "NS": "America/Halifax", "ON": "America/Toronto", "PE": "America/Halifax",
+	"QC": "America/Toronto", "SK": "America/Regina", "NT": "America/Yellowknife", "NU": "America/Iqaluit",
+	"YT": "America/Whitehorse",
+
+	// US territories
+	"AS": "Pacific/Pago_Pago", "GU": "Pacific/Guam", "MP": "Pacific/Saipan", "PR": "America/Puerto_Rico",
+	"VI": "America/St_Thomas",
+}
+
+// countryTimeZones maps the region codes of internationalRegions to their
+// predominant IANA time zone. They are kept apart from regionTimeZones
+// since some codes are also US state or Canadian province codes.
+var countryTimeZones = map[string]string{
+	"AG": "America/Antigua", "AI": "America/Anguilla", "BB": "America/Barbados", "BM": "Atlantic/Bermuda",
+	"BS": "America/Nassau", "CU": "America/Havana", "DM": "America/Dominica", "DO": "America/Santo_Domingo",
+	"GD": "America/Grenada", "HT": "America/Port-au-Prince", "JM": "America/Jamaica", "KN": "America/St_Kitts",
+	"LC": "America/St_Lucia", "SX": "America/Lower_Princes", "TC": "America/Grand_Turk", "TT": "America/Port_of_Spain",
+	"VC": "America/St_Vincent", "VG": "America/Tortola", "MX": "America/Mexico_City", "AE": "Asia/Dubai",
+	"AT": "Europe/Vienna", "AU": "Australia/Sydney", "BE": "Europe/Brussels", "BR": "America/Sao_Paulo",
+	"CH": "Europe/Zurich", "CL": "America/Santiago", "CN": "Asia/Shanghai", "DK": "Europe/Copenhagen",
+	"EG": "Africa/Cairo", "ES": "Europe/Madrid", "FI": "Europe/Helsinki", "FR": "Europe/Paris",
+	"GR": "Europe/Athens", "HK": "Asia/Hong_Kong", "IE": "Europe/Dublin", "IT": "Europe/Rome",
+	"JP": "Asia/Tokyo", "KR": "Asia/Seoul", "NO": "Europe/Oslo", "NZ": "Pacific/Auckland",
+	"PH": "Asia/Manila", "PL": "Europe/Warsaw", "PT": "Europe/Lisbon", "RU": "Europe/Moscow",
+	"SE": "Europe/Stockholm", "SG": "Asia/Singapore", "TR": "Europe/Istanbul", "TW": "Asia/Taipei",
+	"UK": "Europe/London", "VE": "America/Caracas", "ZA": "Africa/Johannesburg",
+}
+
+// mexicanStateTimeZones maps the region codes of mexicanStates to their
+// predominant IANA time zone. They are kept apart from regionTimeZones
+// since some codes are also US state or Canadian province codes.
+var mexicanStateTimeZones = map[string]string{
+	"AG": "America/Mexico_City", "BC": "America/Tijuana", "BS": "America/Mazatlan", "CM": "America/Merida",
+	"CS": "America/Mexico_City", "CH": "America/Chihuahua", "DF": "America/Mexico_City", "CO": "America/Monterrey",
+	"CL": "America/Mexico_City", "DG": "America/Monterrey", "EM": "America/Mexico_City", "GT": "America/Mexico_City",
+	"GR": "America/Mexico_City", "HG": "America/Mexico_City", "JA": "America/Mexico_City", "MI": "America/Mexico_City",
+	"MO": "America/Mexico_City", "NA": "America/Mazatlan", "NL": "America/Monterrey", "OA": "America/Mexico_City",
+	"PU": "America/Mexico_City", "QT": "America/Mexico_City", "QR": "America/Cancun", "SL": "America/Mexico_City",
+	"SI": "America/Mazatlan", "SO": "America/Hermosillo", "TB": "America/Mexico_City", "TM": "America/Monterrey",
+	"TL": "America/Mexico_City", "VE": "America/Mexico_City", "YU": "America/Merida", "ZA": "America/Mexico_City",
+}
+
+// placeTimeZones maps places to their IANA time zone where it differs from
+// the predominant zone of their region.
+var placeTimeZones = map[string]map[string]string{
+	// Format: place -> region -> zone
+	"ELPS": {"TX": "America/Denver"},
+}
+
+// locations caches loaded time zones by name.
+var locations sync.Map
+
+// TimeZone returns the IANA time zone of the CLLI's central office, from
+// its place where known and otherwise its region, for localizing
+// maintenance windows. Regions registered with a TimeZone supply their own
+// zone. Returns nil if the zone is not known or cannot be loaded.
+func (c *CLLI) TimeZone() *time.Location {
+	name := placeTimeZones[c.Place][c.Region]
+	if name == "" {
+		info, _ := lookupRegion(c.Region)
+		name = info.TimeZone
+	}
+	if name == "" {
+		return nil
+	}
+	return loadLocation(name)
+}
+
+// loadLocation returns the named time zone, loading it on first use.
+func loadLocation(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	locations.Store(name, loc)
+	return loc
+}
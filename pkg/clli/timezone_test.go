@@ -0,0 +1,63 @@
+//go:build !clli_nogeodata
+
+package clli
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTimeZone tests resolving time zones by place and region
+func TestTimeZone(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"CHCGIL01DS0", "America/Chicago"},
+		{"NYCMNY01DS0", "America/New_York"},
+		{"PHNXAZ01DS0", "America/Phoenix"},
+		{"DLLSTX01DS0", "America/Chicago"},
+		{"ELPSTX01DS0", "America/Denver"},
+		{"STJHNL01DS0", "America/St_Johns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			loc := MustParse(tt.code).TimeZone()
+			require.NotNil(t, loc)
+			assert.Equal(t, tt.expected, loc.String())
+		})
+	}
+
+	c, err := ParseWithOptions("LNDNUK01DS0", &ParseOptions{AllowInternational: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/London", c.TimeZone().String())
+
+	c, err = ParseWithOptions("CHCGZZ01DS0", &ParseOptions{AllowUnknownRegion: true})
+	require.NoError(t, err)
+	assert.Nil(t, c.TimeZone())
+
+	// Registered regions supply their own zone
+	require.NoError(t, DefaultRegionRegistry().Register(RegionInfo{Code: "ZX", TimeZone: "Asia/Tokyo"}))
+	defer DefaultRegionRegistry().Unregister("ZX")
+	assert.Equal(t, "Asia/Tokyo", MustParse("LABSZX01DS0").TimeZone().String())
+}
+
+// TestRegionTimeZones tests that every geographic region has a loadable zone
+func TestRegionTimeZones(t *testing.T) {
+	regions := slices.Concat(DefaultRegionRegistry().Regions(), InternationalRegionRegistry().Regions(), MexicanStateRegistry().Regions(), CountryRegistry().Regions())
+	for _, info := range regions {
+		if info.Subdivision == "military" {
+			continue
+		}
+		assert.NotEmpty(t, info.TimeZone, info.Code)
+		assert.NotNil(t, loadLocation(info.TimeZone), info.Code)
+	}
+	for place, zones := range placeTimeZones {
+		for region, zone := range zones {
+			assert.NotNil(t, loadLocation(zone), place+region)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package clli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tracef writes one line of a parse trace to w.
+func tracef(w io.Writer, format string, args ...any) {
+	fmt.Fprintf(w, format+"\n", args...)
+}
+
+// traceResult writes the outcome of a parse to w, naming the components
+// accepted without passing validation.
+func traceResult(w io.Writer, c *CLLI, err error) {
+	if err != nil {
+		tracef(w, "rejected: %v", err)
+		return
+	}
+	invalid := c.InvalidFields()
+	if len(invalid) == 0 {
+		tracef(w, "parsed as %s", c.Type())
+		return
+	}
+	names := make([]string, len(invalid))
+	for i, kind := range invalid {
+		names[i] = kind.String()
+	}
+	tracef(w, "parsed as %s with invalid %s", c.Type(), strings.Join(names, ", "))
+}
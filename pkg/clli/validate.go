@@ -0,0 +1,160 @@
+package clli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity grades a validation issue.
+type Severity string
+
+// Validation issue severities
+const (
+	// SeverityError issues make the input invalid
+	SeverityError Severity = "error"
+
+	// SeverityWarning issues are accepted by the parser but worth review,
+	// such as reserved entity codes and the nonconforming components
+	// accepted by non-strict parsing
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue is one problem found by Validate.
+type ValidationIssue struct {
+	Severity Severity
+	Field    string // Component name, as in ParseError
+	Position int    // Position of the component in the normalized input (0-based)
+	Err      error  // Sentinel such as ErrInvalidPlace, wrapping the detail
+}
+
+// String returns the severity, component and problem, e.g.
+// "error: region at position 4: invalid region code: invalid region code: ZZ".
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s at position %d: %v", i.Severity, i.Field, i.Position, i.Err)
+}
+
+// ValidationReport is the result of Validate: every issue found in an
+// input, in position order, and the parsed CLLI if the input is valid.
+type ValidationReport struct {
+	Input  string
+	CLLI   *CLLI // Parsed code, or nil if the input has errors
+	Issues []ValidationIssue
+}
+
+// Valid reports whether the input has no error-severity issues.
+func (r *ValidationReport) Valid() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the error-severity issues as ParseErrors joined with
+// errors.Join, or nil if the input is valid.
+func (r *ValidationReport) Err() error {
+	var errs []error
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, &ParseError{Input: r.Input, Position: issue.Position, Field: issue.Field, Err: issue.Err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks input against the same rules as ParseWithOptions but,
+// instead of stopping at the first failure, reports every component error
+// at once, for data-cleaning workflows that need the full picture per
+// record. Nil opts selects the ParseWithOptions defaults.
+func Validate(input string, opts *ParseOptions) *ValidationReport {
+	if opts == nil {
+		opts = &ParseOptions{Strict: true, NormalizeCase: true, TrimWhitespace: true}
+	}
+	report := &ValidationReport{Input: input}
+	add := func(severity Severity, field string, position int, err error) {
+		report.Issues = append(report.Issues, ValidationIssue{Severity: severity, Field: field, Position: position, Err: err})
+	}
+
+	if strings.TrimSpace(input) == "" {
+		add(SeverityError, "input", 0, ErrEmptyInput)
+		return report
+	}
+	defer func() { sortIssues(report.Issues) }()
+
+	s := input
+	if opts.TrimWhitespace {
+		s = strings.TrimSpace(s)
+	}
+	if opts.NormalizeCase {
+		s = strings.ToUpper(s)
+	}
+
+	lengthOK := len(s) <= 15 && (len(s) >= 8 || !opts.Strict && len(s) >= 4)
+	if !lengthOK {
+		add(SeverityError, "length", 0, ErrInvalidCLLI)
+	}
+	for i, r := range s {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') && !(r == ' ' && !opts.Strict && i > 0 && i < 4) {
+			add(SeverityError, "characters", i, ErrInvalidCLLI)
+		}
+	}
+
+	// Components that non-strict parsing accepts are reported as warnings
+	lenient := func(value string) Severity {
+		if !opts.Strict && isAlpha(value) {
+			return SeverityWarning
+		}
+		return SeverityError
+	}
+	place := s[:min(len(s), 4)]
+	if err := validatePlace(place); err != nil {
+		add(lenient(strings.TrimRight(place, " ")), "place", 0, fmt.Errorf("%w: %w", ErrInvalidPlace, err))
+	}
+	if len(s) > 4 {
+		region := s[4:min(len(s), 6)]
+		err := validateRegionIn(region, opts.regionRegistry())
+		if _, international := internationalRegionRegistry.Lookup(region); err != nil && !(opts.AllowInternational && international) {
+			add(lenient(region), "region", 4, fmt.Errorf("%w: %w", ErrInvalidRegion, err))
+		}
+	}
+
+	if !lengthOK || len(s) <= 6 {
+		return report
+	}
+
+	// The rest of the code is validated by parsing it behind a known-valid
+	// place and region, so its errors are found even when those fail
+	var pe *ParseError
+	if _, err := parseInput("XXXXIL"+s[6:], opts); errors.As(err, &pe) && pe.Position >= 6 && pe.Field != "characters" {
+		add(SeverityError, pe.Field, pe.Position, pe.Err)
+	}
+
+	if report.Valid() {
+		c, err := ParseWithOptions(s, opts)
+		if err != nil {
+			// Any failure not attributed to a component above
+			if errors.As(err, &pe) {
+				add(SeverityError, pe.Field, pe.Position, pe.Err)
+			}
+			return report
+		}
+		report.CLLI = c
+		if !c.FieldValid(ComponentEntityCode) {
+			add(SeverityWarning, "entity_code", 8, fmt.Errorf("%w: %w", ErrInvalidEntity, validateEntityCode(c.EntityCode)))
+		}
+		if c.IsReservedEntity() {
+			info, _ := c.EntityCategory()
+			add(SeverityWarning, "entity_code", 8, errors.New(info.Description))
+		}
+	}
+	return report
+}
+
+// sortIssues orders issues by position, keeping the order they were found
+// in for issues at the same position.
+func sortIssues(issues []ValidationIssue) {
+	slices.SortStableFunc(issues, func(a, b ValidationIssue) int { return a.Position - b.Position })
+}
@@ -0,0 +1,62 @@
+package clli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate tests reporting every component error at once
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     *ParseOptions
+		expected []string // Field of each issue, in order
+		valid    bool
+	}{
+		{"valid", "CHCGIL01DS0", nil, nil, true},
+		{"place and region", "CH1GZZ01DS0", nil, []string{"place", "region"}, false},
+		{"place, region and entity", "CH1GZZ01DQ0", nil, []string{"place", "region", "entity_code"}, false},
+		{"characters", "CHCG-L01DS0", nil, []string{"characters", "region"}, false},
+		{"length", "CHCGIL0", nil, []string{"length"}, false},
+		{"empty", "  ", nil, []string{"input"}, false},
+		{"unknown region warning", "CHCGZZ01DS0", &ParseOptions{AllowUnknownRegion: true}, []string{"region"}, true},
+		{"reserved entity warning", "CHCGIL01ZAZ", nil, []string{"entity_code"}, true},
+		{"lenient warnings", "RYE ZZ01QQQ", &ParseOptions{}, []string{"place", "region", "entity_code"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Validate(tt.input, tt.opts)
+			var fields []string
+			for _, issue := range report.Issues {
+				fields = append(fields, issue.Field)
+			}
+			assert.Equal(t, tt.expected, fields)
+			assert.Equal(t, tt.valid, report.Valid())
+			assert.Equal(t, tt.valid, report.CLLI != nil)
+			assert.Equal(t, tt.valid, report.Err() == nil)
+		})
+	}
+}
+
+// TestValidationReport tests issue details and the joined error
+func TestValidationReport(t *testing.T) {
+	report := Validate("ch1gzz01ds0", nil)
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, SeverityError, report.Issues[1].Severity)
+	assert.Equal(t, 4, report.Issues[1].Position)
+	assert.ErrorIs(t, report.Issues[0].Err, ErrInvalidPlace)
+	assert.ErrorIs(t, report.Issues[1].Err, ErrInvalidRegion)
+	assert.Equal(t, "error: region at position 4: invalid region code: invalid region code: ZZ", report.Issues[1].String())
+
+	err := report.Err()
+	assert.ErrorIs(t, err, ErrInvalidPlace)
+	assert.ErrorIs(t, err, ErrInvalidRegion)
+
+	report = Validate("CHCGIL01ZAZ", nil)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, SeverityWarning, report.Issues[0].Severity)
+	assert.Equal(t, "warning: entity_code at position 8: Reserved testing code (Z.Z)", report.Issues[0].String())
+}